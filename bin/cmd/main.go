@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/s3studio/cloud-bench-checker/pkg/auth"
+	"github.com/s3studio/cloud-bench-checker/pkg/connector"
 	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
 	"github.com/s3studio/cloud-bench-checker/pkg/framework"
 
@@ -26,6 +27,12 @@ var (
 	confFilePath = pflag.StringP("conf-file", "c", "", "File containing configs and baselines in yaml format")
 	tag          = pflag.StringSliceP("tag", "t", []string{"test"}, "Tags of which baselines to check")
 	showProgress = pflag.BoolP("show-progress", "p", true, "Show progress")
+	saveSnapshot = pflag.String("save-snapshot", "", "Directory to save snapshot of data from listor")
+	loadSnapshot = pflag.String("load-snapshot", "", "Directory to load snapshot of data from listor instead of the cloud")
+	recorderMode = pflag.String("recorder-mode", string(connector.RECORDER_OFF), "Mode of recording calls to the cloud: off, record or replay")
+	cassette     = pflag.String("cassette", "", "Cassette file to record calls to the cloud into or replay them from")
+	waiverFile   = pflag.String("waiver-file", "", "File of waivers to suppress accepted risks, instead of waiver_file in the conf file")
+	param        = pflag.StringArray("param", nil, "Param overriding dyn_validate_value of validators in the format of key=value, can be repeated")
 )
 
 // Add visibility management to pb.ProgressBar
@@ -89,6 +96,16 @@ func main() {
 		}
 	}
 
+	// Inspect for validators failing to compile
+	for i, b := range conf.Baseline {
+		for j := range b.Checker {
+			if err := framework.CheckValidatorConf(&b.Checker[j].Validator); err != nil {
+				log.Printf("Invalid validator of checker %d in baseline %d: %v\n", j, i, err)
+				os.Exit(-1)
+			}
+		}
+	}
+
 	// Inspect for duplicated id of listor
 	for i := range conf.Listor {
 		for j := 0; j < i; j++ {
@@ -98,10 +115,67 @@ func main() {
 		}
 	}
 
+	// Load waivers defined in conf file if not specified in command parameter
+	var waivers *framework.WaiverList
+	waiverConfPath := ""
+	if len(*waiverFile) == 0 && len(conf.Option.WaiverFile) > 0 {
+		*waiverFile = conf.Option.WaiverFile
+		waiverConfPath = *confFilePath
+	}
+	if len(*waiverFile) > 0 {
+		var err error
+		if waivers, err = framework.LoadWaiverFile(*waiverFile, waiverConfPath); err != nil {
+			log.Printf("Failed to load waivers from \"%s\": %v\n", *waiverFile, err)
+			os.Exit(-1)
+		}
+	}
+
+	validateParams, err := framework.ParseParams(*param)
+	if err != nil {
+		log.Printf("Invalid parameter param: %v\n", err)
+		os.Exit(-1)
+	}
+
+	if len(*saveSnapshot) > 0 && len(*loadSnapshot) > 0 {
+		log.Println("Parameters save-snapshot and load-snapshot can not be used together")
+		os.Exit(-1)
+	}
+	if (len(*saveSnapshot) > 0 || len(*loadSnapshot) > 0) && connector.RecorderMode(*recorderMode) != connector.RECORDER_OFF {
+		// Snapshot uses its own cassette for calls of extract_cmd
+		log.Println("Parameter recorder-mode can not be used together with save-snapshot or load-snapshot")
+		os.Exit(-1)
+	}
+
+	if connector.RecorderMode(*recorderMode) != connector.RECORDER_OFF {
+		if len(*cassette) == 0 {
+			log.Println("Parameter cassette is required when recorder-mode is not off")
+			os.Exit(-1)
+		}
+
+		recorder, err := connector.NewRecorder(connector.RecorderMode(*recorderMode), *cassette)
+		if err != nil {
+			log.Printf("Failed to create recorder: %v\n", err)
+			os.Exit(-1)
+		}
+		connector.SetRecorder(recorder)
+		defer func() {
+			if err := recorder.Save(); err != nil {
+				log.Printf("Failed to save cassette to \"%s\": %v\n", *cassette, err)
+			}
+		}()
+	}
+
 	if conf.Option.PageSize >= 10 {
 		framework.SetPageSize(conf.Option.PageSize)
 	}
+	if conf.Option.K8sDiscoveryInterval > 0 {
+		connector.SetK8sDiscoveryInterval(time.Duration(conf.Option.K8sDiscoveryInterval) * time.Second)
+	}
 	authProvider := auth.NewAuthFileProvider(conf.Profile)
+	if len(*loadSnapshot) > 0 {
+		// Run offline without any profile of the cloud
+		authProvider = auth.NewAuthFileProvider(def.ConfProfile{})
+	}
 
 	// Create baselines with tags specified in command parameter
 	var baseline []*framework.Baseline
@@ -144,48 +218,86 @@ func main() {
 	}
 	bar1.Finish()
 
-	// Create listor and get raw data
-	bar2 := newPb(*showProgress, len(idListor), "Get data from listor")
-	bar2.Start()
-	var wg2 sync.WaitGroup
-	wg2.Add(len(idListor))
-	mapRawData := &framework.SyncMapDataProvider{}
-
+	// Create listor
+	var listor []*framework.Listor
+	var listorConf []*def.ConfListor
 	for _, id := range idListor {
-		go func(target *framework.SyncMapDataProvider) {
-			defer func() {
-				bar2.Increment()
-				wg2.Done()
-			}()
+		bFound := false
+		for _, c := range conf.Listor {
+			if c.Id == id {
+				listor = append(listor, framework.NewListor(&c, authProvider))
+				listorConf = append(listorConf, &c)
+				bFound = true
+				break
+			}
+		}
 
-			bFound := false
-			for _, c := range conf.Listor {
-				if c.Id == id {
-					listor := framework.NewListor(&c, authProvider)
-					rawData, err := listor.ListData()
-					if err != nil {
-						log.Println(err)
-					} else if len(rawData) > 0 {
-						target.DataMap.Store(id, rawData)
-						target.CtMap.Store(id, string(c.CloudType))
-					}
+		if !bFound {
+			log.Printf("failed to find listor with id of %d, please check the conf file\n", id)
+		}
+	}
 
-					bFound = true
-					break
-				}
+	var dataProvider framework.IDataProvider
+	var snapshotRecorder *connector.Recorder
+	if len(*loadSnapshot) > 0 {
+		// Get raw data from snapshot
+		snapshot, err := framework.LoadSnapshot(*loadSnapshot, listor)
+		if err != nil {
+			log.Printf("Failed to load snapshot from \"%s\": %v\n", *loadSnapshot, err)
+			os.Exit(-1)
+		}
+		for _, entry := range snapshot.Manifest.Listor {
+			if len(entry.Error) > 0 {
+				log.Printf("Listor %d failed to list data when the snapshot was collected: %s\n", entry.Id, entry.Error)
 			}
+		}
+		dataProvider = snapshot
+		// Replay calls of extract_cmd from the snapshot
+		connector.SetRecorder(snapshot.Recorder)
+	} else {
+		// Get raw data from listor
+		bar2 := newPb(*showProgress, len(listor), "Get data from listor")
+		bar2.Start()
+		var wg2 sync.WaitGroup
+		wg2.Add(len(listor))
+		mapRawData := &framework.SyncMapDataProvider{}
+
+		for i, l := range listor {
+			go func(target *framework.SyncMapDataProvider) {
+				defer func() {
+					bar2.Increment()
+					wg2.Done()
+				}()
+
+				rawData, err := l.ListData()
+				if err != nil {
+					log.Println(err)
+					// Kept to be recorded in the snapshot
+					target.ErrMap.Store(listorConf[i].Id, err)
+				} else if len(rawData) > 0 {
+					target.DataMap.Store(listorConf[i].Id, rawData)
+					target.CtMap.Store(listorConf[i].Id, string(listorConf[i].CloudType))
+				}
+			}(mapRawData)
+		}
+
+		wg2.Wait()
+		bar2.Finish()
+		dataProvider = mapRawData
 
-			if !bFound {
-				log.Printf("failed to find listor with id of %d, please check the conf file\n", id)
+		if len(*saveSnapshot) > 0 {
+			// Record calls of extract_cmd into the snapshot
+			var err error
+			if snapshotRecorder, err = framework.NewSnapshotRecorder(*saveSnapshot); err != nil {
+				log.Printf("Failed to save snapshot to \"%s\": %v\n", *saveSnapshot, err)
+				os.Exit(-1)
 			}
-		}(mapRawData)
+			connector.SetRecorder(snapshotRecorder)
+		}
 	}
 
-	wg2.Wait()
-	bar2.Finish()
-
 	for _, b := range baseline {
-		b.SetDataProvider(mapRawData)
+		b.SetDataProvider(dataProvider)
 	}
 
 	// Extract prop
@@ -209,6 +321,12 @@ func main() {
 	wg3.Wait()
 	bar3.Finish()
 
+	if len(*saveSnapshot) > 0 {
+		if err := framework.SaveSnapshot(*saveSnapshot, listor, dataProvider, snapshotRecorder); err != nil {
+			log.Printf("Failed to save snapshot to \"%s\": %v\n", *saveSnapshot, err)
+		}
+	}
+
 	// Validate prop
 	bar4 := newPb(*showProgress, len(baseline), "Validate prop")
 	bar4.Start()
@@ -219,10 +337,13 @@ func main() {
 	}
 	result := make([]overallResult, 0, len(baseline))
 	for i, b := range baseline {
-		resBaseline, err := b.Validate(listProp[i])
+		resBaseline, err := b.Validate(listProp[i], framework.SetParamsOpt(validateParams))
 		if err != nil {
 			log.Println(err)
 		} else if len(resBaseline) > 0 {
+			for _, warning := range waivers.Apply(b, resBaseline) {
+				log.Printf("Warning: %s\n", warning)
+			}
 			result = append(result, overallResult{
 				baseline: b, res: resBaseline,
 			})
@@ -236,14 +357,26 @@ func main() {
 	var outputData []map[string]string
 	for _, eachResBaseline := range result {
 		for _, eachRes := range eachResBaseline.res {
-			if eachRes.InRisk || !conf.Option.OutputRiskOnly {
+			// Errors are always outputted, as the resource may be in risk, and so are risks waived
+			if eachRes.InRisk || eachRes.Outcome == framework.OUTCOME_ERROR || eachRes.Outcome == framework.OUTCOME_WAIVED ||
+				!conf.Option.OutputRiskOnly {
 				singleOutputData := map[string]string{
 					"Cloud Type":    string(eachRes.CloudType),
 					"Resource Id":   eachRes.Id,
 					"Resource Name": eachRes.Name,
+					"Region":        eachRes.Region,
+					"Subscription":  eachRes.Subscription,
+					"K8s Context":   eachRes.K8sContext,
 					"Actual Value":  eachRes.Value,
+					"Outcome":       string(eachRes.Outcome),
+					"Error":         eachRes.Error,
+					"Reason":        eachRes.Reason,
+					"Waiver":        eachRes.Waiver,
 				}
-				if eachRes.InRisk {
+				if eachRes.Outcome == framework.OUTCOME_NOT_APPLICABLE || eachRes.Outcome == framework.OUTCOME_ERROR ||
+					eachRes.Outcome == framework.OUTCOME_WAIVED {
+					singleOutputData["Resource in risk"] = "N/A"
+				} else if eachRes.InRisk {
 					singleOutputData["Resource in risk"] = "True"
 				} else {
 					singleOutputData["Resource in risk"] = "False"
@@ -287,7 +420,7 @@ func main() {
 				file.WriteString("\xEF\xBB\xBF")                      // UTF8-BOM for Excel
 				regNum := regexp.MustCompile(`^(\d*\.)?\d+(\.\d*)?$`) // Check numberic value
 
-				header := []string{"Cloud Type", "Resource Id", "Resource Name", "Resource in risk", "Actual Value"}
+				header := []string{"Cloud Type", "Resource Id", "Resource Name", "Region", "Subscription", "K8s Context", "Resource in risk", "Outcome", "Actual Value", "Reason", "Waiver", "Error"}
 				for _, key := range conf.Option.OutputMetadata {
 					if regNum.MatchString(key) {
 						key = fmt.Sprintf("=\"%s\"", key) // Avoid item to be convert to integer
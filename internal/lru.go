@@ -0,0 +1,102 @@
+// LRU cache util
+
+package internal
+
+import (
+	"container/list"
+	"sync"
+)
+
+// LruCache[T]: Generics type of cache bounded by the number of objects, evicting the least recently used one
+type LruCache[T any] struct {
+	// Max number of objects in the cache
+	capacity int
+	// Mutex of list and items
+	mu sync.Mutex
+	// List of entries, with the most recently used one at front
+	list *list.List
+	// Mapping from key to the element of list
+	items map[string]*list.Element
+}
+
+// lruEntry: Entry of LruCache stored in the element of list
+type lruEntry struct {
+	key string
+	val any
+}
+
+// NewLruCache: Constructor of LruCache
+// @param: capacity: Max number of objects in the cache, at least 1
+func NewLruCache[T any](capacity int) *LruCache[T] {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &LruCache[T]{capacity: capacity, list: list.New(), items: make(map[string]*list.Element)}
+}
+
+// LoadOrCreate: Load an object of type T, and create a new one if it does not exist in the cache.
+// @param: key: Key of object in the cache
+// @param: fnCreate: Function to create an object, normally a closure
+// @param: nilVal: Nil value if fnCreate failed. Generics type does not support return nil as T
+// @return: Actual object of type T loaded or created
+// @return: Error
+func (c *LruCache[T]) LoadOrCreate(key string, fnCreate func() (any, error), nilVal T) (T, error) {
+	val, ok := c.load(key)
+	if !ok {
+		// Created without holding the lock, as it may take a while
+		newVal, err := fnCreate()
+		if err != nil {
+			return nilVal, err
+		}
+		// May have already been created by other goroutions,
+		// but it's ok to spend a little more time creating them
+		val = c.loadOrStore(key, newVal)
+	}
+
+	tVal, ok := val.(T)
+	if !ok {
+		panic("internal error, not a valid type in LruCache")
+	}
+	return tVal, nil
+}
+
+// Len: Get the number of objects in the cache
+// @return: Number of objects
+func (c *LruCache[T]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.list.Len()
+}
+
+// load: Load the object and mark it as the most recently used one
+func (c *LruCache[T]) load(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.list.MoveToFront(elem)
+	return elem.Value.(*lruEntry).val, true
+}
+
+// loadOrStore: Load the existing object, or store the given one and evict the least recently used one if full
+func (c *LruCache[T]) loadOrStore(key string, val any) any {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		c.list.MoveToFront(elem)
+		return elem.Value.(*lruEntry).val
+	}
+
+	c.items[key] = c.list.PushFront(&lruEntry{key: key, val: val})
+	for c.list.Len() > c.capacity {
+		oldest := c.list.Back()
+		c.list.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry).key)
+	}
+	return val
+}
@@ -0,0 +1,92 @@
+// LRU cache util
+
+package internal
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestLruCache_LoadOrCreate(t *testing.T) {
+	mockKey := "mock"
+	mockVal := 42
+
+	type args struct {
+		key      string
+		fnCreate func() (any, error)
+		nilVal   int
+	}
+	tests := []struct {
+		name    string
+		c       *LruCache[int]
+		args    args
+		want    int
+		wantErr bool
+	}{
+		{
+			"Valid result with generics type of int",
+			NewLruCache[int](1),
+			args{
+				mockKey,
+				func() (any, error) { return mockVal, nil },
+				0,
+			},
+			mockVal,
+			false,
+		},
+		{
+			"failed to create",
+			NewLruCache[int](1),
+			args{
+				"invalid",
+				func() (any, error) { return nil, errors.New("mock error") },
+				0,
+			},
+			0,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.c.LoadOrCreate(tt.args.key, tt.args.fnCreate, tt.args.nilVal)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LruCache.LoadOrCreate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LruCache.LoadOrCreate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLruCache_evict(t *testing.T) {
+	c := NewLruCache[int](2)
+	created := 0
+	fnCreate := func(val int) func() (any, error) {
+		return func() (any, error) {
+			created++
+			return val, nil
+		}
+	}
+
+	c.LoadOrCreate("a", fnCreate(1), 0)
+	c.LoadOrCreate("b", fnCreate(2), 0)
+	// "a" is used recently, so "b" is evicted by "c"
+	c.LoadOrCreate("a", fnCreate(1), 0)
+	c.LoadOrCreate("c", fnCreate(3), 0)
+	if created != 3 {
+		t.Errorf("LruCache.LoadOrCreate() created %d objects, want 3", created)
+	}
+	if c.Len() != 2 {
+		t.Errorf("LruCache.Len() = %d, want 2", c.Len())
+	}
+
+	if got, _ := c.LoadOrCreate("a", fnCreate(-1), 0); got != 1 {
+		t.Errorf("LruCache.LoadOrCreate() = %d, want 1 not evicted", got)
+	}
+	if got, _ := c.LoadOrCreate("b", fnCreate(-2), 0); got != -2 {
+		t.Errorf("LruCache.LoadOrCreate() = %d, want -2 created again after evicted", got)
+	}
+}
@@ -288,6 +288,16 @@ func init() {
             "name": "metadata",
             "in": "query"
           },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "Params overriding dyn_validate_value of validators, in the format of key=value",
+            "name": "param",
+            "in": "query"
+          },
           {
             "type": "boolean",
             "default": false,
@@ -576,7 +586,11 @@ func init() {
         "aliyun",
         "aliyun_oss",
         "k8s",
-        "azure"
+        "azure",
+        "aws",
+        "gcp",
+        "openstack",
+        "http_api"
       ]
     },
     "error_response": {
@@ -653,12 +667,23 @@ func init() {
           "type": "string",
           "x-omitempty": false
         },
+        "error_message": {
+          "type": "string"
+        },
         "metadata": {
           "type": "object",
           "additionalProperties": {
             "type": "string"
           }
         },
+        "outcome": {
+          "description": "One of pass, fail, not_applicable, error and waived",
+          "type": "string",
+          "x-omitempty": false
+        },
+        "reason": {
+          "type": "string"
+        },
         "resource_id": {
           "type": "string",
           "x-omitempty": false
@@ -667,9 +692,21 @@ func init() {
           "type": "boolean",
           "x-omitempty": false
         },
+        "resource_k8s_context": {
+          "type": "string"
+        },
         "resource_name": {
           "type": "string",
           "x-omitempty": false
+        },
+        "resource_region": {
+          "type": "string"
+        },
+        "resource_subscription": {
+          "type": "string"
+        },
+        "waiver": {
+          "type": "string"
         }
       }
     }
@@ -986,6 +1023,16 @@ func init() {
             "name": "metadata",
             "in": "query"
           },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "Params overriding dyn_validate_value of validators, in the format of key=value",
+            "name": "param",
+            "in": "query"
+          },
           {
             "type": "boolean",
             "default": false,
@@ -1289,7 +1336,11 @@ func init() {
         "aliyun",
         "aliyun_oss",
         "k8s",
-        "azure"
+        "azure",
+        "aws",
+        "gcp",
+        "openstack",
+        "http_api"
       ]
     },
     "error_response": {
@@ -1366,12 +1417,23 @@ func init() {
           "type": "string",
           "x-omitempty": false
         },
+        "error_message": {
+          "type": "string"
+        },
         "metadata": {
           "type": "object",
           "additionalProperties": {
             "type": "string"
           }
         },
+        "outcome": {
+          "description": "One of pass, fail, not_applicable, error and waived",
+          "type": "string",
+          "x-omitempty": false
+        },
+        "reason": {
+          "type": "string"
+        },
         "resource_id": {
           "type": "string",
           "x-omitempty": false
@@ -1380,9 +1442,21 @@ func init() {
           "type": "boolean",
           "x-omitempty": false
         },
+        "resource_k8s_context": {
+          "type": "string"
+        },
         "resource_name": {
           "type": "string",
           "x-omitempty": false
+        },
+        "resource_region": {
+          "type": "string"
+        },
+        "resource_subscription": {
+          "type": "string"
+        },
+        "waiver": {
+          "type": "string"
         }
       }
     }
@@ -9,11 +9,13 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/s3studio/cloud-bench-checker/internal"
 	"github.com/s3studio/cloud-bench-checker/internal/server/operations"
 	"github.com/s3studio/cloud-bench-checker/internal/server/operations/baseline"
 	"github.com/s3studio/cloud-bench-checker/internal/server/operations/listor"
+	"github.com/s3studio/cloud-bench-checker/pkg/connector"
 	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
 	"github.com/s3studio/cloud-bench-checker/pkg/framework"
 	"github.com/s3studio/cloud-bench-checker/pkg/server_model"
@@ -44,6 +46,7 @@ const CONF_FILE_NAME = "config.conf"
 var (
 	_conf      def.ConfFile
 	_confValid bool = false
+	_waivers   *framework.WaiverList
 
 	_mapInstance internal.SyncMap[any]
 )
@@ -84,8 +87,9 @@ func getBaselineHash(id int) (*[]byte, error) {
 		bConf := &_conf.Baseline[id]
 		listorHash := make([][]*[]byte, len(bConf.Checker))
 		for i, c := range bConf.Checker {
-			listorHash[i] = make([]*[]byte, len(c.Listor))
-			for j, id := range c.Listor {
+			listorIds := framework.GetCheckerListorId(&c)
+			listorHash[i] = make([]*[]byte, len(listorIds))
+			for j, id := range listorIds {
 				eachHash, err := getListorHash(id)
 				if err != nil {
 					return nil, err
@@ -151,6 +155,26 @@ func prepareHandler(api *operations.CloudBenchCheckerAPIAPI) {
 		}
 	}
 
+	for i, b := range _conf.Baseline {
+		for j := range b.Checker {
+			if err := framework.CheckValidatorConf(&b.Checker[j].Validator); err != nil {
+				logf(api.Logger, "Invalid validator of checker %d in baseline %d: %v\n", j, i, err)
+				return
+			}
+		}
+	}
+
+	if len(_conf.Option.WaiverFile) > 0 {
+		if _waivers, err = framework.LoadWaiverFile(_conf.Option.WaiverFile, confFilePath); err != nil {
+			logf(api.Logger, "Failed to load waivers from \"%s\": %v\n", _conf.Option.WaiverFile, err)
+			return
+		}
+	}
+
+	if _conf.Option.K8sDiscoveryInterval > 0 {
+		connector.SetK8sDiscoveryInterval(time.Duration(_conf.Option.K8sDiscoveryInterval) * time.Second)
+	}
+
 	_confValid = true
 }
 
@@ -464,7 +488,7 @@ func baselinePostBaselineGetPropHandler(params baseline.PostBaselineGetPropParam
 
 	dataProvider := listDataProvider{params.ListorData}
 	for _, c := range b.Checker {
-		for _, id := range c.Listor {
+		for _, id := range framework.GetCheckerListorId(&c) {
 			if provideCloudType, err := dataProvider.GetCloudTypeByListorId(id); err != nil {
 				return baseline.NewPostBaselineGetPropBadRequest().WithPayload(
 					generalError{Code: 400, Msg: fmt.Sprintf("%v", err)})
@@ -591,22 +615,39 @@ func baselinePostBaselineValidateHandler(params baseline.PostBaselineValidatePar
 		data[i] = provideData
 	}
 
-	resBaseline, err := bIns.Validate(data)
+	validateParams, err := framework.ParseParams(params.Param)
+	if err != nil {
+		return baseline.NewPostBaselineValidateBadRequest().WithPayload(
+			generalError{Code: 400, Msg: fmt.Sprintf("%v", err)})
+	}
+
+	resBaseline, err := bIns.Validate(data, framework.SetParamsOpt(validateParams))
 	if err != nil {
 		return baseline.NewPostBaselineValidateBadRequest().WithPayload(
 			generalError{Code: 400, Msg: fmt.Sprintf("Failed in Validate: %v", err)})
 	}
+	for _, warning := range _waivers.Apply(bIns, resBaseline) {
+		logf(nil, "Warning: %s\n", warning)
+	}
 
 	data4api := make([]*server_model.ValidateResult, 0)
 	for _, res := range resBaseline {
-		if res.InRisk || params.RiskOnly == nil || !*params.RiskOnly {
+		if res.InRisk || res.Outcome == framework.OUTCOME_ERROR || res.Outcome == framework.OUTCOME_WAIVED ||
+			params.RiskOnly == nil || !*params.RiskOnly {
 			singleOutputData := server_model.ValidateResult{
-				CloudType:      string(res.CloudType),
-				ResourceID:     res.Id,
-				ResourceName:   res.Name,
-				ActualValue:    res.Value,
-				ResourceInRisk: res.InRisk,
-				Metadata:       make(map[string]string),
+				CloudType:            string(res.CloudType),
+				ResourceID:           res.Id,
+				ResourceName:         res.Name,
+				ResourceRegion:       res.Region,
+				ResourceSubscription: res.Subscription,
+				ResourceK8sContext:   res.K8sContext,
+				ActualValue:          res.Value,
+				ResourceInRisk:       res.InRisk,
+				Outcome:              string(res.Outcome),
+				ErrorMessage:         res.Error,
+				Reason:               res.Reason,
+				Waiver:               res.Waiver,
+				Metadata:             make(map[string]string),
 			}
 
 			for _, key := range params.Metadata {
@@ -58,6 +58,11 @@ type PostBaselineValidateParams struct {
 	  Collection Format: multi
 	*/
 	Metadata []string
+	/*Params overriding dyn_validate_value of validators, in the format of key=value
+	  In: query
+	  Collection Format: multi
+	*/
+	Param []string
 	/*Whether only returns cloud resources in risk (failing the benchmark check)
 	  In: query
 	  Default: false
@@ -114,6 +119,11 @@ func (o *PostBaselineValidateParams) BindRequest(r *http.Request, route *middlew
 		res = append(res, err)
 	}
 
+	qParam, qhkParam, _ := qs.GetOK("param")
+	if err := o.bindParam(qParam, qhkParam, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qRiskOnly, qhkRiskOnly, _ := qs.GetOK("risk_only")
 	if err := o.bindRiskOnly(qRiskOnly, qhkRiskOnly, route.Formats); err != nil {
 		res = append(res, err)
@@ -172,6 +182,28 @@ func (o *PostBaselineValidateParams) bindMetadata(rawData []string, hasKey bool,
 	return nil
 }
 
+// bindParam binds and validates array parameter Param from query.
+//
+// Arrays are parsed according to CollectionFormat: "multi" (defaults to "csv" when empty).
+func (o *PostBaselineValidateParams) bindParam(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	// CollectionFormat: multi
+	paramIC := rawData
+	if len(paramIC) == 0 {
+		return nil
+	}
+
+	var paramIR []string
+	for _, paramIV := range paramIC {
+		paramI := paramIV
+
+		paramIR = append(paramIR, paramI)
+	}
+
+	o.Param = paramIR
+
+	return nil
+}
+
 // bindRiskOnly binds and validates parameter RiskOnly from query.
 func (o *PostBaselineValidateParams) bindRiskOnly(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
@@ -17,6 +17,7 @@ import (
 type PostBaselineValidateURL struct {
 	ID       int64
 	Metadata []string
+	Param    []string
 	RiskOnly *bool
 
 	_basePath string
@@ -72,6 +73,20 @@ func (o *PostBaselineValidateURL) Build() (*url.URL, error) {
 		qs.Add("metadata", qsv)
 	}
 
+	var paramIR []string
+	for _, paramI := range o.Param {
+		paramIS := paramI
+		if paramIS != "" {
+			paramIR = append(paramIR, paramIS)
+		}
+	}
+
+	param := swag.JoinByFormat(paramIR, "multi")
+
+	for _, qsv := range param {
+		qs.Add("param", qsv)
+	}
+
 	var riskOnlyQ string
 	if o.RiskOnly != nil {
 		riskOnlyQ = swag.FormatBool(*o.RiskOnly)
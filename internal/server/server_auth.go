@@ -112,3 +112,11 @@ func (p *serverAuthProvider) GetProfilePathname(cloudType def.CloudType) (string
 
 	return filepath.Join(binDir, ".auth", p.profile), nil
 }
+
+// GetProfileName: Implementation of IProfileNameProvider.GetProfileName
+// @param: cloudType: Type of the cloud, omitted in this implementation of IAuthProvider
+// @return: Name of profile
+// @return: Error
+func (p *serverAuthProvider) GetProfileName(_ def.CloudType) (string, error) {
+	return p.profile, nil
+}
@@ -29,6 +29,16 @@ type IAuthProvider interface {
 	GetProfilePathname(cloudType def.CloudType) (string, error)
 }
 
+// IProfileNameProvider: Optional interface of IAuthProvider that provides the name of profile,
+// used to identify the account when no resource identifier is available
+type IProfileNameProvider interface {
+	// GetProfileName: Get name of profile for the cloud
+	// @param: cloudType: Type of the cloud
+	// @return: Name of profile
+	// @return: Error
+	GetProfileName(cloudType def.CloudType) (string, error)
+}
+
 // AuthFileProvider: Implementation of IAuthProvider using files in the ".auth" subdirectory
 type AuthFileProvider struct {
 	// Definition of profile
@@ -53,6 +63,10 @@ var _mapCloudTypeToName = map[def.CloudType]string{
 	def.ALIYUN_OSS:    "aliyun",
 	def.K8S:           "k8s",
 	def.AZURE:         "azure",
+	def.AWS:           "aws",
+	def.GCP:           "gcp",
+	def.OPENSTACK:     "openstack",
+	def.HTTP_API:      "http_api",
 }
 
 // ProfileNotDefinedError: Error of profile not defined
@@ -69,6 +83,18 @@ func (e ProfileNotDefinedError) Error() string {
 	return fmt.Sprintf("no profile defined for cloud: %s", e.key)
 }
 
+// NewProfileNotDefinedError: Constructor of ProfileNotDefinedError,
+// used by other providers of profile, e.g. snapshot recording the names of profile
+// @param: cloudType: Type of the cloud
+func NewProfileNotDefinedError(cloudType def.CloudType) ProfileNotDefinedError {
+	key, ok := _mapCloudTypeToName[cloudType]
+	if !ok {
+		key = string(cloudType)
+	}
+
+	return ProfileNotDefinedError{key}
+}
+
 // GetProfile: Implementation of IAuthProvider.GetProfile
 // @param: cloudType: Type of the cloud
 // @return: Profile that can be accessed as Viper
@@ -119,6 +145,12 @@ func readProfile(profileName string) (*viper.Viper, error) {
 
 var _defaultProfilePathname = map[string]string{
 	"k8s": "~/.kube/config",
+	"gcp": "~/.config/gcloud/application_default_credentials.json",
+}
+
+// _defaultProfilePathnameEnv: Environment variable which takes precedence over _defaultProfilePathname
+var _defaultProfilePathnameEnv = map[string]string{
+	"gcp": "GOOGLE_APPLICATION_CREDENTIALS",
 }
 
 // GetProfilePathname: Implement of IAuthProvider.GetProfilePathname
@@ -137,6 +169,12 @@ func (p *AuthFileProvider) GetProfilePathname(cloudType def.CloudType) (string,
 	}
 
 	if profileName == def.PROFILE_ENV {
+		if envName, ok := _defaultProfilePathnameEnv[key]; ok {
+			if pathname := os.Getenv(envName); pathname != "" {
+				return pathname, nil
+			}
+		}
+
 		pathname, ok := _defaultProfilePathname[key]
 		if !ok {
 			return "", fmt.Errorf("no default pathname defined for cloud \"%s\" with profile of $ENV", key)
@@ -166,6 +204,24 @@ func (p *AuthFileProvider) GetProfilePathname(cloudType def.CloudType) (string,
 	return filepath.Join(binDir, ".auth", profileName), nil
 }
 
+// GetProfileName: Implementation of IProfileNameProvider.GetProfileName
+// @param: cloudType: Type of the cloud
+// @return: Name of profile
+// @return: Error
+func (p *AuthFileProvider) GetProfileName(cloudType def.CloudType) (string, error) {
+	key, ok := _mapCloudTypeToName[cloudType]
+	if !ok {
+		panic(fmt.Sprintf("internal error, key name of cloudType \"%s\" not assigned", cloudType))
+	}
+
+	profileName, ok := p.profile[key]
+	if !ok {
+		return "", ProfileNotDefinedError{key}
+	}
+
+	return profileName, nil
+}
+
 // IsAllSet: Check for all required keys,
 // otherwise Viper.GetString will return an empty value other than error
 // @param: v: Instance of viper
@@ -14,6 +14,35 @@ import (
 	"github.com/spf13/viper"
 )
 
+func TestNewProfileNotDefinedError(t *testing.T) {
+	type args struct {
+		cloudType def.CloudType
+	}
+	tests := []struct {
+		name string
+		args args
+		want ProfileNotDefinedError
+	}{
+		{
+			"Valid result",
+			args{def.TENCENT_COS},
+			ProfileNotDefinedError{"tencent"},
+		},
+		{
+			"Valid result of unknown cloud type",
+			args{"mock"},
+			ProfileNotDefinedError{"mock"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewProfileNotDefinedError(tt.args.cloudType); got != tt.want {
+				t.Errorf("NewProfileNotDefinedError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestAuthFileProvider_GetProfile(t *testing.T) {
 	patches := gomonkey.ApplyMethodFunc(&viper.Viper{}, "ReadInConfig",
 		func() error {
@@ -146,6 +175,9 @@ func Test_readProfile(t *testing.T) {
 }
 
 func TestAuthFileProvider_GetProfilePathname(t *testing.T) {
+	os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "/mock/sa.json")
+	defer os.Unsetenv("GOOGLE_APPLICATION_CREDENTIALS")
+
 	type args struct {
 		cloudType def.CloudType
 	}
@@ -170,6 +202,13 @@ func TestAuthFileProvider_GetProfilePathname(t *testing.T) {
 			"config",
 			false,
 		},
+		{
+			"Valid result with conf of gcp from environment variable",
+			NewAuthFileProvider(test.Test_conf_gcp),
+			args{def.GCP},
+			"sa.json",
+			false,
+		},
 		{
 			"no profile defined for cloud",
 			NewAuthFileProvider(test.Test_conf_env),
@@ -214,6 +253,53 @@ func TestAuthFileProvider_GetProfilePathname(t *testing.T) {
 	}
 }
 
+func TestAuthFileProvider_GetProfileName(t *testing.T) {
+	type args struct {
+		cloudType def.CloudType
+	}
+	tests := []struct {
+		name    string
+		p       *AuthFileProvider
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			"Valid result with conf of file",
+			NewAuthFileProvider(test.Test_conf_file),
+			args{def.TENCENT_COS},
+			"file",
+			false,
+		},
+		{
+			"Valid result with conf of env",
+			NewAuthFileProvider(test.Test_conf_env),
+			args{def.TENCENT_CLOUD},
+			def.PROFILE_ENV,
+			false,
+		},
+		{
+			"no profile defined for cloud",
+			NewAuthFileProvider(test.Test_conf_env),
+			args{def.ALIYUN_CLOUD},
+			"",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.p.GetProfileName(tt.args.cloudType)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AuthFileProvider.GetProfileName() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("AuthFileProvider.GetProfileName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestIsAllSet(t *testing.T) {
 	envMap := map[string]string{
 		"TENCENTCLOUD_SECRET_ID":  "mock_secretid",
@@ -24,7 +24,10 @@ const (
 	ALIYUN_REGION            = "ALIBABA_CLOUD_REGION"
 )
 
-func createAliyunCloudClient(p auth.IAuthProvider, endpoint string, bEpWithRegion bool) (*openapi.Client, error) {
+// Keys in profile whose values are scrubbed by Recorder
+var _recorderSecretAliyun = []string{ALIYUN_ACCESS_KEY_ID, ALIYUN_ACCESS_KEY_SECRET}
+
+func createAliyunCloudClient(p auth.IAuthProvider, endpoint string, bEpWithRegion bool, region string) (*openapi.Client, error) {
 	if p == nil {
 		return nil, errors.New("nil pointor of IAuthProvider")
 	}
@@ -33,16 +36,24 @@ func createAliyunCloudClient(p auth.IAuthProvider, endpoint string, bEpWithRegio
 	if err != nil {
 		return nil, err
 	}
-	if err := auth.IsAllSet(v, []string{ALIYUN_ACCESS_KEY_ID, ALIYUN_ACCESS_KEY_SECRET, ALIYUN_REGION}); err != nil {
+	requiredKeys := []string{ALIYUN_ACCESS_KEY_ID, ALIYUN_ACCESS_KEY_SECRET}
+	if len(region) == 0 {
+		// Region in the profile is used by default
+		requiredKeys = append(requiredKeys, ALIYUN_REGION)
+	}
+	if err := auth.IsAllSet(v, requiredKeys); err != nil {
 		return nil, err
 	}
+	if len(region) == 0 {
+		region = v.GetString(ALIYUN_REGION)
+	}
 
 	config := &openapi.Config{
 		AccessKeyId:     tea.String(v.GetString(ALIYUN_ACCESS_KEY_ID)),
 		AccessKeySecret: tea.String(v.GetString(ALIYUN_ACCESS_KEY_SECRET)),
 	}
 	if bEpWithRegion {
-		config.Endpoint = tea.String(fmt.Sprintf("%s.%s.aliyuncs.com", endpoint, v.GetString(ALIYUN_REGION)))
+		config.Endpoint = tea.String(fmt.Sprintf("%s.%s.aliyuncs.com", endpoint, region))
 	} else {
 		config.Endpoint = tea.String(fmt.Sprintf("%s.aliyuncs.com", endpoint))
 	}
@@ -56,14 +67,20 @@ var (
 	_rlAliyunCloud = ratelimit.New(10, ratelimit.WithoutSlack)
 )
 
-func getAliyunCloudClient(p auth.IAuthProvider, endpoint string, bEpWithRegion bool) (*openapi.Client, error) {
+// getAliyunCloudClient: Get client of the endpoint and region from cache, or create a new one
+//
+// Empty region stands for the region in the profile
+func getAliyunCloudClient(p auth.IAuthProvider, endpoint string, bEpWithRegion bool, region string) (*openapi.Client, error) {
 	key := fmt.Sprintf("%p_%s", p, endpoint)
+	if len(region) > 0 {
+		key = fmt.Sprintf("%p_%s_%s", p, endpoint, region)
+	}
 	return _mapAliyunCloudClient.LoadOrCreate(key, func() (any, error) {
-		return createAliyunCloudClient(p, endpoint, bEpWithRegion)
+		return createAliyunCloudClient(p, endpoint, bEpWithRegion, region)
 	}, nil)
 }
 
-// CallAliyunCloud: Send a request to Aliyun and parse response
+// CallAliyunCloud: Send a request to Aliyun in the region of the profile and parse response
 //
 // TODO: Deal with more types of extraParam for tea
 // @param: authProvider: IAuthProvider to provide profile of auth
@@ -76,16 +93,64 @@ func getAliyunCloudClient(p auth.IAuthProvider, endpoint string, bEpWithRegion b
 // @return: Error
 func CallAliyunCloud(authProvider auth.IAuthProvider, endpoint string, bEpWithRegion bool, version string, action string, extraParam map[string]any) (
 	*json.RawMessage, error) {
-	client, err := getAliyunCloudClient(authProvider, endpoint, bEpWithRegion)
+	return recordCall(authProvider, def.ALIYUN_CLOUD, _recorderSecretAliyun, "CallAliyunCloud", []any{endpoint, bEpWithRegion, version, action, extraParam},
+		func() (*json.RawMessage, error) {
+			return callAliyunCloud(authProvider, "", endpoint, bEpWithRegion, version, action, extraParam)
+		})
+}
+
+// CallAliyunCloudWithRegion: Send a request to Aliyun in the given region and parse response
+// @param: authProvider: IAuthProvider to provide profile of auth
+// @param: region: Region of the request, the region of the profile is used if empty
+// @param: endpoint: Parameter for Aliyun common request
+// @param: bEpWithRegion: Indicate if region should be added to endpoint
+// @param: version: Parameter for Aliyun common request
+// @param: action: Parameter for Aliyun common request
+// @param: extraParam: Extra parameters provided to Aliyun
+// @return: Response data from Aliyun
+// @return: Error
+func CallAliyunCloudWithRegion(authProvider auth.IAuthProvider, region string, endpoint string, bEpWithRegion bool, version string, action string,
+	extraParam map[string]any) (*json.RawMessage, error) {
+	if len(region) == 0 {
+		return CallAliyunCloud(authProvider, endpoint, bEpWithRegion, version, action, extraParam)
+	}
+
+	return recordCall(authProvider, def.ALIYUN_CLOUD, _recorderSecretAliyun, "CallAliyunCloudWithRegion",
+		[]any{region, endpoint, bEpWithRegion, version, action, extraParam},
+		func() (*json.RawMessage, error) {
+			return callAliyunCloud(authProvider, region, endpoint, bEpWithRegion, version, action, extraParam)
+		})
+}
+
+// GetAliyunRegions: Get all regions available to the account with DescribeRegions of ECS
+// @param: authProvider: IAuthProvider to provide profile of auth
+// @return: List of regions
+// @return: Error
+func GetAliyunRegions(authProvider auth.IAuthProvider) ([]string, error) {
+	res, err := CallAliyunCloud(authProvider, "ecs", false, "2014-05-26", "DescribeRegions", nil)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to describe regions: %w", err)
 	}
 
-	v, err := authProvider.GetProfile(def.ALIYUN_CLOUD)
+	return parseRegions(res, "$.Regions.Region[*].RegionId")
+}
+
+// callAliyunCloud: Implementation of CallAliyunCloud without Recorder
+func callAliyunCloud(authProvider auth.IAuthProvider, region string, endpoint string, bEpWithRegion bool, version string, action string,
+	extraParam map[string]any) (*json.RawMessage, error) {
+	client, err := getAliyunCloudClient(authProvider, endpoint, bEpWithRegion, region)
 	if err != nil {
 		return nil, err
 	}
 
+	if len(region) == 0 {
+		v, err := authProvider.GetProfile(def.ALIYUN_CLOUD)
+		if err != nil {
+			return nil, err
+		}
+		region = v.GetString(ALIYUN_REGION)
+	}
+
 	params := &openapi.Params{
 		Action:      tea.String(action),
 		Version:     tea.String(version),
@@ -98,7 +163,7 @@ func CallAliyunCloud(authProvider auth.IAuthProvider, endpoint string, bEpWithRe
 	}
 
 	queries := make(map[string]any)
-	queries["RegionId"] = tea.String(v.GetString(ALIYUN_REGION))
+	queries["RegionId"] = tea.String(region)
 	for k, v := range extraParam {
 		switch p := v.(type) {
 		case string:
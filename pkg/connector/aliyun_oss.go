@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/s3studio/cloud-bench-checker/internal"
 	"github.com/s3studio/cloud-bench-checker/pkg/auth"
@@ -16,7 +17,7 @@ import (
 	"go.uber.org/ratelimit"
 )
 
-func createAliyunOSSClient(p auth.IAuthProvider) (*oss.Client, error) {
+func createAliyunOSSClient(p auth.IAuthProvider, region string) (*oss.Client, error) {
 	if p == nil {
 		return nil, errors.New("nil pointor of IAuthProvider")
 	}
@@ -25,36 +26,54 @@ func createAliyunOSSClient(p auth.IAuthProvider) (*oss.Client, error) {
 	if err != nil {
 		return nil, err
 	}
-	if err := auth.IsAllSet(v, []string{ALIYUN_ACCESS_KEY_ID, ALIYUN_ACCESS_KEY_SECRET, ALIYUN_REGION}); err != nil {
+	requiredKeys := []string{ALIYUN_ACCESS_KEY_ID, ALIYUN_ACCESS_KEY_SECRET}
+	if len(region) == 0 {
+		// Region in the profile is used by default
+		requiredKeys = append(requiredKeys, ALIYUN_REGION)
+	}
+	if err := auth.IsAllSet(v, requiredKeys); err != nil {
 		return nil, err
 	}
-
-	region := v.GetString(ALIYUN_REGION)
-	if region[:4] != "oss-" {
-		region = fmt.Sprintf("oss-%s", region)
+	if len(region) == 0 {
+		region = v.GetString(ALIYUN_REGION)
 	}
+
 	return oss.New(
-		fmt.Sprintf("https://%s.aliyuncs.com", region),
+		fmt.Sprintf("https://%s.aliyuncs.com", getAliyunOSSLocation(region)),
 		v.GetString(ALIYUN_ACCESS_KEY_ID),
 		v.GetString(ALIYUN_ACCESS_KEY_SECRET))
 }
 
+// getAliyunOSSLocation: Get location of OSS used in endpoint and bucket, which is the region with "oss-" prefix
+func getAliyunOSSLocation(region string) string {
+	if !strings.HasPrefix(region, "oss-") {
+		return fmt.Sprintf("oss-%s", region)
+	}
+	return region
+}
+
 var (
 	_mapAliyunOSSClient internal.SyncMap[*oss.Client]
 
 	_rlAliyunOSS = ratelimit.New(10, ratelimit.WithoutSlack)
 )
 
-func getAliyunOSSClient(p auth.IAuthProvider) (*oss.Client, error) {
+// getAliyunOSSClient: Get client of the region from cache, or create a new one
+//
+// Empty region stands for the region in the profile
+func getAliyunOSSClient(p auth.IAuthProvider, region string) (*oss.Client, error) {
 	key := fmt.Sprintf("%p_default", p)
+	if len(region) > 0 {
+		key = fmt.Sprintf("%p_%s", p, region)
+	}
 	return _mapAliyunOSSClient.LoadOrCreate(key, func() (any, error) {
-		return createAliyunOSSClient(p)
+		return createAliyunOSSClient(p, region)
 	}, nil)
 }
 
 const ALIYUN_OSS_MARKER_KEY = "marker"
 
-// CallAliyunOSS: Send a request to Aliyun OSS and parse response
+// CallAliyunOSS: Send a request to Aliyun OSS in the region of the profile and parse response
 //
 // TODO: Deal with more extra parameters for different reflect call
 // @param: authProvider: IAuthProvider to provide profile of auth
@@ -65,7 +84,39 @@ const ALIYUN_OSS_MARKER_KEY = "marker"
 // @return: Error
 func CallAliyunOSS(authProvider auth.IAuthProvider, bucketName string, action string, extraParam map[string]any) (
 	*json.RawMessage, error) {
-	client, err := getAliyunOSSClient(authProvider)
+	return recordCall(authProvider, def.ALIYUN_OSS, _recorderSecretAliyun, "CallAliyunOSS", []any{bucketName, action, extraParam},
+		func() (*json.RawMessage, error) {
+			return callAliyunOSS(authProvider, "", bucketName, action, extraParam)
+		})
+}
+
+// CallAliyunOSSWithRegion: Send a request to Aliyun OSS in the given region and parse response
+//
+// When listing buckets, only the buckets located in the region are returned
+// @param: authProvider: IAuthProvider to provide profile of auth
+// @param: region: Region of the request, the region of the profile is used if empty
+// @param: bucketName: Name of the bucket. List all buckets if empty string given
+// @param: action: Parameter for the reflection of Aliyun OSS API
+// @param: extraParam: Currently only used to transfer marker when listing buckets
+// @return: Response data from Aliyun OSS
+// @return: Error
+func CallAliyunOSSWithRegion(authProvider auth.IAuthProvider, region string, bucketName string, action string, extraParam map[string]any) (
+	*json.RawMessage, error) {
+	if len(region) == 0 {
+		return CallAliyunOSS(authProvider, bucketName, action, extraParam)
+	}
+
+	return recordCall(authProvider, def.ALIYUN_OSS, _recorderSecretAliyun, "CallAliyunOSSWithRegion",
+		[]any{region, bucketName, action, extraParam},
+		func() (*json.RawMessage, error) {
+			return callAliyunOSS(authProvider, region, bucketName, action, extraParam)
+		})
+}
+
+// callAliyunOSS: Implementation of CallAliyunOSS without Recorder
+func callAliyunOSS(authProvider auth.IAuthProvider, region string, bucketName string, action string, extraParam map[string]any) (
+	*json.RawMessage, error) {
+	client, err := getAliyunOSSClient(authProvider, region)
 	if err != nil {
 		return nil, err
 	}
@@ -104,6 +155,20 @@ func CallAliyunOSS(authProvider auth.IAuthProvider, bucketName string, action st
 			}
 		}
 
-		return internal.JsonMarshal(callResult[0].Interface())
+		result := callResult[0].Interface()
+		if listResult, ok := result.(oss.ListBucketsResult); ok && len(region) > 0 {
+			// Buckets of all regions are listed with the client of any region
+			location := getAliyunOSSLocation(region)
+			buckets := make([]oss.BucketProperties, 0, len(listResult.Buckets))
+			for _, bucket := range listResult.Buckets {
+				if bucket.Location == location {
+					buckets = append(buckets, bucket)
+				}
+			}
+			listResult.Buckets = buckets
+			result = listResult
+		}
+
+		return internal.JsonMarshal(result)
 	}
 }
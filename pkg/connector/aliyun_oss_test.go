@@ -50,7 +50,7 @@ func Test_createAliyunOSSClient(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := createAliyunOSSClient(tt.args.p)
+			got, err := createAliyunOSSClient(tt.args.p, "")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("createAliyunOSSClient() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -87,7 +87,7 @@ func Test_getAliyunOSSClient(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := getAliyunOSSClient(tt.args.p)
+			got, err := getAliyunOSSClient(tt.args.p, "")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("getAliyunOSSClient() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -170,3 +170,58 @@ func TestCallAliyunOSS(t *testing.T) {
 		})
 	}
 }
+
+func TestCallAliyunOSSWithRegion(t *testing.T) {
+	setupEnvAliyun()
+	bucket1 := oss.BucketProperties{Name: "bucket-1", Location: "oss-region-1"}
+	bucket2 := oss.BucketProperties{Name: "bucket-2", Location: "oss-region-2"}
+	patchListBucket := gomonkey.ApplyMethodFunc(oss.Client{}, "ListBuckets",
+		func(options ...oss.Option) (oss.ListBucketsResult, error) {
+			return oss.ListBucketsResult{Buckets: []oss.BucketProperties{bucket1, bucket2}}, nil
+		})
+	defer patchListBucket.Reset()
+	rmAll, _ := internal.JsonMarshal(oss.ListBucketsResult{Buckets: []oss.BucketProperties{bucket1, bucket2}})
+	rmRegion, _ := internal.JsonMarshal(oss.ListBucketsResult{Buckets: []oss.BucketProperties{bucket1}})
+
+	type args struct {
+		authProvider auth.IAuthProvider
+		region       string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *json.RawMessage
+		wantErr bool
+	}{
+		{
+			"Valid result of all buckets in region of profile",
+			args{auth.NewAuthFileProvider(test.Test_conf_aliyun), ""},
+			rmAll,
+			false,
+		},
+		{
+			"Valid result of buckets in given region",
+			args{auth.NewAuthFileProvider(test.Test_conf_aliyun), "region-1"},
+			rmRegion,
+			false,
+		},
+		{
+			"Profile not defined",
+			args{auth.NewAuthFileProvider(test.Test_conf_invalid), "region-1"},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CallAliyunOSSWithRegion(tt.args.authProvider, tt.args.region, "", "", map[string]any{"marker": ""})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CallAliyunOSSWithRegion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CallAliyunOSSWithRegion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
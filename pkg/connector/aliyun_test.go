@@ -71,7 +71,7 @@ func Test_createAliyunCloudClient(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := createAliyunCloudClient(tt.args.p, tt.args.endpoint, tt.args.bEpWithRegion)
+			got, err := createAliyunCloudClient(tt.args.p, tt.args.endpoint, tt.args.bEpWithRegion, "")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("createAliyunCloudClient() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -110,7 +110,7 @@ func Test_getAliyunCloudClient(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := getAliyunCloudClient(tt.args.p, tt.args.endpoint, tt.args.bEpWithRegion)
+			got, err := getAliyunCloudClient(tt.args.p, tt.args.endpoint, tt.args.bEpWithRegion, "")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("getAliyunCloudClient() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -195,3 +195,106 @@ func TestCallAliyunCloud(t *testing.T) {
 		})
 	}
 }
+
+func setupCallApiWithRegionAliyun() *gomonkey.Patches {
+	return gomonkey.ApplyMethodFunc(&openapi.Client{}, "CallApi",
+		func(params *openapi.Params, request *openapi.OpenApiRequest, runtime *util.RuntimeOptions) (_result map[string]interface{}, _err error) {
+			if tea.StringValue(params.Action) == "DescribeRegions" {
+				return map[string]any{"body": map[string]any{"Regions": map[string]any{"Region": []any{
+					map[string]any{"RegionId": "region-1"}, map[string]any{"RegionId": "region-2"},
+				}}}}, nil
+			}
+			return map[string]any{"body": tea.StringValue(request.Query["RegionId"])}, nil
+		})
+}
+
+func TestCallAliyunCloudWithRegion(t *testing.T) {
+	setupEnvAliyun()
+	patches := setupCallApiWithRegionAliyun()
+	defer patches.Reset()
+	rmProfileRegion, _ := internal.JsonMarshal("mock_region")
+	rmRegion, _ := internal.JsonMarshal("region-1")
+
+	type args struct {
+		authProvider auth.IAuthProvider
+		region       string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *json.RawMessage
+		wantErr bool
+	}{
+		{
+			"Valid result in region of profile",
+			args{auth.NewAuthFileProvider(test.Test_conf_aliyun), ""},
+			rmProfileRegion,
+			false,
+		},
+		{
+			"Valid result in given region",
+			args{auth.NewAuthFileProvider(test.Test_conf_aliyun), "region-1"},
+			rmRegion,
+			false,
+		},
+		{
+			"Profile not defined",
+			args{auth.NewAuthFileProvider(test.Test_conf_invalid), "region-1"},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CallAliyunCloudWithRegion(tt.args.authProvider, tt.args.region, "", true, "", "", make(map[string]any))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CallAliyunCloudWithRegion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CallAliyunCloudWithRegion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetAliyunRegions(t *testing.T) {
+	setupEnvAliyun()
+	patches := setupCallApiWithRegionAliyun()
+	defer patches.Reset()
+
+	type args struct {
+		authProvider auth.IAuthProvider
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []string
+		wantErr bool
+	}{
+		{
+			"Valid result",
+			args{auth.NewAuthFileProvider(test.Test_conf_aliyun)},
+			[]string{"region-1", "region-2"},
+			false,
+		},
+		{
+			"failed to describe regions",
+			args{auth.NewAuthFileProvider(test.Test_conf_invalid)},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetAliyunRegions(tt.args.authProvider)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetAliyunRegions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetAliyunRegions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
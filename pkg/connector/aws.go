@@ -0,0 +1,381 @@
+// Connector for AWS using signed requests of Signature Version 4
+
+package connector
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/s3studio/cloud-bench-checker/internal"
+	"github.com/s3studio/cloud-bench-checker/pkg/auth"
+	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"go.uber.org/ratelimit"
+)
+
+const (
+	AWS_ACCESS_KEY_ID     = "AWS_ACCESS_KEY_ID"
+	AWS_SECRET_ACCESS_KEY = "AWS_SECRET_ACCESS_KEY"
+	AWS_SESSION_TOKEN     = "AWS_SESSION_TOKEN"
+	AWS_REGION            = "AWS_REGION"
+	// Optional, base url used instead of the public endpoint of each service
+	AWS_ENDPOINT_URL = "AWS_ENDPOINT_URL"
+)
+
+// Keys in profile whose values are scrubbed by Recorder
+var _recorderSecretAWS = []string{AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN}
+
+// Bind credential with region and signer
+type awsClient struct {
+	cred   aws.Credentials
+	region string
+	signer *v4.Signer
+	hc     *http.Client
+}
+
+func createAWSClient(p auth.IAuthProvider) (*awsClient, error) {
+	if p == nil {
+		return nil, errors.New("nil pointor of IAuthProvider")
+	}
+
+	v, err := p.GetProfile(def.AWS)
+	if err != nil {
+		return nil, err
+	}
+	if err := auth.IsAllSet(v, []string{AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, AWS_REGION}); err != nil {
+		return nil, err
+	}
+
+	return &awsClient{
+		cred: aws.Credentials{
+			AccessKeyID:     v.GetString(AWS_ACCESS_KEY_ID),
+			SecretAccessKey: v.GetString(AWS_SECRET_ACCESS_KEY),
+			SessionToken:    v.GetString(AWS_SESSION_TOKEN),
+		},
+		region: v.GetString(AWS_REGION),
+		signer: v4.NewSigner(),
+		hc:     &http.Client{Timeout: 60 * time.Second},
+	}, nil
+}
+
+var (
+	_mapAWSClient internal.SyncMap[*awsClient]
+
+	_rlAWS = ratelimit.New(10, ratelimit.WithoutSlack)
+)
+
+func getAWSClient(p auth.IAuthProvider) (*awsClient, error) {
+	key := fmt.Sprintf("%p_default", p)
+	return _mapAWSClient.LoadOrCreate(key, func() (any, error) {
+		return createAWSClient(p)
+	}, nil)
+}
+
+// _mapAWSGlobalService: Services served by a single global endpoint, and the region used to sign requests
+var _mapAWSGlobalService = map[string]string{
+	"iam": "us-east-1",
+}
+
+// resolveAWSEndpoint: Get base url and signing region of the service
+func resolveAWSEndpoint(endpointOverride string, service string, region string) (string, string) {
+	signingRegion := region
+	if globalRegion, ok := _mapAWSGlobalService[service]; ok {
+		signingRegion = globalRegion
+	}
+
+	if len(endpointOverride) > 0 {
+		return strings.TrimRight(endpointOverride, "/"), signingRegion
+	}
+
+	if _, ok := _mapAWSGlobalService[service]; ok {
+		return fmt.Sprintf("https://%s.amazonaws.com", service), signingRegion
+	}
+	return fmt.Sprintf("https://%s.%s.amazonaws.com", service, region), signingRegion
+}
+
+// CallAWS: Send a request to AWS and parse response
+//
+// Both Query and JSON protocols are supported, and the protocol is decided by action:
+//
+// - Query protocol: action is the name of API, e.g. "ListUsers".
+// Action, version and extraParam are sent as form values,
+// and the content in "<action>Result" of the xml response is converted to json.
+//
+// - JSON protocol: action is the full target of API with prefix, e.g. "Logs_20140328.DescribeLogGroups".
+// version is the version of JSON protocol ("1.0" or "1.1", default "1.1"),
+// and extraParam is sent as the json body.
+//
+// TODO: Deal with more types of extraParam for Query protocol
+// @param: authProvider: IAuthProvider to provide profile of auth
+// @param: service: Name of service used in endpoint and signature, e.g. "iam"
+// @param: version: Parameter for AWS request, see above
+// @param: action: Parameter for AWS request, see above
+// @param: extraParam: Extra parameters provided to AWS
+// @return: Response data from AWS
+// @return: Error
+func CallAWS(authProvider auth.IAuthProvider, service string, version string, action string, extraParam map[string]any) (
+	*json.RawMessage, error) {
+	return recordCall(authProvider, def.AWS, _recorderSecretAWS, "CallAWS", []any{service, version, action, extraParam},
+		func() (*json.RawMessage, error) {
+			return callAWS(authProvider, service, version, action, extraParam)
+		})
+}
+
+// callAWS: Implementation of CallAWS without Recorder
+func callAWS(authProvider auth.IAuthProvider, service string, version string, action string, extraParam map[string]any) (
+	*json.RawMessage, error) {
+	client, err := getAWSClient(authProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := authProvider.GetProfile(def.AWS)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(service) == 0 || len(action) == 0 {
+		return nil, errors.New("service or action for AWS is empty")
+	}
+
+	baseURL, signingRegion := resolveAWSEndpoint(v.GetString(AWS_ENDPOINT_URL), service, client.region)
+	bJsonProtocol := strings.Contains(action, ".")
+
+	var byBody []byte
+	var contentType string
+	if bJsonProtocol {
+		if extraParam == nil {
+			extraParam = make(map[string]any)
+		}
+		byBody, err = json.Marshal(extraParam)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal extraParam: %w", err)
+		}
+
+		jsonVersion := version
+		if len(jsonVersion) == 0 {
+			jsonVersion = "1.1"
+		}
+		contentType = fmt.Sprintf("application/x-amz-json-%s", jsonVersion)
+	} else {
+		form := url.Values{}
+		form.Set("Action", action)
+		form.Set("Version", version)
+		for k, v := range extraParam {
+			if err := addAWSFormValue(form, k, v); err != nil {
+				return nil, err
+			}
+		}
+
+		byBody = []byte(form.Encode())
+		contentType = "application/x-www-form-urlencoded; charset=utf-8"
+	}
+
+	ctx := context.Background()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/", bytes.NewReader(byBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	if bJsonProtocol {
+		req.Header.Set("X-Amz-Target", action)
+	}
+
+	payloadHash := sha256.Sum256(byBody)
+	if err := client.signer.SignHTTP(ctx, client.cred, req, hex.EncodeToString(payloadHash[:]),
+		service, signingRegion, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	_rlAWS.Take()
+	resp, err := client.hc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to invoke api: %w", err)
+	}
+
+	defer resp.Body.Close()
+	byResp, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("response indicates failure with status %d: %s", resp.StatusCode, string(byResp))
+	}
+
+	if bJsonProtocol {
+		responseMap := make(map[string]json.RawMessage)
+		if err := internal.JsonUnmarshal(byResp, &responseMap); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response as json: %w", err)
+		}
+
+		var rm json.RawMessage = byResp
+		return &rm, nil
+	}
+
+	rootName, rootValue, err := awsXmlToAny(byResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response as xml: %w", err)
+	}
+	if rootName != action+"Response" {
+		return nil, fmt.Errorf("invalid response, unexpected root element \"%s\"", rootName)
+	}
+
+	if responseMap, ok := rootValue.(map[string]any); ok {
+		if result, ok := responseMap[action+"Result"]; ok {
+			return internal.JsonMarshal(result)
+		}
+	}
+
+	// Some actions return nothing but metadata
+	return internal.JsonMarshal(rootValue)
+}
+
+// addAWSFormValue: Add a value of extraParam to the form of Query protocol
+func addAWSFormValue(form url.Values, key string, value any) error {
+	switch p := value.(type) {
+	case string:
+		form.Set(key, p)
+	case int:
+		form.Set(key, strconv.Itoa(p))
+	case bool:
+		form.Set(key, strconv.FormatBool(p))
+	case []string:
+		for i, item := range p {
+			form.Set(fmt.Sprintf("%s.member.%d", key, i+1), item)
+		}
+	case []any:
+		for i, item := range p {
+			if err := addAWSFormValue(form, fmt.Sprintf("%s.member.%d", key, i+1), item); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported type of param \"%s\" for Query protocol of AWS", key)
+	}
+
+	return nil
+}
+
+// awsXmlNode: Element of xml response of Query protocol
+type awsXmlNode struct {
+	name     string
+	text     string
+	children []*awsXmlNode
+}
+
+// awsXmlToAny: Convert xml response of Query protocol to an object that can be marshaled to json
+//
+// Elements containing only "member" or "item" elements are converted to lists,
+// and text of "true" or "false" is converted to boolean, so that the result can be used in paginator.
+// Empty elements are converted to empty lists, as AWS omits the members not set,
+// and an empty element is the wrapper of a list without items, e.g. <Users/>.
+// @param: data: Xml response
+// @return: Name of the root element
+// @return: Value of the root element
+// @return: Error
+func awsXmlToAny(data []byte) (string, any, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			return "", nil, err
+		}
+
+		if start, ok := tok.(xml.StartElement); ok {
+			root, err := parseAWSXmlNode(decoder, start)
+			if err != nil {
+				return "", nil, err
+			}
+
+			return root.name, root.value(), nil
+		}
+	}
+}
+
+func parseAWSXmlNode(decoder *xml.Decoder, start xml.StartElement) (*awsXmlNode, error) {
+	node := &awsXmlNode{name: start.Name.Local}
+	var text strings.Builder
+
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			child, err := parseAWSXmlNode(decoder, t)
+			if err != nil {
+				return nil, err
+			}
+			node.children = append(node.children, child)
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			node.text = strings.TrimSpace(text.String())
+			return node, nil
+		}
+	}
+}
+
+func (n *awsXmlNode) value() any {
+	if len(n.children) == 0 {
+		switch n.text {
+		case "true":
+			return true
+		case "false":
+			return false
+		case "":
+			// Empty wrapper of list
+			return []any{}
+		default:
+			return n.text
+		}
+	}
+
+	bList := true
+	for _, c := range n.children {
+		if c.name != "member" && c.name != "item" {
+			bList = false
+			break
+		}
+	}
+	if bList {
+		list := make([]any, len(n.children))
+		for i, c := range n.children {
+			list[i] = c.value()
+		}
+		return list
+	}
+
+	m := make(map[string]any, len(n.children))
+	repeated := make(map[string]bool)
+	for _, c := range n.children {
+		existing, ok := m[c.name]
+		if !ok {
+			m[c.name] = c.value()
+		} else if repeated[c.name] {
+			m[c.name] = append(existing.([]any), c.value())
+		} else {
+			// Flattened list without wrapper of "member"
+			m[c.name] = []any{existing, c.value()}
+			repeated[c.name] = true
+		}
+	}
+	return m
+}
@@ -0,0 +1,358 @@
+// Connector for AWS using signed requests of Signature Version 4
+
+package connector
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/s3studio/cloud-bench-checker/internal"
+	"github.com/s3studio/cloud-bench-checker/pkg/auth"
+	"github.com/s3studio/cloud-bench-checker/test"
+)
+
+func setupEnvAWS() {
+	envMap := map[string]string{
+		"AWS_ACCESS_KEY_ID":     "mock_keyid",
+		"AWS_SECRET_ACCESS_KEY": "mock_secret",
+		"AWS_REGION":            "mock-region-1",
+	}
+	for k, v := range envMap {
+		os.Setenv(k, v)
+	}
+}
+
+func Test_createAWSClient(t *testing.T) {
+	setupEnvAWS()
+
+	type args struct {
+		p auth.IAuthProvider
+	}
+	tests := []struct {
+		name string
+		args args
+		//want    *awsClient
+		wantErr bool
+	}{
+		{
+			"Valid result",
+			args{auth.NewAuthFileProvider(test.Test_conf_aws)},
+			false,
+		},
+		{
+			"Profile not defined",
+			args{auth.NewAuthFileProvider(test.Test_conf_invalid)},
+			true,
+		},
+		{
+			"Key not set",
+			args{&test.MockKeyNotSetAuthProvider{}},
+			true,
+		},
+		{
+			"nil pointor of IAuthProvider",
+			args{nil},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := createAWSClient(tt.args.p)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("createAWSClient() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if (got == nil) != tt.wantErr {
+				t.Errorf("createAWSClient() = %v, want a valid pointer", got)
+			}
+		})
+	}
+}
+
+func Test_getAWSClient(t *testing.T) {
+	setupEnvAWS()
+
+	type args struct {
+		p auth.IAuthProvider
+	}
+	tests := []struct {
+		name string
+		args args
+		//want    *awsClient
+		wantErr bool
+	}{
+		{
+			"Valid result",
+			args{auth.NewAuthFileProvider(test.Test_conf_aws)},
+			false,
+		},
+		{
+			"Profile not defined",
+			args{auth.NewAuthFileProvider(test.Test_conf_invalid)},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getAWSClient(tt.args.p)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getAWSClient() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if (got == nil) != tt.wantErr {
+				t.Errorf("getAWSClient() = %v, want a valid pointer", got)
+			}
+		})
+	}
+}
+
+func Test_resolveAWSEndpoint(t *testing.T) {
+	type args struct {
+		endpointOverride string
+		service          string
+		region           string
+	}
+	tests := []struct {
+		name  string
+		args  args
+		want  string
+		want1 string
+	}{
+		{
+			"Regional service",
+			args{"", "ec2", "us-west-2"},
+			"https://ec2.us-west-2.amazonaws.com",
+			"us-west-2",
+		},
+		{
+			"Global service",
+			args{"", "iam", "us-west-2"},
+			"https://iam.amazonaws.com",
+			"us-east-1",
+		},
+		{
+			"Endpoint overridden",
+			args{"http://127.0.0.1:8080/", "ec2", "us-west-2"},
+			"http://127.0.0.1:8080",
+			"us-west-2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1 := resolveAWSEndpoint(tt.args.endpointOverride, tt.args.service, tt.args.region)
+			if got != tt.want {
+				t.Errorf("resolveAWSEndpoint() got = %v, want %v", got, tt.want)
+			}
+			if got1 != tt.want1 {
+				t.Errorf("resolveAWSEndpoint() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func TestCallAWS(t *testing.T) {
+	setupEnvAWS()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		if target := r.Header.Get("X-Amz-Target"); len(target) > 0 {
+			byBody, _ := io.ReadAll(r.Body)
+			if target == "Mock_20240101.Fail" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"__type":"ValidationException"}`))
+				return
+			}
+			w.Write(byBody) // echo
+			return
+		}
+
+		r.ParseForm()
+		switch r.Form.Get("Action") {
+		case "ListMock":
+			w.Write([]byte(`<ListMockResponse xmlns="https://mock/">` +
+				`<ListMockResult><IsTruncated>true</IsTruncated><Marker>m</Marker>` +
+				`<Items><member><Name>` + r.Form.Get("Names.member.1") + `</Name></member></Items>` +
+				`</ListMockResult><ResponseMetadata><RequestId>id</RequestId></ResponseMetadata>` +
+				`</ListMockResponse>`))
+		case "ListUsers":
+			w.Write([]byte(`<ListUsersResponse xmlns="https://iam.amazonaws.com/doc/2010-05-08/">` +
+				`<ListUsersResult><IsTruncated>false</IsTruncated><Users/></ListUsersResult>` +
+				`<ResponseMetadata><RequestId>id</RequestId></ResponseMetadata></ListUsersResponse>`))
+		case "NoResult":
+			w.Write([]byte(`<NoResultResponse><ResponseMetadata><RequestId>id</RequestId></ResponseMetadata></NoResultResponse>`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`<ErrorResponse><Error><Code>InvalidAction</Code></Error></ErrorResponse>`))
+		}
+	}))
+	defer server.Close()
+	os.Setenv("AWS_ENDPOINT_URL", server.URL)
+	defer os.Unsetenv("AWS_ENDPOINT_URL")
+
+	authProvider := auth.NewAuthFileProvider(test.Test_conf_aws)
+	var rmQuery json.RawMessage = []byte(`{"IsTruncated":true,"Items":[{"Name":"mock"}],"Marker":"m"}`)
+	var rmEmptyList json.RawMessage = []byte(`{"IsTruncated":false,"Users":[]}`)
+	var rmNoResult json.RawMessage = []byte(`{"ResponseMetadata":{"RequestId":"id"}}`)
+	var rmJson json.RawMessage = []byte(`{"Limit":1}`)
+
+	type args struct {
+		authProvider auth.IAuthProvider
+		service      string
+		version      string
+		action       string
+		extraParam   map[string]any
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *json.RawMessage
+		wantErr bool
+	}{
+		{
+			"Valid result of Query protocol",
+			args{authProvider, "iam", "2010-05-08", "ListMock", map[string]any{"Names": []string{"mock"}}},
+			&rmQuery,
+			false,
+		},
+		{
+			"Valid result of Query protocol with empty list",
+			args{authProvider, "iam", "2010-05-08", "ListUsers", nil},
+			&rmEmptyList,
+			false,
+		},
+		{
+			"Valid result of Query protocol without result",
+			args{authProvider, "iam", "2010-05-08", "NoResult", nil},
+			&rmNoResult,
+			false,
+		},
+		{
+			"Valid result of JSON protocol",
+			args{authProvider, "logs", "", "Mock_20240101.ListMock", map[string]any{"Limit": 1}},
+			&rmJson,
+			false,
+		},
+		{
+			"Failure of Query protocol",
+			args{authProvider, "iam", "2010-05-08", "Invalid", nil},
+			nil,
+			true,
+		},
+		{
+			"Failure of JSON protocol",
+			args{authProvider, "logs", "1.0", "Mock_20240101.Fail", nil},
+			nil,
+			true,
+		},
+		{
+			"Unsupported type of param",
+			args{authProvider, "iam", "2010-05-08", "ListMock", map[string]any{"Names": 1.5}},
+			nil,
+			true,
+		},
+		{
+			"Empty action",
+			args{authProvider, "iam", "2010-05-08", "", nil},
+			nil,
+			true,
+		},
+		{
+			"Profile not defined",
+			args{auth.NewAuthFileProvider(test.Test_conf_invalid), "iam", "2010-05-08", "ListMock", nil},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CallAWS(tt.args.authProvider, tt.args.service, tt.args.version, tt.args.action, tt.args.extraParam)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CallAWS() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CallAWS() = %v, want %v", string(*got), string(*tt.want))
+			}
+			if tt.want == &rmEmptyList {
+				// Empty wrapper of list should be listed as no item
+				list, err := internal.ParseJsonPathList(got, "$.Users", false)
+				if err != nil || len(list) != 0 {
+					t.Errorf("ParseJsonPathList() = %v, %v, want empty list", list, err)
+				}
+			}
+		})
+	}
+}
+
+func Test_awsXmlToAny(t *testing.T) {
+	type args struct {
+		data []byte
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		want1   any
+		wantErr bool
+	}{
+		{
+			"List wrapped in member",
+			args{[]byte(`<R><L><member>a</member><member>b</member></L></R>`)},
+			"R",
+			map[string]any{"L": []any{"a", "b"}},
+			false,
+		},
+		{
+			"List wrapped in item",
+			args{[]byte(`<R><L><item><K>false</K></item></L></R>`)},
+			"R",
+			map[string]any{"L": []any{map[string]any{"K": false}}},
+			false,
+		},
+		{
+			"Flattened list",
+			args{[]byte(`<R><K>a</K><K>b</K><K>c</K></R>`)},
+			"R",
+			map[string]any{"K": []any{"a", "b", "c"}},
+			false,
+		},
+		{
+			"Empty wrapper of list",
+			args{[]byte(`<R><L/><M></M></R>`)},
+			"R",
+			map[string]any{"L": []any{}, "M": []any{}},
+			false,
+		},
+		{
+			"Invalid xml",
+			args{[]byte(`<R><K>`)},
+			"",
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1, err := awsXmlToAny(tt.args.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("awsXmlToAny() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("awsXmlToAny() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(got1, tt.want1) {
+				t.Errorf("awsXmlToAny() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
@@ -1,4 +1,4 @@
-// Connector for Azure using Azure Resource Manager(arm)
+// Connector for Azure using Azure Resource Manager(arm) and Microsoft Graph
 
 package connector
 
@@ -14,7 +14,9 @@ import (
 	"github.com/s3studio/cloud-bench-checker/pkg/auth"
 	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"go.uber.org/ratelimit"
@@ -27,7 +29,17 @@ const (
 	AZURE_SUBSCRIPTION_ID = "AZURE_SUBSCRIPTION_ID"
 )
 
-func createAzureClient(p auth.IAuthProvider) (*arm.Client, error) {
+// Keys in profile whose values are scrubbed by Recorder
+var _recorderSecretAzure = []string{AZURE_CLIENT_SECRET}
+
+const (
+	AZURE_GRAPH_ENDPOINT = "https://graph.microsoft.com"
+	AZURE_GRAPH_SCOPE    = "https://graph.microsoft.com/.default"
+	// Version of Microsoft Graph used if not defined
+	AZURE_GRAPH_DEFAULT_VERSION = "v1.0"
+)
+
+func createAzureCredential(p auth.IAuthProvider) (*azidentity.ClientSecretCredential, error) {
 	if p == nil {
 		return nil, errors.New("nil pointor of IAuthProvider")
 	}
@@ -36,15 +48,20 @@ func createAzureClient(p auth.IAuthProvider) (*arm.Client, error) {
 	if err != nil {
 		return nil, err
 	}
-	if err := auth.IsAllSet(v, []string{AZURE_CLIENT_ID, AZURE_TENANT_ID, AZURE_CLIENT_SECRET, AZURE_SUBSCRIPTION_ID}); err != nil {
+	// AZURE_SUBSCRIPTION_ID is only required when listing resources in the subscription of profile
+	if err := auth.IsAllSet(v, []string{AZURE_CLIENT_ID, AZURE_TENANT_ID, AZURE_CLIENT_SECRET}); err != nil {
 		return nil, err
 	}
 
-	credential, err := azidentity.NewClientSecretCredential(
+	return azidentity.NewClientSecretCredential(
 		v.GetString(AZURE_TENANT_ID),
 		v.GetString(AZURE_CLIENT_ID),
 		v.GetString(AZURE_CLIENT_SECRET),
 		nil)
+}
+
+func createAzureClient(p auth.IAuthProvider) (*arm.Client, error) {
+	credential, err := createAzureCredential(p)
 	if err != nil {
 		return nil, err
 	}
@@ -52,8 +69,21 @@ func createAzureClient(p auth.IAuthProvider) (*arm.Client, error) {
 	return arm.NewClient("cloud-bench-checker", "v0.0.1", credential, nil)
 }
 
+// createAzureGraphClient: Create a client with token of Microsoft Graph from the same credential of Azure
+func createAzureGraphClient(p auth.IAuthProvider) (*azcore.Client, error) {
+	credential, err := createAzureCredential(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return azcore.NewClient("cloud-bench-checker", "v0.0.1", runtime.PipelineOptions{
+		PerRetry: []policy.Policy{runtime.NewBearerTokenPolicy(credential, []string{AZURE_GRAPH_SCOPE}, nil)},
+	}, nil)
+}
+
 var (
-	_mapAzureClient internal.SyncMap[*arm.Client]
+	_mapAzureClient      internal.SyncMap[*arm.Client]
+	_mapAzureGraphClient internal.SyncMap[*azcore.Client]
 
 	_rlAzure = ratelimit.New(10, ratelimit.WithoutSlack)
 )
@@ -65,6 +95,13 @@ func getAzureClient(p auth.IAuthProvider) (*arm.Client, error) {
 	}, nil)
 }
 
+func getAzureGraphClient(p auth.IAuthProvider) (*azcore.Client, error) {
+	key := fmt.Sprintf("%p_default", p)
+	return _mapAzureGraphClient.LoadOrCreate(key, func() (any, error) {
+		return createAzureGraphClient(p)
+	}, nil)
+}
+
 // Need to be checked before decommenting
 // func CallAzure(authProvider auth.IAuthProvider, provider string, version string, rsType string, rsName string, action string) (
 // 	*json.RawMessage, error) {
@@ -86,9 +123,7 @@ func getAzureClient(p auth.IAuthProvider) (*arm.Client, error) {
 // 	return CallAzureWithEndpoint(authProvider, version, endpoint, "")
 // }
 
-// CallAzureList: Send a request to Azure to list resources
-//
-// TODO: Deal with extra parameters for Azure request
+// CallAzureList: Send a request to Azure to list resources in the subscription of profile
 // @param: authProvider: IAuthProvider to provide profile of auth
 // @param: provider: Parameter for Azure common request
 // @param: version: Parameter for Azure common request
@@ -98,31 +133,211 @@ func getAzureClient(p auth.IAuthProvider) (*arm.Client, error) {
 // @return: Error
 func CallAzureList(authProvider auth.IAuthProvider, provider string, version string, rsType string, nextLink string) (
 	*json.RawMessage, error) {
-	var endpoint string
+	return recordCall(authProvider, def.AZURE, _recorderSecretAzure, "CallAzureList", []any{provider, version, rsType, nextLink},
+		func() (*json.RawMessage, error) {
+			return callAzureList(authProvider, "", "", provider, version, rsType, nil, nextLink)
+		})
+}
+
+// CallAzureListInScope: Send a request to Azure to list resources in the given subscription and resource group
+// @param: authProvider: IAuthProvider to provide profile of auth
+// @param: subscription: Id of subscription, the subscription of profile is used if empty
+// @param: resourceGroup: Name of resource group, resources in the whole subscription are listed if empty
+// @param: provider: Parameter for Azure common request
+// @param: version: Parameter for Azure common request
+// @param: rsType: Parameter for Azure common request
+// @param: extraParam: Query parameters such as "$filter", ignored if nextLink is not empty
+// @param: nextLink: Returned from the previous call for pagination
+// @return: Response data from Azure
+// @return: Error
+func CallAzureListInScope(authProvider auth.IAuthProvider, subscription string, resourceGroup string,
+	provider string, version string, rsType string, extraParam map[string]any, nextLink string) (*json.RawMessage, error) {
+	if len(subscription) == 0 && len(resourceGroup) == 0 && len(extraParam) == 0 {
+		return CallAzureList(authProvider, provider, version, rsType, nextLink)
+	}
+
+	return recordCall(authProvider, def.AZURE, _recorderSecretAzure, "CallAzureListInScope",
+		[]any{subscription, resourceGroup, provider, version, rsType, extraParam, nextLink},
+		func() (*json.RawMessage, error) {
+			return callAzureList(authProvider, subscription, resourceGroup, provider, version, rsType, extraParam, nextLink)
+		})
+}
+
+// callAzureList: Implementation of CallAzureList without Recorder
+func callAzureList(authProvider auth.IAuthProvider, subscription string, resourceGroup string,
+	provider string, version string, rsType string, extraParam map[string]any, nextLink string) (*json.RawMessage, error) {
 	if len(nextLink) > 0 {
-		// treat nextLink as endpoint
-		endpoint = nextLink
-	} else {
-		v, err := authProvider.GetProfile(def.AZURE)
-		if err != nil {
+		// treat nextLink as endpoint, which already contains the query parameters
+		return CallAzureWithEndpoint(authProvider, version, nextLink, "")
+	}
+
+	if len(subscription) == 0 {
+		var err error
+		if subscription, err = getAzureProfileSubscription(authProvider); err != nil {
 			return nil, err
 		}
+	}
 
-		endpoint = fmt.Sprintf("/subscriptions/%s/providers/Microsoft.%s/%s",
-			v.GetString(AZURE_SUBSCRIPTION_ID),
-			provider, rsType)
+	endpoint := fmt.Sprintf("/subscriptions/%s", subscription)
+	if len(resourceGroup) > 0 {
+		endpoint = fmt.Sprintf("%s/resourceGroups/%s", endpoint, resourceGroup)
 	}
+	endpoint = fmt.Sprintf("%s/providers/Microsoft.%s/%s", endpoint, provider, rsType)
 
 	// ignore action when listing
-	return CallAzureWithEndpoint(authProvider, version, endpoint, "")
+	return CallAzureWithEndpointAndParam(authProvider, version, endpoint, "", extraParam)
+}
+
+// getAzureProfileSubscription: Get the subscription set in the profile
+func getAzureProfileSubscription(authProvider auth.IAuthProvider) (string, error) {
+	if authProvider == nil {
+		return "", errors.New("nil pointor of IAuthProvider")
+	}
+	v, err := authProvider.GetProfile(def.AZURE)
+	if err != nil {
+		return "", err
+	}
+	if err := auth.IsAllSet(v, []string{AZURE_SUBSCRIPTION_ID}); err != nil {
+		return "", err
+	}
+
+	return v.GetString(AZURE_SUBSCRIPTION_ID), nil
+}
+
+const (
+	AZURE_SUBSCRIPTION_VERSION     = "2022-12-01"
+	AZURE_MANAGEMENT_GROUP_VERSION = "2020-05-01"
+	// Type of subscription in the descendants of management group
+	AZURE_MANAGEMENT_GROUP_SUBSCRIPTION_TYPE = "Microsoft.Management/managementGroups/subscriptions"
+)
+
+// azureListItem: Common fields of subscription and descendant of management group
+type azureListItem struct {
+	Name           string `json:"name"`
+	Type           string `json:"type"`
+	SubscriptionId string `json:"subscriptionId"`
+	State          string `json:"state"`
+}
+
+// listAzureAll: List all items in the "value" of response following nextLink
+func listAzureAll(authProvider auth.IAuthProvider, version string, endpoint string) ([]azureListItem, error) {
+	var fullList []azureListItem
+	for len(endpoint) > 0 {
+		res, err := CallAzureWithEndpoint(authProvider, version, endpoint, "")
+		if err != nil {
+			return nil, err
+		}
+
+		var page struct {
+			Value    []azureListItem `json:"value"`
+			NextLink string          `json:"nextLink"`
+		}
+		if err := json.Unmarshal(*res, &page); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response as json: %w", err)
+		}
+
+		fullList = append(fullList, page.Value...)
+		endpoint = page.NextLink
+	}
+
+	return fullList, nil
+}
+
+// GetAzureSubscriptions: Get ids of all enabled subscriptions visible to the service principal
+// @param: authProvider: IAuthProvider to provide profile of auth
+// @return: List of ids of subscription
+// @return: Error
+func GetAzureSubscriptions(authProvider auth.IAuthProvider) ([]string, error) {
+	items, err := listAzureAll(authProvider, AZURE_SUBSCRIPTION_VERSION, "/subscriptions")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subscriptions: %w", err)
+	}
+
+	subscriptions := make([]string, 0, len(items))
+	for _, item := range items {
+		if item.State == "Enabled" {
+			subscriptions = append(subscriptions, item.SubscriptionId)
+		}
+	}
+
+	return subscriptions, nil
+}
+
+// GetAzureManagementGroupSubscriptions: Get ids of all subscriptions under the management group,
+// including those under the descendant management groups
+// @param: authProvider: IAuthProvider to provide profile of auth
+// @param: managementGroup: Name (id) of management group
+// @return: List of ids of subscription
+// @return: Error
+func GetAzureManagementGroupSubscriptions(authProvider auth.IAuthProvider, managementGroup string) ([]string, error) {
+	items, err := listAzureAll(authProvider, AZURE_MANAGEMENT_GROUP_VERSION,
+		fmt.Sprintf("/providers/Microsoft.Management/managementGroups/%s/descendants", managementGroup))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list descendants of management group: %w", err)
+	}
+
+	subscriptions := make([]string, 0, len(items))
+	for _, item := range items {
+		if item.Type == AZURE_MANAGEMENT_GROUP_SUBSCRIPTION_TYPE {
+			subscriptions = append(subscriptions, item.Name)
+		}
+	}
+
+	return subscriptions, nil
+}
+
+// AZURE_PROVIDER_VERSION: Version of api to get resource provider
+const AZURE_PROVIDER_VERSION = "2021-04-01"
+
+// AzureResourceProvider: Registration state and resource types of resource provider in a subscription
+type AzureResourceProvider struct {
+	Namespace         string                      `json:"namespace"`
+	RegistrationState string                      `json:"registrationState"`
+	ResourceTypes     []AzureProviderResourceType `json:"resourceTypes"`
 }
 
-// CallAzureList: Send a request to Azure with an endpoint provided
+// AzureProviderResourceType: Resource type of resource provider and its available versions of api
+type AzureProviderResourceType struct {
+	ResourceType string   `json:"resourceType"`
+	ApiVersions  []string `json:"apiVersions"`
+}
+
+// GetAzureResourceProvider: Get resource provider in the subscription
+// @param: authProvider: IAuthProvider to provide profile of auth
+// @param: subscription: Id of subscription, the subscription of profile is used if empty
+// @param: namespace: Namespace of resource provider, such as "Microsoft.Storage"
+// @return: Resource provider
+// @return: Error
+func GetAzureResourceProvider(authProvider auth.IAuthProvider, subscription string, namespace string) (
+	*AzureResourceProvider, error) {
+	if len(namespace) == 0 {
+		return nil, errors.New("namespace of resource provider is empty")
+	}
+	if len(subscription) == 0 {
+		var err error
+		if subscription, err = getAzureProfileSubscription(authProvider); err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := CallAzureWithEndpoint(authProvider, AZURE_PROVIDER_VERSION,
+		fmt.Sprintf("/subscriptions/%s/providers/%s", subscription, namespace), "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resource provider %s: %w", namespace, err)
+	}
+
+	var provider AzureResourceProvider
+	if err := json.Unmarshal(*res, &provider); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response as json: %w", err)
+	}
+
+	return &provider, nil
+}
+
+// CallAzureWithEndpoint: Send a request to Azure with an endpoint provided
 //
 // The endpoint may be returned from the previous call as nextLink or resource id.
 // Other functions like CallAzureList also concats endpoint string from their own parameters.
-//
-// TODO: Deal with extra parameters for Azure request
 // @param: authProvider: IAuthProvider to provide profile of auth
 // @param: version: Parameter for Azure common request
 // @param: endpoint: Parameter for Azure common request
@@ -131,10 +346,47 @@ func CallAzureList(authProvider auth.IAuthProvider, provider string, version str
 // @return: Error
 func CallAzureWithEndpoint(authProvider auth.IAuthProvider, version string, endpoint string, action string) (
 	*json.RawMessage, error) {
+	return recordCall(authProvider, def.AZURE, _recorderSecretAzure, "CallAzureWithEndpoint", []any{version, endpoint, action},
+		func() (*json.RawMessage, error) {
+			return callAzureWithEndpoint(authProvider, version, endpoint, action, nil)
+		})
+}
+
+// CallAzureWithEndpointAndParam: Send a request to Azure with an endpoint and query parameters provided
+// @param: authProvider: IAuthProvider to provide profile of auth
+// @param: version: Parameter for Azure common request
+// @param: endpoint: Parameter for Azure common request
+// @param: action: Parameter for Azure common request
+// @param: extraParam: Query parameters such as "$filter", "$expand" and "$top"
+// @return: Response data from Azure
+// @return: Error
+func CallAzureWithEndpointAndParam(authProvider auth.IAuthProvider, version string, endpoint string, action string,
+	extraParam map[string]any) (*json.RawMessage, error) {
+	if len(extraParam) == 0 {
+		return CallAzureWithEndpoint(authProvider, version, endpoint, action)
+	}
+
+	return recordCall(authProvider, def.AZURE, _recorderSecretAzure, "CallAzureWithEndpointAndParam",
+		[]any{version, endpoint, action, extraParam},
+		func() (*json.RawMessage, error) {
+			return callAzureWithEndpoint(authProvider, version, endpoint, action, extraParam)
+		})
+}
+
+// callAzureWithEndpoint: Implementation of CallAzureWithEndpoint without Recorder
+func callAzureWithEndpoint(authProvider auth.IAuthProvider, version string, endpoint string, action string,
+	extraParam map[string]any) (*json.RawMessage, error) {
 	if len(endpoint) == 0 {
 		return nil, errors.New("endpoint for Azure is empty")
 	}
 
+	query, err := getAzureQuery(extraParam)
+	if err != nil {
+		return nil, err
+	}
+	// api-version can not be overridden by extraParam
+	query["api-version"] = version
+
 	if len(action) > 0 {
 		endpoint = fmt.Sprintf("%s/%s", endpoint, action)
 	}
@@ -149,19 +401,103 @@ func CallAzureWithEndpoint(authProvider auth.IAuthProvider, version string, endp
 		URL = runtime.JoinPaths(client.Endpoint(), endpoint)
 	}
 
+	return doAzureRequest(client.Pipeline(), URL, query)
+}
+
+// getAzureQuery: Convert extra parameters to query parameters of Azure
+// @param: extraParam: Extra parameters whose values must be scalar
+// @return: Query parameters
+// @return: Error
+func getAzureQuery(extraParam map[string]any) (map[string]string, error) {
+	query := make(map[string]string, len(extraParam)+1)
+	for k, v := range extraParam {
+		switch v.(type) {
+		case string, bool, int, int64, float64, json.Number:
+			query[k] = fmt.Sprint(v)
+		default:
+			return nil, fmt.Errorf("type of query parameter %s for Azure is not supported: %T", k, v)
+		}
+	}
+
+	return query, nil
+}
+
+// CallAzureGraph: Send a request to Microsoft Graph with an endpoint provided
+//
+// The endpoint may be returned from the previous call as @odata.nextLink,
+// or a path relative to the version such as "users" or "identity/conditionalAccess/policies".
+// @param: authProvider: IAuthProvider to provide profile of auth
+// @param: version: Version of Microsoft Graph, AZURE_GRAPH_DEFAULT_VERSION is used if empty
+// @param: endpoint: Path or url of the request
+// @param: action: Appended to the path of the request if not empty
+// @param: extraParam: Query parameters such as "$filter" and "$select"
+// @return: Response data from Microsoft Graph
+// @return: Error
+func CallAzureGraph(authProvider auth.IAuthProvider, version string, endpoint string, action string, extraParam map[string]any) (
+	*json.RawMessage, error) {
+	return recordCall(authProvider, def.AZURE, _recorderSecretAzure, "CallAzureGraph", []any{version, endpoint, action, extraParam},
+		func() (*json.RawMessage, error) {
+			return callAzureGraph(authProvider, version, endpoint, action, extraParam)
+		})
+}
+
+// callAzureGraph: Implementation of CallAzureGraph without Recorder
+func callAzureGraph(authProvider auth.IAuthProvider, version string, endpoint string, action string, extraParam map[string]any) (
+	*json.RawMessage, error) {
+	if len(endpoint) == 0 {
+		return nil, errors.New("endpoint for Microsoft Graph is empty")
+	}
+
+	query, err := getAzureQuery(extraParam)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(action) > 0 {
+		endpoint = fmt.Sprintf("%s/%s", endpoint, action)
+	}
+
+	client, err := getAzureGraphClient(authProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	URL := endpoint
+	if len(endpoint) < 4 || endpoint[:4] != "http" {
+		if len(version) == 0 {
+			version = AZURE_GRAPH_DEFAULT_VERSION
+		}
+		URL = runtime.JoinPaths(AZURE_GRAPH_ENDPOINT, version, endpoint)
+	}
+
+	return doAzureRequest(client.Pipeline(), URL, query)
+}
+
+// doAzureRequest: Send a GET request through the pipeline and check the response
+// @param: pipeline: Pipeline of client with policy of auth
+// @param: URL: Full url of the request
+// @param: query: Query parameters added to the url
+// @return: Response data in json
+// @return: Error
+func doAzureRequest(pipeline runtime.Pipeline, URL string, query map[string]string) (*json.RawMessage, error) {
 	ctx := context.Background()
 	req, err := runtime.NewRequest(ctx, http.MethodGet, URL)
 	if err != nil {
 		return nil, err
 	}
 
-	reqQP := req.Raw().URL.Query()
-	reqQP.Set("api-version", version)
-	req.Raw().URL.RawQuery = reqQP.Encode()
+	if len(query) > 0 {
+		// Query of nextLink is kept as is if no query parameter is added
+		reqQP := req.Raw().URL.Query()
+		for k, v := range query {
+			reqQP.Set(k, v)
+		}
+		req.Raw().URL.RawQuery = reqQP.Encode()
+	}
 	req.Raw().Header["Accept"] = []string{"application/json"}
 
 	_rlAzure.Take()
-	resp, err := client.Pipeline().Do(req)
+	resp, err := pipeline.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to invoke api: %w", err)
 	}
@@ -15,6 +15,7 @@ import (
 	"github.com/s3studio/cloud-bench-checker/pkg/auth"
 	"github.com/s3studio/cloud-bench-checker/test"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
@@ -131,6 +132,50 @@ func Test_getAzureClient(t *testing.T) {
 	}
 }
 
+func Test_createAzureGraphClient(t *testing.T) {
+	setupEnvAzure()
+	deferFn := setupAzureCredential()
+	defer deferFn()
+
+	type args struct {
+		p auth.IAuthProvider
+	}
+	tests := []struct {
+		name string
+		args args
+		//want    *azcore.Client
+		wantErr bool
+	}{
+		{
+			"Valid result",
+			args{auth.NewAuthFileProvider(test.Test_conf_azure)},
+			false,
+		},
+		{
+			"Profile not defined",
+			args{auth.NewAuthFileProvider(test.Test_conf_invalid)},
+			true,
+		},
+		{
+			"nil pointor of IAuthProvider",
+			args{nil},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := createAzureGraphClient(tt.args.p)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("createAzureGraphClient() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if (got == nil) != tt.wantErr {
+				t.Errorf("createAzureGraphClient() = %v, want a valid pointer", got)
+			}
+		})
+	}
+}
+
 type mockAzureClient struct {
 	Ep string
 	Pl runtime.Pipeline
@@ -297,3 +342,395 @@ func TestCallAzureWithEndpoint(t *testing.T) {
 		})
 	}
 }
+
+func setupDoAzureByPath(fnResp func(req *policy.Request) string) func() {
+	patchGetAzureClient := gomonkey.ApplyFunc(getAzureClient,
+		func(p auth.IAuthProvider) (*arm.Client, error) {
+			c := mockAzureClient{
+				Ep: "https://mock.domain",
+				Pl: runtime.Pipeline{},
+			}
+			return (*arm.Client)(unsafe.Pointer(&c)), nil
+		})
+
+	patchDo := gomonkey.ApplyMethodFunc(runtime.Pipeline{}, "Do",
+		func(req *policy.Request) (*http.Response, error) {
+			rr := httptest.ResponseRecorder{
+				Code: 200,
+				Body: bytes.NewBufferString(fnResp(req)),
+			}
+			return rr.Result(), nil
+		})
+
+	return func() {
+		patchDo.Reset()
+		patchGetAzureClient.Reset()
+	}
+}
+
+func TestCallAzureListInScope(t *testing.T) {
+	setupEnvAzure()
+	deferFn := setupAzureCredential()
+	defer deferFn()
+	deferFn2 := setupDoAzureByPath(func(req *policy.Request) string {
+		return `{"path":"` + req.Raw().URL.Path + `","filter":"` + req.Raw().URL.Query().Get("$filter") + `"}`
+	})
+	defer deferFn2()
+	var rmProfile json.RawMessage = []byte(`{"path":"/subscriptions/mock_subid/providers/Microsoft.Compute/virtualMachines","filter":""}`)
+	var rmSubscription json.RawMessage = []byte(`{"path":"/subscriptions/sub-1/providers/Microsoft.Compute/virtualMachines","filter":""}`)
+	var rmResourceGroup json.RawMessage = []byte(
+		`{"path":"/subscriptions/sub-1/resourceGroups/rg-1/providers/Microsoft.Compute/virtualMachines","filter":"mock"}`)
+
+	type args struct {
+		authProvider  auth.IAuthProvider
+		subscription  string
+		resourceGroup string
+		extraParam    map[string]any
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *json.RawMessage
+		wantErr bool
+	}{
+		{
+			"Valid result in subscription of profile",
+			args{auth.NewAuthFileProvider(test.Test_conf_azure), "", "", nil},
+			&rmProfile,
+			false,
+		},
+		{
+			"Valid result in given subscription",
+			args{auth.NewAuthFileProvider(test.Test_conf_azure), "sub-1", "", nil},
+			&rmSubscription,
+			false,
+		},
+		{
+			"Valid result in given resource group with query parameters",
+			args{auth.NewAuthFileProvider(test.Test_conf_azure), "sub-1", "rg-1", map[string]any{"$filter": "mock"}},
+			&rmResourceGroup,
+			false,
+		},
+		{
+			"Key of subscription not set",
+			args{&test.MockKeyNotSetAuthProvider{}, "", "rg-1", nil},
+			nil,
+			true,
+		},
+		{
+			"type of query parameter not supported",
+			args{auth.NewAuthFileProvider(test.Test_conf_azure), "sub-1", "", map[string]any{"$filter": []string{"mock"}}},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CallAzureListInScope(tt.args.authProvider, tt.args.subscription, tt.args.resourceGroup,
+				"Compute", "", "virtualMachines", tt.args.extraParam, "")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CallAzureListInScope() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CallAzureListInScope() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getAzureQuery(t *testing.T) {
+	type args struct {
+		extraParam map[string]any
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			"Valid result",
+			args{map[string]any{"$filter": "mock", "$top": 10, "mock": true}},
+			map[string]string{"$filter": "mock", "$top": "10", "mock": "true"},
+			false,
+		},
+		{
+			"Valid result of nil",
+			args{nil},
+			map[string]string{},
+			false,
+		},
+		{
+			"type of query parameter not supported",
+			args{map[string]any{"$filter": map[string]any{}}},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getAzureQuery(tt.args.extraParam)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getAzureQuery() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getAzureQuery() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func setupListAzureSubscriptions() func() {
+	return setupDoAzureByPath(func(req *policy.Request) string {
+		switch req.Raw().URL.Path {
+		case "/subscriptions":
+			return `{"value":[{"subscriptionId":"sub-1","state":"Enabled"},{"subscriptionId":"sub-2","state":"Disabled"}],` +
+				`"nextLink":"https://mock.domain/subscriptions/next"}`
+		case "/subscriptions/next":
+			return `{"value":[{"subscriptionId":"sub-3","state":"Enabled"}]}`
+		case "/providers/Microsoft.Management/managementGroups/mg-1/descendants":
+			return `{"value":[{"name":"mg-2","type":"Microsoft.Management/managementGroups"},` +
+				`{"name":"sub-1","type":"Microsoft.Management/managementGroups/subscriptions"}]}`
+		default:
+			return `{"value":"invalid"}`
+		}
+	})
+}
+
+func TestGetAzureSubscriptions(t *testing.T) {
+	setupEnvAzure()
+	deferFn := setupAzureCredential()
+	defer deferFn()
+	deferFn2 := setupListAzureSubscriptions()
+	defer deferFn2()
+
+	type args struct {
+		authProvider auth.IAuthProvider
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []string
+		wantErr bool
+	}{
+		{
+			"Valid result",
+			args{auth.NewAuthFileProvider(test.Test_conf_azure)},
+			[]string{"sub-1", "sub-3"},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetAzureSubscriptions(tt.args.authProvider)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetAzureSubscriptions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetAzureSubscriptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetAzureManagementGroupSubscriptions(t *testing.T) {
+	setupEnvAzure()
+	deferFn := setupAzureCredential()
+	defer deferFn()
+	deferFn2 := setupListAzureSubscriptions()
+	defer deferFn2()
+
+	type args struct {
+		authProvider    auth.IAuthProvider
+		managementGroup string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []string
+		wantErr bool
+	}{
+		{
+			"Valid result",
+			args{auth.NewAuthFileProvider(test.Test_conf_azure), "mg-1"},
+			[]string{"sub-1"},
+			false,
+		},
+		{
+			"failed to unmarshal response",
+			args{auth.NewAuthFileProvider(test.Test_conf_azure), "invalid"},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetAzureManagementGroupSubscriptions(tt.args.authProvider, tt.args.managementGroup)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetAzureManagementGroupSubscriptions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetAzureManagementGroupSubscriptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetAzureResourceProvider(t *testing.T) {
+	setupEnvAzure()
+	deferFn := setupAzureCredential()
+	defer deferFn()
+	deferFn2 := setupDoAzureByPath(func(req *policy.Request) string {
+		switch req.Raw().URL.Path {
+		case "/subscriptions/mock_subid/providers/Microsoft.Storage", "/subscriptions/sub-1/providers/Microsoft.Storage":
+			return `{"namespace":"Microsoft.Storage","registrationState":"Registered",` +
+				`"resourceTypes":[{"resourceType":"storageAccounts","apiVersions":["2023-05-01"]}]}`
+		default:
+			return `invalid`
+		}
+	})
+	defer deferFn2()
+	want := &AzureResourceProvider{
+		Namespace:         "Microsoft.Storage",
+		RegistrationState: "Registered",
+		ResourceTypes:     []AzureProviderResourceType{{ResourceType: "storageAccounts", ApiVersions: []string{"2023-05-01"}}},
+	}
+
+	type args struct {
+		authProvider auth.IAuthProvider
+		subscription string
+		namespace    string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *AzureResourceProvider
+		wantErr bool
+	}{
+		{
+			"Valid result",
+			args{auth.NewAuthFileProvider(test.Test_conf_azure), "sub-1", "Microsoft.Storage"},
+			want,
+			false,
+		},
+		{
+			"Valid result in subscription of profile",
+			args{auth.NewAuthFileProvider(test.Test_conf_azure), "", "Microsoft.Storage"},
+			want,
+			false,
+		},
+		{
+			"namespace of resource provider is empty",
+			args{auth.NewAuthFileProvider(test.Test_conf_azure), "sub-1", ""},
+			nil,
+			true,
+		},
+		{
+			"failed to get resource provider",
+			args{auth.NewAuthFileProvider(test.Test_conf_azure), "sub-1", "Microsoft.Invalid"},
+			nil,
+			true,
+		},
+		{
+			"nil pointor of IAuthProvider",
+			args{nil, "", "Microsoft.Storage"},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetAzureResourceProvider(tt.args.authProvider, tt.args.subscription, tt.args.namespace)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetAzureResourceProvider() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetAzureResourceProvider() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCallAzureGraph(t *testing.T) {
+	setupEnvAzure()
+	patchGetAzureGraphClient := gomonkey.ApplyFunc(getAzureGraphClient,
+		func(p auth.IAuthProvider) (*azcore.Client, error) {
+			return azcore.NewClient("mock", "v0.0.1", runtime.PipelineOptions{}, nil)
+		})
+	defer patchGetAzureGraphClient.Reset()
+	patchDo := gomonkey.ApplyMethodFunc(runtime.Pipeline{}, "Do",
+		func(req *policy.Request) (*http.Response, error) {
+			rr := httptest.ResponseRecorder{
+				Code: 200,
+				Body: bytes.NewBufferString(`{"url":"` + req.Raw().URL.String() + `"}`),
+			}
+			return rr.Result(), nil
+		})
+	defer patchDo.Reset()
+	var rmDefault json.RawMessage = []byte(`{"url":"https://graph.microsoft.com/v1.0/users/mock-id/memberOf"}`)
+	var rmBeta json.RawMessage = []byte(`{"url":"https://graph.microsoft.com/beta/users"}`)
+	var rmNextLink json.RawMessage = []byte(`{"url":"https://graph.microsoft.com/v1.0/users?$skiptoken=mock"}`)
+	var rmParam json.RawMessage = []byte(`{"url":"https://graph.microsoft.com/v1.0/users?%24top=1"}`)
+
+	type args struct {
+		authProvider auth.IAuthProvider
+		version      string
+		endpoint     string
+		action       string
+		extraParam   map[string]any
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *json.RawMessage
+		wantErr bool
+	}{
+		{
+			"Valid result of default version",
+			args{auth.NewAuthFileProvider(test.Test_conf_azure), "", "users/mock-id", "memberOf", nil},
+			&rmDefault,
+			false,
+		},
+		{
+			"Valid result of given version",
+			args{auth.NewAuthFileProvider(test.Test_conf_azure), "beta", "users", "", nil},
+			&rmBeta,
+			false,
+		},
+		{
+			"Valid result of nextLink",
+			args{auth.NewAuthFileProvider(test.Test_conf_azure), "beta", "https://graph.microsoft.com/v1.0/users?$skiptoken=mock", "", nil},
+			&rmNextLink,
+			false,
+		},
+		{
+			"Valid result with query parameters",
+			args{auth.NewAuthFileProvider(test.Test_conf_azure), "", "users", "", map[string]any{"$top": 1}},
+			&rmParam,
+			false,
+		},
+		{
+			"endpoint for Microsoft Graph is empty",
+			args{auth.NewAuthFileProvider(test.Test_conf_azure), "", "", "", nil},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CallAzureGraph(tt.args.authProvider, tt.args.version, tt.args.endpoint, tt.args.action, tt.args.extraParam)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CallAzureGraph() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CallAzureGraph() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
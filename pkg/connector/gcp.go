@@ -0,0 +1,253 @@
+// Connector for Google Cloud Platform using REST APIs of discovery-style
+
+package connector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/s3studio/cloud-bench-checker/internal"
+	"github.com/s3studio/cloud-bench-checker/pkg/auth"
+	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
+
+	"go.uber.org/ratelimit"
+	"golang.org/x/oauth2/jwt"
+)
+
+const (
+	GCP_DEFAULT_TOKEN_URI = "https://oauth2.googleapis.com/token"
+	GCP_DEFAULT_DOMAIN    = "googleapis.com"
+	GCP_SCOPE_READONLY    = "https://www.googleapis.com/auth/cloud-platform.read-only"
+
+	// Placeholder of project id in path and string values of extraParam
+	GCP_PROJECT_PLACEHOLDER = "{project}"
+)
+
+// gcpCredentialFile: Fields used in the json key file of service account
+type gcpCredentialFile struct {
+	Type           string `json:"type"`
+	ProjectId      string `json:"project_id"`
+	PrivateKeyId   string `json:"private_key_id"`
+	PrivateKey     string `json:"private_key"`
+	ClientEmail    string `json:"client_email"`
+	TokenUri       string `json:"token_uri"`
+	UniverseDomain string `json:"universe_domain"`
+	// Not defined by Google, base url used instead of the public endpoint of each service
+	EndpointUrl string `json:"endpoint_url"`
+}
+
+// Bind authorized http client with project
+type gcpClient struct {
+	hc      *http.Client
+	project string
+	domain  string
+	// Override of base url
+	endpoint string
+}
+
+func createGCPClient(p auth.IAuthProvider) (*gcpClient, error) {
+	if p == nil {
+		return nil, errors.New("nil pointor of IAuthProvider")
+	}
+
+	credentialPathname, err := p.GetProfilePathname(def.GCP)
+	if err != nil {
+		return nil, err
+	}
+
+	byCredential, err := os.ReadFile(credentialPathname)
+	if err != nil {
+		// Do not use value of err to avoid leaking the file path
+		return nil, errors.New("unable to read credential file of service account")
+	}
+
+	var f gcpCredentialFile
+	if err := json.Unmarshal(byCredential, &f); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal credential file as json: %w", err)
+	}
+	if f.Type != "service_account" {
+		return nil, fmt.Errorf("invalid type of credential \"%s\", only service_account is supported", f.Type)
+	}
+	if len(f.ProjectId) == 0 || len(f.ClientEmail) == 0 || len(f.PrivateKey) == 0 {
+		return nil, errors.New("failed to read project_id, client_email or private_key from credential file")
+	}
+
+	config := &jwt.Config{
+		Email:        f.ClientEmail,
+		PrivateKey:   []byte(f.PrivateKey),
+		PrivateKeyID: f.PrivateKeyId,
+		Scopes:       []string{GCP_SCOPE_READONLY},
+		TokenURL:     f.TokenUri,
+	}
+	if len(config.TokenURL) == 0 {
+		config.TokenURL = GCP_DEFAULT_TOKEN_URI
+	}
+
+	client := gcpClient{
+		hc:       config.Client(context.Background()),
+		project:  f.ProjectId,
+		domain:   f.UniverseDomain,
+		endpoint: strings.TrimRight(f.EndpointUrl, "/"),
+	}
+	if len(client.domain) == 0 {
+		client.domain = GCP_DEFAULT_DOMAIN
+	}
+
+	return &client, nil
+}
+
+var (
+	_mapGCPClient internal.SyncMap[*gcpClient]
+
+	_rlGCP = ratelimit.New(10, ratelimit.WithoutSlack)
+)
+
+func getGCPClient(p auth.IAuthProvider) (*gcpClient, error) {
+	key := fmt.Sprintf("%p_default", p)
+	return _mapGCPClient.LoadOrCreate(key, func() (any, error) {
+		return createGCPClient(p)
+	}, nil)
+}
+
+// buildURL: Concat url of request
+//
+// - path is an absolute url: path is treated as the full url, e.g. selfLink of a resource,
+// which must be of https on the domain of GCP (or the overridden base url), as the token is sent to it
+//
+// - path starts with "/": {base}/{version}{path}
+//
+// - otherwise: {base}/{version}/projects/{project}/{path}
+func (c *gcpClient) buildURL(service string, version string, path string) (string, error) {
+	path = strings.ReplaceAll(path, GCP_PROJECT_PLACEHOLDER, c.project)
+	if u := parseAbsoluteURL(path); u != nil {
+		if !c.isTrustedURL(u) {
+			return "", fmt.Errorf("url of GCP is not on the domain of %s: %s", c.domain, u.Redacted())
+		}
+		return path, nil
+	}
+
+	base := c.endpoint
+	if len(base) == 0 {
+		base = fmt.Sprintf("https://%s.%s", service, c.domain)
+	}
+
+	if strings.HasPrefix(path, "/") {
+		return fmt.Sprintf("%s/%s%s", base, version, path), nil
+	}
+
+	URL := fmt.Sprintf("%s/%s/projects/%s", base, version, c.project)
+	if len(path) > 0 {
+		URL = fmt.Sprintf("%s/%s", URL, path)
+	}
+	return URL, nil
+}
+
+// isTrustedURL: Check whether the full url is of https on the domain of GCP, or of the overridden base url
+func (c *gcpClient) isTrustedURL(u *url.URL) bool {
+	if len(c.endpoint) > 0 {
+		return isSameOrigin(u, parseAbsoluteURL(c.endpoint))
+	}
+
+	host := strings.ToLower(u.Hostname())
+	return u.Scheme == "https" && (host == c.domain || strings.HasSuffix(host, "."+c.domain))
+}
+
+// CallGCP: Send a request to GCP and parse response
+//
+// The project is defined in the credential file of service account,
+// and "{project}" in path and string values of extraParam is replaced by it.
+// @param: authProvider: IAuthProvider to provide pathname of credential file of service account
+// @param: service: Name of service used in endpoint, e.g. "iam"
+// @param: version: Version of API, may contain prefix of path, e.g. "v1" or "compute/v1"
+// @param: method: Method of http request, GET if empty
+// @param: path: Path of resource, see gcpClient.buildURL for details
+// @param: extraParam: Query parameters for GET, or json body for other methods
+// @return: Response data from GCP
+// @return: Error
+func CallGCP(authProvider auth.IAuthProvider, service string, version string, method string, path string, extraParam map[string]any) (
+	*json.RawMessage, error) {
+	return recordCall(authProvider, def.GCP, nil, "CallGCP", []any{service, version, method, path, extraParam},
+		func() (*json.RawMessage, error) {
+			return callGCP(authProvider, service, version, method, path, extraParam)
+		})
+}
+
+// callGCP: Implementation of CallGCP without Recorder
+func callGCP(authProvider auth.IAuthProvider, service string, version string, method string, path string, extraParam map[string]any) (
+	*json.RawMessage, error) {
+	client, err := getGCPClient(authProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(method) == 0 {
+		method = http.MethodGet
+	}
+
+	URL, err := client.buildURL(service, version, path)
+	if err != nil {
+		return nil, err
+	}
+	var body io.Reader
+	if method == http.MethodGet {
+		query := url.Values{}
+		for k, v := range extraParam {
+			if err := addQueryValue(query, k, v, replacePlaceholder(GCP_PROJECT_PLACEHOLDER, client.project)); err != nil {
+				return nil, err
+			}
+		}
+		if len(query) > 0 {
+			URL = fmt.Sprintf("%s?%s", URL, query.Encode())
+		}
+	} else {
+		if extraParam == nil {
+			extraParam = make(map[string]any)
+		}
+		byBody, err := json.Marshal(extraParam)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal extraParam: %w", err)
+		}
+		body = bytes.NewReader(byBody)
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), method, URL, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	_rlGCP.Take()
+	resp, err := client.hc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to invoke api: %w", err)
+	}
+
+	defer resp.Body.Close()
+	byResp, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("response indicates failure with status %d: %s", resp.StatusCode, string(byResp))
+	}
+
+	responseMap := make(map[string]json.RawMessage)
+	if err := internal.JsonUnmarshal(byResp, &responseMap); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response as json: %w", err)
+	}
+
+	var rm json.RawMessage = byResp
+	return &rm, nil
+}
@@ -0,0 +1,338 @@
+// Connector for Google Cloud Platform using REST APIs of discovery-style
+
+package connector
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/s3studio/cloud-bench-checker/pkg/auth"
+	"github.com/s3studio/cloud-bench-checker/test"
+)
+
+// setupEnvGCP: Create credential file of service account and set it as GOOGLE_APPLICATION_CREDENTIALS
+func setupEnvGCP(t *testing.T, serverURL string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	byCredential, _ := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"project_id":   "mock-project",
+		"private_key":  string(byKey),
+		"client_email": "mock@mock-project.iam.gserviceaccount.com",
+		"token_uri":    serverURL + "/token",
+		"endpoint_url": serverURL,
+	})
+	pathname := filepath.Join(t.TempDir(), "sa.json")
+	if err := os.WriteFile(pathname, byCredential, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", pathname)
+}
+
+func Test_createGCPClient(t *testing.T) {
+	setupEnvGCP(t, "http://mock")
+
+	type args struct {
+		p auth.IAuthProvider
+	}
+	tests := []struct {
+		name string
+		args args
+		//want    *gcpClient
+		wantErr bool
+	}{
+		{
+			"Valid result",
+			args{auth.NewAuthFileProvider(test.Test_conf_gcp)},
+			false,
+		},
+		{
+			"Profile not defined",
+			args{auth.NewAuthFileProvider(test.Test_conf_invalid)},
+			true,
+		},
+		{
+			"Key not set",
+			args{&test.MockKeyNotSetAuthProvider{}},
+			true,
+		},
+		{
+			"nil pointor of IAuthProvider",
+			args{nil},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := createGCPClient(tt.args.p)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("createGCPClient() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if (got == nil) != tt.wantErr {
+				t.Errorf("createGCPClient() = %v, want a valid pointer", got)
+			}
+		})
+	}
+}
+
+func Test_createGCPClient_invalidFile(t *testing.T) {
+	dir := t.TempDir()
+	pathnameNotSA := filepath.Join(dir, "user.json")
+	os.WriteFile(pathnameNotSA, []byte(`{"type":"authorized_user"}`), 0600)
+	pathnameNotJson := filepath.Join(dir, "invalid.json")
+	os.WriteFile(pathnameNotJson, []byte(`invalid`), 0600)
+
+	tests := []struct {
+		name     string
+		pathname string
+	}{
+		{"File not found", filepath.Join(dir, "not_found.json")},
+		{"Not json", pathnameNotJson},
+		{"Not service account", pathnameNotSA},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", tt.pathname)
+			if _, err := createGCPClient(auth.NewAuthFileProvider(test.Test_conf_gcp)); err == nil {
+				t.Errorf("createGCPClient() error = nil, wantErr true")
+			}
+		})
+	}
+}
+
+func Test_getGCPClient(t *testing.T) {
+	setupEnvGCP(t, "http://mock")
+
+	type args struct {
+		p auth.IAuthProvider
+	}
+	tests := []struct {
+		name string
+		args args
+		//want    *gcpClient
+		wantErr bool
+	}{
+		{
+			"Valid result",
+			args{auth.NewAuthFileProvider(test.Test_conf_gcp)},
+			false,
+		},
+		{
+			"Profile not defined",
+			args{auth.NewAuthFileProvider(test.Test_conf_invalid)},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getGCPClient(tt.args.p)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getGCPClient() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if (got == nil) != tt.wantErr {
+				t.Errorf("getGCPClient() = %v, want a valid pointer", got)
+			}
+		})
+	}
+}
+
+func Test_gcpClient_buildURL(t *testing.T) {
+	client := &gcpClient{project: "p", domain: GCP_DEFAULT_DOMAIN}
+	clientOverride := &gcpClient{project: "p", domain: GCP_DEFAULT_DOMAIN, endpoint: "http://127.0.0.1"}
+
+	type args struct {
+		service string
+		version string
+		path    string
+	}
+	tests := []struct {
+		name    string
+		c       *gcpClient
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			"Path under project",
+			client,
+			args{"iam", "v1", "serviceAccounts"},
+			"https://iam.googleapis.com/v1/projects/p/serviceAccounts",
+			false,
+		},
+		{
+			"Empty path",
+			client,
+			args{"cloudresourcemanager", "v1", ""},
+			"https://cloudresourcemanager.googleapis.com/v1/projects/p",
+			false,
+		},
+		{
+			"Path from root",
+			client,
+			args{"storage", "storage/v1", "/b"},
+			"https://storage.googleapis.com/storage/v1/b",
+			false,
+		},
+		{
+			"Path of full url with placeholder",
+			client,
+			args{"compute", "compute/v1", "https://compute.googleapis.com/compute/v1/projects/{project}/global/networks"},
+			"https://compute.googleapis.com/compute/v1/projects/p/global/networks",
+			false,
+		},
+		{
+			"Endpoint overridden",
+			clientOverride,
+			args{"iam", "v1", "serviceAccounts"},
+			"http://127.0.0.1/v1/projects/p/serviceAccounts",
+			false,
+		},
+		{
+			"Path starting with http but not url",
+			client,
+			args{"compute", "compute/v1", "httpHealthChecks"},
+			"https://compute.googleapis.com/compute/v1/projects/p/httpHealthChecks",
+			false,
+		},
+		{
+			"Full url of http",
+			client,
+			args{"compute", "compute/v1", "http://compute.googleapis.com/compute/v1/projects/p"},
+			"",
+			true,
+		},
+		{
+			"Full url of foreign host",
+			client,
+			args{"compute", "compute/v1", "https://compute.googleapis.com.mock.com/compute/v1/projects/p"},
+			"",
+			true,
+		},
+		{
+			"Full url not of overridden endpoint",
+			clientOverride,
+			args{"iam", "v1", "https://iam.googleapis.com/v1/projects/p"},
+			"",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.c.buildURL(tt.args.service, tt.args.version, tt.args.path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("gcpClient.buildURL() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("gcpClient.buildURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCallGCP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"access_token":"mock_token","token_type":"Bearer","expires_in":3600}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer mock_token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/projects/mock-project/serviceAccounts":
+			w.Write([]byte(`{"accounts":[{"name":"` + r.URL.Query().Get("filter") + `"}]}`))
+		case "/v1/projects/mock-project:getIamPolicy":
+			byBody, _ := io.ReadAll(r.Body)
+			w.Write(byBody) // echo
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"code":404}}`))
+		}
+	}))
+	defer server.Close()
+	setupEnvGCP(t, server.URL)
+
+	authProvider := auth.NewAuthFileProvider(test.Test_conf_gcp)
+	var rmList json.RawMessage = []byte(`{"accounts":[{"name":"mock-project"}]}`)
+	var rmPost json.RawMessage = []byte(`{"options":{"requestedPolicyVersion":3}}`)
+
+	type args struct {
+		authProvider auth.IAuthProvider
+		service      string
+		version      string
+		method       string
+		path         string
+		extraParam   map[string]any
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *json.RawMessage
+		wantErr bool
+	}{
+		{
+			"Valid result of GET",
+			args{authProvider, "iam", "v1", "", "serviceAccounts", map[string]any{"filter": "{project}"}},
+			&rmList,
+			false,
+		},
+		{
+			"Valid result of POST",
+			args{authProvider, "cloudresourcemanager", "v1", "POST", "/projects/{project}:getIamPolicy",
+				map[string]any{"options": map[string]any{"requestedPolicyVersion": 3}}},
+			&rmPost,
+			false,
+		},
+		{
+			"Response indicates failure",
+			args{authProvider, "iam", "v1", "", "notfound", nil},
+			nil,
+			true,
+		},
+		{
+			"Unsupported type of query param",
+			args{authProvider, "iam", "v1", "", "serviceAccounts", map[string]any{"filter": 1.5}},
+			nil,
+			true,
+		},
+		{
+			"Profile not defined",
+			args{auth.NewAuthFileProvider(test.Test_conf_invalid), "iam", "v1", "", "serviceAccounts", nil},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CallGCP(tt.args.authProvider, tt.args.service, tt.args.version, tt.args.method, tt.args.path, tt.args.extraParam)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CallGCP() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CallGCP() = %v, want %v", string(*got), string(*tt.want))
+			}
+		})
+	}
+}
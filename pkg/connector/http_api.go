@@ -0,0 +1,402 @@
+// Connector for generic HTTP/REST APIs returning json
+
+package connector
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/s3studio/cloud-bench-checker/internal"
+	"github.com/s3studio/cloud-bench-checker/pkg/auth"
+	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
+
+	"github.com/spf13/viper"
+	"go.uber.org/ratelimit"
+)
+
+const (
+	HTTP_API_DEFAULT_PROFILE_PREFIX = "HTTP_API"
+
+	// Suffix of keys in profile, the full key is "{prefix}_{suffix}", e.g. "HTTP_API_TOKEN"
+	HTTP_API_BASE_URL       = "BASE_URL"
+	HTTP_API_AUTH_TYPE      = "AUTH_TYPE"
+	HTTP_API_TOKEN          = "TOKEN"
+	HTTP_API_USERNAME       = "USERNAME"
+	HTTP_API_PASSWORD       = "PASSWORD"
+	HTTP_API_API_KEY        = "API_KEY"
+	HTTP_API_API_KEY_HEADER = "API_KEY_HEADER"
+	HTTP_API_HMAC_KEY_ID    = "HMAC_KEY_ID"
+	HTTP_API_HMAC_SECRET    = "HMAC_SECRET"
+
+	HTTP_API_DEFAULT_API_KEY_HEADER = "X-API-Key"
+	HTTP_API_HMAC_TIMESTAMP_HEADER  = "X-Timestamp"
+)
+
+// HttpAuthType: Auth scheme of generic HTTP API defined in profile
+type HttpAuthType string
+
+const (
+	HTTP_AUTH_NONE    HttpAuthType = "none"
+	HTTP_AUTH_BEARER  HttpAuthType = "bearer"
+	HTTP_AUTH_BASIC   HttpAuthType = "basic"
+	HTTP_AUTH_API_KEY HttpAuthType = "apikey"
+	HTTP_AUTH_HMAC    HttpAuthType = "hmac"
+)
+
+// Bind http client with profile
+type httpApiClient struct {
+	hc *http.Client
+	v  *viper.Viper
+}
+
+func createHttpApiClient(p auth.IAuthProvider) (*httpApiClient, error) {
+	if p == nil {
+		return nil, errors.New("nil pointor of IAuthProvider")
+	}
+
+	v, err := p.GetProfile(def.HTTP_API)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, errors.New("invalid viper instance of nil")
+	}
+
+	return &httpApiClient{
+		hc: &http.Client{Timeout: 60 * time.Second},
+		v:  v,
+	}, nil
+}
+
+var (
+	_mapHttpApiClient internal.SyncMap[*httpApiClient]
+
+	_rlHttpApi = ratelimit.New(10, ratelimit.WithoutSlack)
+)
+
+func getHttpApiClient(p auth.IAuthProvider) (*httpApiClient, error) {
+	key := fmt.Sprintf("%p_default", p)
+	return _mapHttpApiClient.LoadOrCreate(key, func() (any, error) {
+		return createHttpApiClient(p)
+	}, nil)
+}
+
+// profileKey: Get full key in profile with prefix
+func (c *httpApiClient) profileKey(prefix string, suffix string) string {
+	return fmt.Sprintf("%s_%s", prefix, suffix)
+}
+
+// httpTemplateData: Data used to execute templates in ConfHttpCmd
+type httpTemplateData struct {
+	// Parameters of pagination or id
+	Param map[string]any
+}
+
+// execTemplate: Execute a template of ConfHttpCmd
+//
+// Besides ".Param", the function "profile" is provided to read a value from profile, e.g. {{profile "GITHUB_ORG"}}
+func (c *httpApiClient) execTemplate(name string, text string, data *httpTemplateData) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+
+	tmpl, err := template.New(name).
+		Option("missingkey=zero").
+		Funcs(template.FuncMap{"profile": c.v.GetString}).
+		Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template of %s: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute template of %s: %w", name, err)
+	}
+
+	return buf.String(), nil
+}
+
+// setAuth: Set auth info of request according to auth type in profile
+func (c *httpApiClient) setAuth(req *http.Request, prefix string, body []byte) error {
+	authType := HttpAuthType(strings.ToLower(c.v.GetString(c.profileKey(prefix, HTTP_API_AUTH_TYPE))))
+	requireKeys := func(suffixes ...string) error {
+		keys := make([]string, len(suffixes))
+		for i, suffix := range suffixes {
+			keys[i] = c.profileKey(prefix, suffix)
+		}
+		return auth.IsAllSet(c.v, keys)
+	}
+
+	switch authType {
+	case HTTP_AUTH_NONE, "":
+	case HTTP_AUTH_BEARER:
+		if err := requireKeys(HTTP_API_TOKEN); err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+c.v.GetString(c.profileKey(prefix, HTTP_API_TOKEN)))
+	case HTTP_AUTH_BASIC:
+		if err := requireKeys(HTTP_API_USERNAME, HTTP_API_PASSWORD); err != nil {
+			return err
+		}
+		req.SetBasicAuth(
+			c.v.GetString(c.profileKey(prefix, HTTP_API_USERNAME)),
+			c.v.GetString(c.profileKey(prefix, HTTP_API_PASSWORD)),
+		)
+	case HTTP_AUTH_API_KEY:
+		if err := requireKeys(HTTP_API_API_KEY); err != nil {
+			return err
+		}
+		header := c.v.GetString(c.profileKey(prefix, HTTP_API_API_KEY_HEADER))
+		if len(header) == 0 {
+			header = HTTP_API_DEFAULT_API_KEY_HEADER
+		}
+		req.Header.Set(header, c.v.GetString(c.profileKey(prefix, HTTP_API_API_KEY)))
+	case HTTP_AUTH_HMAC:
+		if err := requireKeys(HTTP_API_HMAC_KEY_ID, HTTP_API_HMAC_SECRET); err != nil {
+			return err
+		}
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		signature := signHttpApiHmac(
+			c.v.GetString(c.profileKey(prefix, HTTP_API_HMAC_SECRET)),
+			req.Method, req.URL.RequestURI(), timestamp, body,
+		)
+		req.Header.Set(HTTP_API_HMAC_TIMESTAMP_HEADER, timestamp)
+		req.Header.Set("Authorization", fmt.Sprintf("HMAC-SHA256 KeyId=%s, Signature=%s",
+			c.v.GetString(c.profileKey(prefix, HTTP_API_HMAC_KEY_ID)), signature))
+	default:
+		return fmt.Errorf("unsupported auth type \"%s\" for HTTP API", authType)
+	}
+
+	return nil
+}
+
+// signHttpApiHmac: Calculate signature of HMAC-SHA256 in base64
+//
+// The string to sign is "{method}\n{request uri}\n{timestamp}\n{hex of sha256 of body}"
+func signHttpApiHmac(secret string, method string, requestURI string, timestamp string, body []byte) string {
+	hashBody := sha256.Sum256(body)
+	stringToSign := strings.Join([]string{method, requestURI, timestamp, hex.EncodeToString(hashBody[:])}, "\n")
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// CallHttpApi: Send a request to a generic HTTP API and parse response
+//
+// For "GET", param is added to the query string.
+// For other methods, param is used as json body if the template of body is not defined.
+// Param is also accessible as ".Param" in all templates.
+// @param: authProvider: IAuthProvider to provide profile of auth
+// @param: cmd: Definition of request
+// @param: nextLink: Url got from Link header of the previous response, which takes place of url and query if not empty
+// @param: param: Parameters of pagination or id
+// @return: Response data
+// @return: Url of the next page in Link header resolved against the url of the request, empty if not found
+// @return: Error
+func CallHttpApi(authProvider auth.IAuthProvider, cmd *def.ConfHttpCmd, nextLink string, param map[string]any) (
+	*json.RawMessage, string, error) {
+	var secretKeys []string
+	if cmd != nil {
+		prefix := cmd.ProfilePrefix
+		if len(prefix) == 0 {
+			prefix = HTTP_API_DEFAULT_PROFILE_PREFIX
+		}
+		for _, suffix := range []string{HTTP_API_TOKEN, HTTP_API_PASSWORD, HTTP_API_API_KEY, HTTP_API_HMAC_SECRET} {
+			secretKeys = append(secretKeys, fmt.Sprintf("%s_%s", prefix, suffix))
+		}
+	}
+
+	res, err := recordCall(authProvider, def.HTTP_API, secretKeys, "CallHttpApi", []any{cmd, nextLink, param},
+		func() (httpApiResult, error) {
+			data, next, err := callHttpApi(authProvider, cmd, nextLink, param)
+			return httpApiResult{Data: data, NextLink: next}, err
+		})
+	return res.Data, res.NextLink, err
+}
+
+// Result of CallHttpApi to be recorded by Recorder
+type httpApiResult struct {
+	Data     *json.RawMessage `json:"data"`
+	NextLink string           `json:"next_link,omitempty"`
+}
+
+// callHttpApi: Implementation of CallHttpApi without Recorder
+func callHttpApi(authProvider auth.IAuthProvider, cmd *def.ConfHttpCmd, nextLink string, param map[string]any) (
+	*json.RawMessage, string, error) {
+	if cmd == nil {
+		return nil, "", errors.New("nil pointor of ConfHttpCmd")
+	}
+
+	client, err := getHttpApiClient(authProvider)
+	if err != nil {
+		return nil, "", err
+	}
+
+	prefix := cmd.ProfilePrefix
+	if len(prefix) == 0 {
+		prefix = HTTP_API_DEFAULT_PROFILE_PREFIX
+	}
+	method := strings.ToUpper(cmd.Method)
+	if len(method) == 0 {
+		method = http.MethodGet
+	}
+	data := &httpTemplateData{Param: param}
+
+	URL, err := client.execTemplate("url", cmd.URL, data)
+	if err != nil {
+		return nil, "", err
+	}
+	if parseAbsoluteURL(URL) == nil {
+		baseURL := client.v.GetString(client.profileKey(prefix, HTTP_API_BASE_URL))
+		if parseAbsoluteURL(baseURL) == nil {
+			return nil, "", fmt.Errorf("failed to read url from key of profile: %s", client.profileKey(prefix, HTTP_API_BASE_URL))
+		}
+		URL = strings.TrimRight(baseURL, "/") + "/" + strings.TrimLeft(URL, "/")
+	}
+
+	if len(nextLink) > 0 {
+		// Auth info is sent to the link got from response, so it must be on the same host as the url defined
+		if !isSameOrigin(parseAbsoluteURL(nextLink), parseAbsoluteURL(URL)) {
+			return nil, "", fmt.Errorf("link of next page is not on the same host as the url of HTTP API: %s", nextLink)
+		}
+		URL = nextLink
+	} else {
+		query := url.Values{}
+		for k, v := range cmd.Query {
+			val, err := client.execTemplate("query "+k, v, data)
+			if err != nil {
+				return nil, "", err
+			}
+			query.Add(k, val)
+		}
+		if method == http.MethodGet {
+			for k, v := range param {
+				if err := addQueryValue(query, k, v, nil); err != nil {
+					return nil, "", err
+				}
+			}
+		}
+		if len(query) > 0 {
+			sep := "?"
+			if strings.Contains(URL, "?") {
+				sep = "&"
+			}
+			URL = URL + sep + query.Encode()
+		}
+	}
+
+	var byBody []byte
+	if method != http.MethodGet {
+		if len(cmd.Body) > 0 {
+			body, err := client.execTemplate("body", cmd.Body, data)
+			if err != nil {
+				return nil, "", err
+			}
+			byBody = []byte(body)
+		} else {
+			if param == nil {
+				param = make(map[string]any)
+			}
+			if byBody, err = json.Marshal(param); err != nil {
+				return nil, "", fmt.Errorf("failed to marshal param: %w", err)
+			}
+		}
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), method, URL, bytes.NewReader(byBody))
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Accept", "application/json")
+	if byBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range cmd.Header {
+		val, err := client.execTemplate("header "+k, v, data)
+		if err != nil {
+			return nil, "", err
+		}
+		req.Header.Set(k, val)
+	}
+	if err := client.setAuth(req, prefix, byBody); err != nil {
+		return nil, "", err
+	}
+
+	_rlHttpApi.Take()
+	resp, err := client.hc.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to invoke api: %w", err)
+	}
+
+	defer resp.Body.Close()
+	byResp, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, "", fmt.Errorf("response indicates failure with status %d: %s", resp.StatusCode, string(byResp))
+	}
+
+	var anyResp any
+	if err := internal.JsonUnmarshal(byResp, &anyResp); err != nil {
+		return nil, "", fmt.Errorf("failed to unmarshal response as json: %w", err)
+	}
+
+	nextLink = parseLinkHeaderNext(resp.Header.Values("Link"))
+	if len(nextLink) > 0 {
+		// Link may be relative to the url of the request
+		nextURL, err := url.Parse(nextLink)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid link of next page: %w", err)
+		}
+		nextLink = req.URL.ResolveReference(nextURL).String()
+	}
+
+	var rm json.RawMessage = byResp
+	return &rm, nextLink, nil
+}
+
+// parseLinkHeaderNext: Get url of rel="next" from Link headers as defined in RFC 8288
+//
+// e.g. <https://api.github.com/orgs/o/repos?page=2>; rel="next", <https://api.github.com/orgs/o/repos?page=5>; rel="last"
+func parseLinkHeaderNext(links []string) string {
+	for _, link := range links {
+		for _, item := range strings.Split(link, ",") {
+			parts := strings.Split(item, ";")
+			target := strings.TrimSpace(parts[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+
+			for _, attr := range parts[1:] {
+				k, v, _ := strings.Cut(strings.TrimSpace(attr), "=")
+				if !strings.EqualFold(k, "rel") {
+					continue
+				}
+				for _, rel := range strings.Fields(strings.Trim(v, `"`)) {
+					if strings.EqualFold(rel, "next") {
+						return target[1 : len(target)-1]
+					}
+				}
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,384 @@
+// Connector for generic HTTP/REST APIs returning json
+
+package connector
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/s3studio/cloud-bench-checker/pkg/auth"
+	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
+	"github.com/s3studio/cloud-bench-checker/test"
+)
+
+func Test_createHttpApiClient(t *testing.T) {
+	type args struct {
+		p auth.IAuthProvider
+	}
+	tests := []struct {
+		name string
+		args args
+		//want    *httpApiClient
+		wantErr bool
+	}{
+		{
+			"Valid result",
+			args{auth.NewAuthFileProvider(test.Test_conf_http_api)},
+			false,
+		},
+		{
+			"Profile not defined",
+			args{auth.NewAuthFileProvider(test.Test_conf_invalid)},
+			true,
+		},
+		{
+			"nil pointor of IAuthProvider",
+			args{nil},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := createHttpApiClient(tt.args.p)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("createHttpApiClient() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if (got == nil) != tt.wantErr {
+				t.Errorf("createHttpApiClient() = %v, want a valid pointer", got)
+			}
+		})
+	}
+}
+
+func Test_getHttpApiClient(t *testing.T) {
+	type args struct {
+		p auth.IAuthProvider
+	}
+	tests := []struct {
+		name string
+		args args
+		//want    *httpApiClient
+		wantErr bool
+	}{
+		{
+			"Valid result",
+			args{auth.NewAuthFileProvider(test.Test_conf_http_api)},
+			false,
+		},
+		{
+			"Profile not defined",
+			args{auth.NewAuthFileProvider(test.Test_conf_invalid)},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getHttpApiClient(tt.args.p)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getHttpApiClient() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if (got == nil) != tt.wantErr {
+				t.Errorf("getHttpApiClient() = %v, want a valid pointer", got)
+			}
+		})
+	}
+}
+
+func TestCallHttpApi(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/bearer/repos":
+			if r.Header.Get("Authorization") != "Bearer mock_token" || r.Header.Get("X-Mock") != "mock_org" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if r.URL.Query().Get("page") != "2" {
+				w.Header().Add("Link", `<`+server.URL+`/bearer/repos?page=2>; rel="next", <`+server.URL+`/bearer/repos?page=2>; rel="last"`)
+			}
+			w.Write([]byte(`[{"name":"` + r.URL.Query().Get("org") + r.URL.Query().Get("per_page") + `"}]`))
+		case "/bearer/relative":
+			if r.URL.Query().Get("page") != "2" {
+				w.Header().Add("Link", `<relative?page=2>; rel="next"`)
+			}
+			w.Write([]byte(`[]`))
+		case "/basic":
+			if user, pwd, _ := r.BasicAuth(); user != "mock_user" || pwd != "mock_password" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{}`))
+		case "/apikey":
+			if r.Header.Get("SSWS") != "mock_key" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{}`))
+		case "/hmac":
+			byBody, _ := io.ReadAll(r.Body)
+			signature := signHttpApiHmac("mock_secret", r.Method, r.URL.RequestURI(), r.Header.Get("X-Timestamp"), byBody)
+			if r.Header.Get("Authorization") != "HMAC-SHA256 KeyId=mock_id, Signature="+signature {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write(byBody) // echo
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"Not Found"}`))
+		}
+	}))
+	defer server.Close()
+	// Foreign host linked from response, which should never receive any request
+	foreignRequested := false
+	foreignServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		foreignRequested = true
+		w.Write([]byte(`[]`))
+	}))
+	defer foreignServer.Close()
+
+	envMap := map[string]string{
+		"HTTP_API_BASE_URL":     server.URL,
+		"HTTP_API_AUTH_TYPE":    "bearer",
+		"HTTP_API_TOKEN":        "mock_token",
+		"BASIC_BASE_URL":        server.URL,
+		"BASIC_AUTH_TYPE":       "basic",
+		"BASIC_USERNAME":        "mock_user",
+		"BASIC_PASSWORD":        "mock_password",
+		"APIKEY_AUTH_TYPE":      "apikey",
+		"APIKEY_API_KEY":        "mock_key",
+		"APIKEY_API_KEY_HEADER": "SSWS",
+		"HMAC_BASE_URL":         server.URL,
+		"HMAC_AUTH_TYPE":        "HMAC",
+		"HMAC_HMAC_KEY_ID":      "mock_id",
+		"HMAC_HMAC_SECRET":      "mock_secret",
+		"INVALID_AUTH_TYPE":     "invalid",
+		"MISSING_AUTH_TYPE":     "bearer",
+		"MISSING_BASE_URL":      server.URL,
+		"MOCK_ORG":              "mock_org",
+	}
+	for k, v := range envMap {
+		t.Setenv(k, v)
+	}
+
+	authProvider := auth.NewAuthFileProvider(test.Test_conf_http_api)
+	var rmRepos json.RawMessage = []byte(`[{"name":"mock_org10"}]`)
+	var rmReposPage2 json.RawMessage = []byte(`[{"name":"mock_org"}]`)
+	var rmEmpty json.RawMessage = []byte(`{}`)
+	var rmEmptyList json.RawMessage = []byte(`[]`)
+	var rmBody json.RawMessage = []byte(`{"query":"mock_org"}`)
+	var rmParam json.RawMessage = []byte(`{"cursor":"c"}`)
+
+	type args struct {
+		authProvider auth.IAuthProvider
+		cmd          *def.ConfHttpCmd
+		nextLink     string
+		param        map[string]any
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *json.RawMessage
+		want1   string
+		wantErr bool
+	}{
+		{
+			"Valid result of bearer with Link header",
+			args{authProvider, &def.ConfHttpCmd{
+				URL:    "/bearer/repos",
+				Header: map[string]string{"X-Mock": `{{profile "MOCK_ORG"}}`},
+				Query:  map[string]string{"org": `{{profile "MOCK_ORG"}}`},
+			}, "", map[string]any{"per_page": 10}},
+			&rmRepos,
+			server.URL + "/bearer/repos?page=2",
+			false,
+		},
+		{
+			"Valid result of next link",
+			args{authProvider, &def.ConfHttpCmd{
+				URL:    "/bearer/repos",
+				Header: map[string]string{"X-Mock": "mock_org"},
+			}, server.URL + "/bearer/repos?page=2&org=mock_org", map[string]any{"per_page": 10}},
+			&rmReposPage2,
+			"",
+			false,
+		},
+		{
+			"Valid result of relative Link header",
+			args{authProvider, &def.ConfHttpCmd{URL: "/bearer/relative"}, "", nil},
+			&rmEmptyList,
+			server.URL + "/bearer/relative?page=2",
+			false,
+		},
+		{
+			"Next link of foreign host",
+			args{authProvider, &def.ConfHttpCmd{
+				URL:    "/bearer/repos",
+				Header: map[string]string{"X-Mock": "mock_org"},
+			}, foreignServer.URL + "/bearer/repos?page=2", nil},
+			nil,
+			"",
+			true,
+		},
+		{
+			"Next link of another scheme",
+			args{authProvider, &def.ConfHttpCmd{
+				URL:    "/bearer/repos",
+				Header: map[string]string{"X-Mock": "mock_org"},
+			}, strings.Replace(server.URL, "http://", "https://", 1) + "/bearer/repos?page=2", nil},
+			nil,
+			"",
+			true,
+		},
+		{
+			"Valid result of basic",
+			args{authProvider, &def.ConfHttpCmd{URL: "basic", ProfilePrefix: "BASIC"}, "", nil},
+			&rmEmpty,
+			"",
+			false,
+		},
+		{
+			"Valid result of api key with full url",
+			args{authProvider, &def.ConfHttpCmd{URL: server.URL + "/apikey", ProfilePrefix: "APIKEY"}, "", nil},
+			&rmEmpty,
+			"",
+			false,
+		},
+		{
+			"Valid result of hmac with template of body",
+			args{authProvider, &def.ConfHttpCmd{
+				Method: "post", URL: "hmac", ProfilePrefix: "HMAC",
+				Body: `{"query":"{{.Param.org}}"}`,
+			}, "", map[string]any{"org": "mock_org"}},
+			&rmBody,
+			"",
+			false,
+		},
+		{
+			"Valid result of param as body",
+			args{authProvider, &def.ConfHttpCmd{Method: "POST", URL: "hmac", ProfilePrefix: "HMAC"}, "", map[string]any{"cursor": "c"}},
+			&rmParam,
+			"",
+			false,
+		},
+		{
+			"Response indicates failure",
+			args{authProvider, &def.ConfHttpCmd{URL: "notfound"}, "", nil},
+			nil,
+			"",
+			true,
+		},
+		{
+			"Unsupported auth type",
+			args{authProvider, &def.ConfHttpCmd{URL: server.URL, ProfilePrefix: "INVALID"}, "", nil},
+			nil,
+			"",
+			true,
+		},
+		{
+			"Missing token of bearer",
+			args{authProvider, &def.ConfHttpCmd{URL: "bearer/repos", ProfilePrefix: "MISSING"}, "", nil},
+			nil,
+			"",
+			true,
+		},
+		{
+			"Missing base url",
+			args{authProvider, &def.ConfHttpCmd{URL: "apikey", ProfilePrefix: "APIKEY"}, "", nil},
+			nil,
+			"",
+			true,
+		},
+		{
+			"Invalid template",
+			args{authProvider, &def.ConfHttpCmd{URL: "{{.Invalid"}, "", nil},
+			nil,
+			"",
+			true,
+		},
+		{
+			"Unsupported type of query param",
+			args{authProvider, &def.ConfHttpCmd{URL: "bearer/repos"}, "", map[string]any{"per_page": 1.5}},
+			nil,
+			"",
+			true,
+		},
+		{
+			"nil pointor of ConfHttpCmd",
+			args{authProvider, nil, "", nil},
+			nil,
+			"",
+			true,
+		},
+		{
+			"Profile not defined",
+			args{auth.NewAuthFileProvider(test.Test_conf_invalid), &def.ConfHttpCmd{URL: "bearer/repos"}, "", nil},
+			nil,
+			"",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1, err := CallHttpApi(tt.args.authProvider, tt.args.cmd, tt.args.nextLink, tt.args.param)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CallHttpApi() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CallHttpApi() got = %v, want %v", string(*got), string(*tt.want))
+			}
+			if got1 != tt.want1 {
+				t.Errorf("CallHttpApi() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+	if foreignRequested {
+		t.Errorf("CallHttpApi() sent request to foreign host of next link")
+	}
+}
+
+func Test_parseLinkHeaderNext(t *testing.T) {
+	type args struct {
+		links []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			"Next in the middle",
+			args{[]string{`<http://mock/?page=1>; rel="prev", <http://mock/?page=3>; rel="next", <http://mock/?page=9>; rel="last"`}},
+			"http://mock/?page=3",
+		},
+		{
+			"Next in separate header and multiple rel",
+			args{[]string{`<http://mock/self>; rel="self"`, `<http://mock/?after=x>; rel="next last"`}},
+			"http://mock/?after=x",
+		},
+		{
+			"No next",
+			args{[]string{`<http://mock/?page=1>; rel="prev"`, `invalid; rel="next"`}},
+			"",
+		},
+		{
+			"No header",
+			args{nil},
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseLinkHeaderNext(tt.args.links); strings.Compare(got, tt.want) != 0 {
+				t.Errorf("parseLinkHeaderNext() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
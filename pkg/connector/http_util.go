@@ -0,0 +1,76 @@
+// Helpers shared by connectors calling http APIs directly, e.g. GCP, OpenStack and HTTP API
+
+package connector
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// addQueryValue: Add a value of extraParam to the query string
+//
+// Shared by connectors sending extraParam as query, e.g. GCP, OpenStack and HTTP API.
+// @param: query: Values of query string
+// @param: key: Key of param
+// @param: value: Value of param, "string", "integer", "boolean" values and lists of them are supported
+// @param: fnConv: Conversion of string values, e.g. replacing placeholder, can be nil
+// @return: Error
+func addQueryValue(query url.Values, key string, value any, fnConv func(string) string) error {
+	switch p := value.(type) {
+	case string:
+		if fnConv != nil {
+			p = fnConv(p)
+		}
+		query.Add(key, p)
+	case int:
+		query.Add(key, strconv.Itoa(p))
+	case bool:
+		query.Add(key, strconv.FormatBool(p))
+	case []string:
+		for _, item := range p {
+			if err := addQueryValue(query, key, item, fnConv); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for _, item := range p {
+			if err := addQueryValue(query, key, item, fnConv); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported type of query param \"%s\"", key)
+	}
+
+	return nil
+}
+
+// replacePlaceholder: Conversion used in addQueryValue to replace the placeholder by value, e.g. "{project}"
+func replacePlaceholder(placeholder string, value string) func(string) string {
+	return func(s string) string {
+		return strings.ReplaceAll(s, placeholder, value)
+	}
+}
+
+// parseAbsoluteURL: Parse the string as an absolute url of http or https
+//
+// Shared by connectors accepting full url in place of path, e.g. GCP, OpenStack and HTTP API,
+// so that a path merely starting with "http", such as "httpHealthChecks", is not taken as url.
+// @param: s: String to parse
+// @return: Url parsed, nil if the string is not an absolute url of http or https
+func parseAbsoluteURL(s string) *url.URL {
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || len(u.Host) == 0 {
+		return nil
+	}
+
+	return u
+}
+
+// isSameOrigin: Check whether the scheme and host of both urls are the same,
+// used before sending credentials to a url got from config or response
+func isSameOrigin(u1 *url.URL, u2 *url.URL) bool {
+	return u1 != nil && u2 != nil && u1.Scheme == u2.Scheme && strings.EqualFold(u1.Host, u2.Host)
+}
@@ -0,0 +1,97 @@
+// Helpers shared by connectors calling http APIs directly, e.g. GCP, OpenStack and HTTP API
+
+package connector
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func Test_addQueryValue(t *testing.T) {
+	type args struct {
+		key    string
+		value  any
+		fnConv func(string) string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    url.Values
+		wantErr bool
+	}{
+		{
+			"Valid result of string",
+			args{"k", "{project}/a", replacePlaceholder(GCP_PROJECT_PLACEHOLDER, "p")},
+			url.Values{"k": {"p/a"}},
+			false,
+		},
+		{
+			"Valid result of list",
+			args{"k", []any{1, true, "s"}, nil},
+			url.Values{"k": {"1", "true", "s"}},
+			false,
+		},
+		{
+			"Unsupported type",
+			args{"k", 1.5, nil},
+			url.Values{},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := url.Values{}
+			if err := addQueryValue(query, tt.args.key, tt.args.value, tt.args.fnConv); (err != nil) != tt.wantErr {
+				t.Errorf("addQueryValue() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(query, tt.want) {
+				t.Errorf("addQueryValue() = %v, want %v", query, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseAbsoluteURL(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want bool
+	}{
+		{"Url of https", "https://mock.domain/path", true},
+		{"Url of http", "http://mock.domain/path", true},
+		{"Path starting with http", "httpHealthChecks", false},
+		{"Url without host", "https:///path", false},
+		{"Url of other scheme", "ftp://mock.domain/path", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseAbsoluteURL(tt.s); (got != nil) != tt.want {
+				t.Errorf("parseAbsoluteURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_isSameOrigin(t *testing.T) {
+	tests := []struct {
+		name string
+		u1   string
+		u2   string
+		want bool
+	}{
+		{"Same origin", "https://Mock.Domain/a", "https://mock.domain/b?c=d", true},
+		{"Different scheme", "http://mock.domain/a", "https://mock.domain/a", false},
+		{"Different host", "https://mock.domain/a", "https://other.domain/a", false},
+		{"Different port", "https://mock.domain/a", "https://mock.domain:8443/a", false},
+		{"Not url", "mock", "https://mock.domain/a", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isSameOrigin(parseAbsoluteURL(tt.u1), parseAbsoluteURL(tt.u2)); got != tt.want {
+				t.Errorf("isSameOrigin() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,35 +3,67 @@
 package connector
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/s3studio/cloud-bench-checker/internal"
 	"github.com/s3studio/cloud-bench-checker/pkg/auth"
 	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
 
 	"go.uber.org/ratelimit"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
-	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
+// K8S_CONTEXT_SEPARATOR: Separator between filename of kubeconfig and name of context in id of k8s context
+const K8S_CONTEXT_SEPARATOR = ":"
+
 // Bind dynamic.DynamicClient with RESTMapper
 type k8sClient struct {
 	c *dynamic.DynamicClient
 	m meta.RESTMapper
 	// Version of server
 	v string
+	// Client to request raw path of server
+	r rest.Interface
 }
 
-func createK8sClient(p auth.IAuthProvider) (*k8sClient, error) {
+// getK8sRestConfig: Build config of k8s client
+// @param: kubeconfigPathname: Pathname of kubeconfig, or directory of kubeconfigs if kubeContext is not empty
+// @param: kubeContext: Id of k8s context returned by GetK8sContexts, or empty to use the current context of kubeconfig
+// @return: Config of k8s client
+// @return: Error
+func getK8sRestConfig(kubeconfigPathname string, kubeContext string) (*rest.Config, error) {
+	if len(kubeContext) == 0 {
+		return clientcmd.BuildConfigFromFlags("", kubeconfigPathname)
+	}
+
+	filename, contextName, ok := strings.Cut(kubeContext, K8S_CONTEXT_SEPARATOR)
+	if !ok || filename != filepath.Base(filename) || filename == ".." {
+		return nil, fmt.Errorf("invalid k8s context: %s", kubeContext)
+	}
+
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: filepath.Join(kubeconfigPathname, filename)},
+		&clientcmd.ConfigOverrides{CurrentContext: contextName},
+	).ClientConfig()
+}
+
+func createK8sClient(p auth.IAuthProvider, kubeContext string) (*k8sClient, error) {
 	if p == nil {
 		return nil, errors.New("nil pointor of IAuthProvider")
 	}
@@ -41,7 +73,7 @@ func createK8sClient(p auth.IAuthProvider) (*k8sClient, error) {
 		return nil, err
 	}
 
-	config, err := clientcmd.BuildConfigFromFlags("", kubeconfigPathname)
+	config, err := getK8sRestConfig(kubeconfigPathname, kubeContext)
 	if err != nil {
 		// Do not use value of err to avoid leaking the file path
 		return nil, errors.New("unable to read kube config file")
@@ -58,14 +90,9 @@ func createK8sClient(p auth.IAuthProvider) (*k8sClient, error) {
 		return nil, err
 	}
 
-	// GetAPIGroupResources() is called only once and the result is cached
-	// TODO: Manage how to refresh restmapper
-	groupResources, err := restmapper.GetAPIGroupResources(discoveryClient)
-	if err != nil {
-		return nil, err
-	}
-
-	client.m = restmapper.NewDiscoveryRESTMapper(groupResources)
+	// Resources are discovered on first use and cached, and discovered again
+	// on no match error or on expiry of _k8sDiscoveryInterval
+	client.m = newK8sRefreshingRESTMapper(discoveryClient, _k8sDiscoveryInterval)
 
 	// ServerVersion() is called only once and the result is cached
 	serverVersion, err := discoveryClient.ServerVersion()
@@ -74,6 +101,7 @@ func createK8sClient(p auth.IAuthProvider) (*k8sClient, error) {
 	}
 
 	client.v = fmt.Sprintf("%s.%s", serverVersion.Major, serverVersion.Minor)
+	client.r = discoveryClient.RESTClient()
 
 	return &client, nil
 }
@@ -84,13 +112,71 @@ var (
 	_rlK8sCloud = ratelimit.New(10, ratelimit.WithoutSlack)
 )
 
-func getK8sClient(p auth.IAuthProvider) (*k8sClient, error) {
+func getK8sClient(p auth.IAuthProvider, kubeContext string) (*k8sClient, error) {
 	key := fmt.Sprintf("%p_default", p)
+	if len(kubeContext) > 0 {
+		key = fmt.Sprintf("%p_%s", p, kubeContext)
+	}
 	return _mapK8sClient.LoadOrCreate(key, func() (any, error) {
-		return createK8sClient(p)
+		return createK8sClient(p, kubeContext)
 	}, nil)
 }
 
+// GetK8sContexts: Get ids of all k8s contexts if the profile of k8s is a directory of kubeconfigs
+//
+// Each regular file in the directory is loaded as a kubeconfig, and all contexts of it are returned
+// with id of "{filename}:{context}", sorted by filename and name of context.
+// Hidden files are ignored.
+// @param: authProvider: IAuthProvider to provide pathname of kubeconfig
+// @return: List of ids of k8s context, or nil if the profile is a single kubeconfig
+// @return: Error
+func GetK8sContexts(authProvider auth.IAuthProvider) ([]string, error) {
+	if authProvider == nil {
+		return nil, errors.New("nil pointor of IAuthProvider")
+	}
+
+	kubeconfigPathname, err := authProvider.GetProfilePathname(def.K8S)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(kubeconfigPathname)
+	if err != nil || !info.IsDir() {
+		// Error of reading a single kubeconfig is left to createK8sClient
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(kubeconfigPathname)
+	if err != nil {
+		return nil, errors.New("unable to read directory of kube config files")
+	}
+
+	var contexts []string
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		config, err := clientcmd.LoadFromFile(filepath.Join(kubeconfigPathname, entry.Name()))
+		if err != nil {
+			// Do not use value of err to avoid leaking the file path
+			return nil, fmt.Errorf("unable to read kube config file %s", entry.Name())
+		}
+
+		contextNames := make([]string, 0, len(config.Contexts))
+		for name := range config.Contexts {
+			contextNames = append(contextNames, name)
+		}
+		slices.Sort(contextNames)
+
+		for _, name := range contextNames {
+			contexts = append(contexts, entry.Name()+K8S_CONTEXT_SEPARATOR+name)
+		}
+	}
+
+	return contexts, nil
+}
+
 // CallK8sList: Send a request to a k8s server to list resources.
 // If group and version are both empty, RESTMapper is used to search for mapped gvr
 //
@@ -106,7 +192,39 @@ func getK8sClient(p auth.IAuthProvider) (*k8sClient, error) {
 // @return: Error
 func CallK8sList(authProvider auth.IAuthProvider, namespace string, group string, version string, resource string, listOpts map[string]any) (
 	*json.RawMessage, error) {
-	client, err := getK8sClient(authProvider)
+	return recordCall(authProvider, def.K8S, nil, "CallK8sList", []any{namespace, group, version, resource, listOpts},
+		func() (*json.RawMessage, error) {
+			return callK8sList(authProvider, "", namespace, group, version, resource, listOpts)
+		})
+}
+
+// CallK8sListWithContext: Send a request to the k8s server of the given context to list resources
+// @param: authProvider: IAuthProvider to provide pathname of kubeconfig
+// @param: kubeContext: Id of k8s context returned by GetK8sContexts, the current context of kubeconfig is used if empty
+// @param: namespace: Parameter for k8s request
+// @param: group: Parameter for k8s request
+// @param: version: Parameter for k8s request
+// @param: resource: Parameter for k8s request
+// @param: extraParam: Parameters of ListOptions
+// @return: Response data from k8s server
+// @return: Error
+func CallK8sListWithContext(authProvider auth.IAuthProvider, kubeContext string, namespace string, group string, version string,
+	resource string, listOpts map[string]any) (*json.RawMessage, error) {
+	if len(kubeContext) == 0 {
+		return CallK8sList(authProvider, namespace, group, version, resource, listOpts)
+	}
+
+	return recordCall(authProvider, def.K8S, nil, "CallK8sListWithContext",
+		[]any{kubeContext, namespace, group, version, resource, listOpts},
+		func() (*json.RawMessage, error) {
+			return callK8sList(authProvider, kubeContext, namespace, group, version, resource, listOpts)
+		})
+}
+
+// callK8sList: Implementation of CallK8sList without Recorder
+func callK8sList(authProvider auth.IAuthProvider, kubeContext string, namespace string, group string, version string, resource string,
+	listOpts map[string]any) (*json.RawMessage, error) {
+	client, err := getK8sClient(authProvider, kubeContext)
 	if err != nil {
 		return nil, err
 	}
@@ -121,31 +239,157 @@ func CallK8sList(authProvider auth.IAuthProvider, namespace string, group string
 		return nil, fmt.Errorf("failed to unmarshal listOpts to listOption: %w", err)
 	}
 
+	rs, err := getK8sResource(client, group, version, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	var listRes *unstructured.UnstructuredList
+	_rlK8sCloud.Take()
+	if len(namespace) > 0 {
+		listRes, err = rs.Namespace(namespace).List(context.TODO(), listOption)
+	} else {
+		listRes, err = rs.List(context.TODO(), listOption)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list k8s resource: %w", err)
+	}
+
+	return internal.JsonMarshal(listRes.UnstructuredContent())
+}
+
+// getK8sResource: Get interface of the resource, which is found by RESTMapper if both group and version are empty
+func getK8sResource(client *k8sClient, group string, version string, resource string) (dynamic.NamespaceableResourceInterface, error) {
 	gvr := schema.GroupVersionResource{Group: group, Version: version, Resource: resource}
-	var rs dynamic.NamespaceableResourceInterface
 	if len(group) == 0 && len(version) == 0 {
 		found, err := client.m.ResourceFor(gvr)
 		if err != nil {
 			return nil, fmt.Errorf("failed to find resource \"%s\": %w", resource, err)
 		}
 
-		rs = client.c.Resource(found)
-	} else {
-		rs = client.c.Resource(schema.GroupVersionResource{Group: group, Version: version, Resource: resource})
+		return client.c.Resource(found), nil
 	}
 
-	var listRes *unstructured.UnstructuredList
+	return client.c.Resource(gvr), nil
+}
+
+// _unsupportedK8sSubresources: Subresources that are streaming or not responding with json object
+var _unsupportedK8sSubresources = []string{"log", "exec", "attach", "portforward", "proxy"}
+
+// CallK8sGet: Send a request to the k8s server to get a single object
+// @param: authProvider: IAuthProvider to provide pathname of kubeconfig
+// @param: kubeContext: Id of k8s context returned by GetK8sContexts, the current context of kubeconfig is used if empty
+// @param: namespace: Parameter for k8s request, empty for cluster-scoped resource
+// @param: group: Parameter for k8s request
+// @param: version: Parameter for k8s request
+// @param: resource: Parameter for k8s request
+// @param: name: Name of the object
+// @param: subresource: Subresource of the object such as "status", or empty for the object itself
+// @param: ignoreNotFound: Return json null instead of error if the object is not found
+// @return: Response data from k8s server
+// @return: Error
+func CallK8sGet(authProvider auth.IAuthProvider, kubeContext string, namespace string, group string, version string, resource string,
+	name string, subresource string, ignoreNotFound bool) (*json.RawMessage, error) {
+	return recordCall(authProvider, def.K8S, nil, "CallK8sGet",
+		[]any{kubeContext, namespace, group, version, resource, name, subresource, ignoreNotFound},
+		func() (*json.RawMessage, error) {
+			return callK8sGet(authProvider, kubeContext, namespace, group, version, resource, name, subresource, ignoreNotFound)
+		})
+}
+
+// callK8sGet: Implementation of CallK8sGet without Recorder
+func callK8sGet(authProvider auth.IAuthProvider, kubeContext string, namespace string, group string, version string, resource string,
+	name string, subresource string, ignoreNotFound bool) (*json.RawMessage, error) {
+	if len(name) == 0 {
+		return nil, errors.New("name of k8s object is empty")
+	}
+
+	var subresources []string
+	if len(subresource) > 0 {
+		subresources = strings.Split(subresource, "/")
+	}
+	for _, each := range subresources {
+		if slices.Contains(_unsupportedK8sSubresources, each) {
+			return nil, fmt.Errorf("unsupported subresource of k8s: %s", subresource)
+		}
+	}
+
+	client, err := getK8sClient(authProvider, kubeContext)
+	if err != nil {
+		return nil, err
+	}
+
+	rs, err := getK8sResource(client, group, version, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	var getRes *unstructured.Unstructured
 	_rlK8sCloud.Take()
 	if len(namespace) > 0 {
-		listRes, err = rs.Namespace(namespace).List(context.TODO(), listOption)
+		getRes, err = rs.Namespace(namespace).Get(context.TODO(), name, metav1.GetOptions{}, subresources...)
 	} else {
-		listRes, err = rs.List(context.TODO(), listOption)
+		getRes, err = rs.Get(context.TODO(), name, metav1.GetOptions{}, subresources...)
 	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to list k8s resource: %w", err)
+		if ignoreNotFound && k8serrors.IsNotFound(err) {
+			return internal.JsonMarshal(nil)
+		}
+		return nil, fmt.Errorf("failed to get k8s resource: %w", err)
 	}
 
-	return internal.JsonMarshal(listRes.UnstructuredContent())
+	return internal.JsonMarshal(getRes.UnstructuredContent())
+}
+
+// CallK8sRaw: Send a GET request to a raw path of the k8s server
+//
+// Useful for endpoints that are not API objects, such as "/api/v1/nodes/{node}/proxy/configz" for config of kubelet.
+// @param: authProvider: IAuthProvider to provide pathname of kubeconfig
+// @param: kubeContext: Id of k8s context returned by GetK8sContexts, the current context of kubeconfig is used if empty
+// @param: path: Absolute path of the request
+// @param: extraParam: Query parameters whose values must be scalar
+// @return: Response data from k8s server, which must be json
+// @return: Error
+func CallK8sRaw(authProvider auth.IAuthProvider, kubeContext string, path string, extraParam map[string]any) (*json.RawMessage, error) {
+	return recordCall(authProvider, def.K8S, nil, "CallK8sRaw", []any{kubeContext, path, extraParam},
+		func() (*json.RawMessage, error) {
+			return callK8sRaw(authProvider, kubeContext, path, extraParam)
+		})
+}
+
+// callK8sRaw: Implementation of CallK8sRaw without Recorder
+func callK8sRaw(authProvider auth.IAuthProvider, kubeContext string, path string, extraParam map[string]any) (*json.RawMessage, error) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("path of k8s must be absolute: %s", path)
+	}
+
+	client, err := getK8sClient(authProvider, kubeContext)
+	if err != nil {
+		return nil, err
+	}
+
+	req := client.r.Get().AbsPath(path)
+	for k, v := range extraParam {
+		switch v.(type) {
+		case string, bool, int, int64, float64, json.Number:
+			req = req.Param(k, fmt.Sprint(v))
+		default:
+			return nil, fmt.Errorf("type of query parameter %s for k8s is not supported: %T", k, v)
+		}
+	}
+
+	_rlK8sCloud.Take()
+	byRes, err := req.DoRaw(context.TODO())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get k8s path: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, byRes); err != nil {
+		return nil, fmt.Errorf("response of k8s path is not json: %s", path)
+	}
+
+	res := json.RawMessage(buf.Bytes())
+	return &res, nil
 }
 
 // GetK8sVersion: Get version of a k8s server
@@ -154,12 +398,38 @@ func CallK8sList(authProvider auth.IAuthProvider, namespace string, group string
 // @return: Version of k8s server
 // @return: Error
 func GetK8sVersion(authProvider auth.IAuthProvider) (string, error) {
-	client, err := getK8sClient(authProvider)
-	if err != nil {
-		return "", err
+	_ = internal.DisableInlining()
+
+	return recordCall(authProvider, def.K8S, nil, "GetK8sVersion", []any{},
+		func() (string, error) {
+			return getK8sVersion(authProvider, "")
+		})
+}
+
+// GetK8sVersionWithContext: Get version of the k8s server of the given context
+//
+// @param: authProvider: IAuthProvider to provide pathname of kubeconfig
+// @param: kubeContext: Id of k8s context returned by GetK8sContexts, the current context of kubeconfig is used if empty
+// @return: Version of k8s server
+// @return: Error
+func GetK8sVersionWithContext(authProvider auth.IAuthProvider, kubeContext string) (string, error) {
+	if len(kubeContext) == 0 {
+		return GetK8sVersion(authProvider)
 	}
 
 	_ = internal.DisableInlining()
 
+	return recordCall(authProvider, def.K8S, nil, "GetK8sVersionWithContext", []any{kubeContext},
+		func() (string, error) {
+			return getK8sVersion(authProvider, kubeContext)
+		})
+}
+
+func getK8sVersion(authProvider auth.IAuthProvider, kubeContext string) (string, error) {
+	client, err := getK8sClient(authProvider, kubeContext)
+	if err != nil {
+		return "", err
+	}
+
 	return client.v, nil
 }
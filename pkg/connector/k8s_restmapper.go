@@ -0,0 +1,124 @@
+// RESTMapper of k8s refreshing discovery information of the server
+
+package connector
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/restmapper"
+)
+
+// K8S_MIN_DISCOVERY_INTERVAL: Min interval between discoveries triggered by no match error,
+// to avoid discovering again and again for resources not installed on the server
+const K8S_MIN_DISCOVERY_INTERVAL = 10 * time.Second
+
+var _k8sDiscoveryInterval time.Duration
+
+// SetK8sDiscoveryInterval: Set interval to discover resources of k8s server again
+//
+// Only affects k8s clients created afterwards.
+// @param: interval: Interval of discovery, 0 to discover again only on no match error
+func SetK8sDiscoveryInterval(interval time.Duration) {
+	_k8sDiscoveryInterval = interval
+}
+
+// k8sRefreshingRESTMapper: RESTMapper discovering resources of server again on no match error
+// and on expiry of the interval, so that CRDs installed later are found
+type k8sRefreshingRESTMapper struct {
+	*restmapper.DeferredDiscoveryRESTMapper
+	// Interval of discovery, 0 to discover again only on no match error
+	interval time.Duration
+
+	mu            sync.Mutex
+	lastDiscovery time.Time
+}
+
+// newK8sRefreshingRESTMapper: Constructor of k8sRefreshingRESTMapper
+// @param: discoveryClient: Client to discover resources of server, the result is cached in memory
+// @param: interval: Interval of discovery, 0 to discover again only on no match error
+func newK8sRefreshingRESTMapper(discoveryClient discovery.DiscoveryInterface, interval time.Duration) *k8sRefreshingRESTMapper {
+	return &k8sRefreshingRESTMapper{
+		DeferredDiscoveryRESTMapper: restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient)),
+		interval:                    interval,
+		lastDiscovery:               time.Now(),
+	}
+}
+
+// reset: Invalidate discovery information if it is older than minInterval
+// @param: minInterval: Min age of discovery information to be invalidated
+// @return: Whether discovery information is invalidated
+func (m *k8sRefreshingRESTMapper) reset(minInterval time.Duration) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if time.Since(m.lastDiscovery) < minInterval {
+		return false
+	}
+
+	m.DeferredDiscoveryRESTMapper.Reset()
+	m.lastDiscovery = time.Now()
+
+	return true
+}
+
+// withRediscovery: Call fn after discovery information is refreshed if expired,
+// and call it again with fresh discovery information on no match error
+func withRediscovery[T any](m *k8sRefreshingRESTMapper, fn func() (T, error)) (T, error) {
+	if m.interval > 0 {
+		m.reset(m.interval)
+	}
+
+	res, err := fn()
+	if err != nil && meta.IsNoMatchError(err) && m.reset(K8S_MIN_DISCOVERY_INTERVAL) {
+		res, err = fn()
+	}
+
+	return res, err
+}
+
+// KindFor: Implementation of meta.RESTMapper.KindFor
+func (m *k8sRefreshingRESTMapper) KindFor(resource schema.GroupVersionResource) (schema.GroupVersionKind, error) {
+	return withRediscovery(m, func() (schema.GroupVersionKind, error) {
+		return m.DeferredDiscoveryRESTMapper.KindFor(resource)
+	})
+}
+
+// KindsFor: Implementation of meta.RESTMapper.KindsFor
+func (m *k8sRefreshingRESTMapper) KindsFor(resource schema.GroupVersionResource) ([]schema.GroupVersionKind, error) {
+	return withRediscovery(m, func() ([]schema.GroupVersionKind, error) {
+		return m.DeferredDiscoveryRESTMapper.KindsFor(resource)
+	})
+}
+
+// ResourceFor: Implementation of meta.RESTMapper.ResourceFor
+func (m *k8sRefreshingRESTMapper) ResourceFor(input schema.GroupVersionResource) (schema.GroupVersionResource, error) {
+	return withRediscovery(m, func() (schema.GroupVersionResource, error) {
+		return m.DeferredDiscoveryRESTMapper.ResourceFor(input)
+	})
+}
+
+// ResourcesFor: Implementation of meta.RESTMapper.ResourcesFor
+func (m *k8sRefreshingRESTMapper) ResourcesFor(input schema.GroupVersionResource) ([]schema.GroupVersionResource, error) {
+	return withRediscovery(m, func() ([]schema.GroupVersionResource, error) {
+		return m.DeferredDiscoveryRESTMapper.ResourcesFor(input)
+	})
+}
+
+// RESTMapping: Implementation of meta.RESTMapper.RESTMapping
+func (m *k8sRefreshingRESTMapper) RESTMapping(gk schema.GroupKind, versions ...string) (*meta.RESTMapping, error) {
+	return withRediscovery(m, func() (*meta.RESTMapping, error) {
+		return m.DeferredDiscoveryRESTMapper.RESTMapping(gk, versions...)
+	})
+}
+
+// RESTMappings: Implementation of meta.RESTMapper.RESTMappings
+func (m *k8sRefreshingRESTMapper) RESTMappings(gk schema.GroupKind, versions ...string) ([]*meta.RESTMapping, error) {
+	return withRediscovery(m, func() ([]*meta.RESTMapping, error) {
+		return m.DeferredDiscoveryRESTMapper.RESTMappings(gk, versions...)
+	})
+}
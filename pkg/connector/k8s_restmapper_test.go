@@ -0,0 +1,102 @@
+// RESTMapper of k8s refreshing discovery information of the server
+
+package connector
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func Test_k8sRefreshingRESTMapper_ResourceFor(t *testing.T) {
+	gvrPod := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	gvrCrd := schema.GroupVersionResource{Group: "constraints.gatekeeper.sh", Version: "v1beta1", Resource: "k8srequiredlabels"}
+
+	type fields struct {
+		interval time.Duration
+		// Age of discovery information when the CRD is installed
+		age time.Duration
+	}
+	type args struct {
+		input schema.GroupVersionResource
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    schema.GroupVersionResource
+		wantErr bool
+	}{
+		{
+			"Valid result of resource discovered at first",
+			fields{0, 0},
+			args{schema.GroupVersionResource{Resource: "pods"}},
+			gvrPod,
+			false,
+		},
+		{
+			"Valid result of CRD discovered again on no match error",
+			fields{0, K8S_MIN_DISCOVERY_INTERVAL},
+			args{schema.GroupVersionResource{Resource: "k8srequiredlabels"}},
+			gvrCrd,
+			false,
+		},
+		{
+			"Valid result of CRD discovered again on expiry of interval",
+			fields{time.Minute, time.Minute},
+			args{schema.GroupVersionResource{Resource: "k8srequiredlabels"}},
+			gvrCrd,
+			false,
+		},
+		{
+			"CRD not discovered again within min interval",
+			fields{0, 0},
+			args{schema.GroupVersionResource{Resource: "k8srequiredlabels"}},
+			schema.GroupVersionResource{},
+			true,
+		},
+		{
+			"resource not installed",
+			fields{0, K8S_MIN_DISCOVERY_INTERVAL},
+			args{schema.GroupVersionResource{Resource: "undefined"}},
+			schema.GroupVersionResource{},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakediscovery.FakeDiscovery{Fake: &k8stesting.Fake{}}
+			fake.Resources = []*metav1.APIResourceList{
+				{
+					GroupVersion: "v1",
+					APIResources: []metav1.APIResource{{Name: "pods", Kind: "Pod", Namespaced: true}},
+				},
+			}
+			m := newK8sRefreshingRESTMapper(fake, tt.fields.interval)
+			if _, err := m.ResourceFor(gvrPod); err != nil {
+				t.Fatalf("k8sRefreshingRESTMapper.ResourceFor() of first discovery error = %v", err)
+			}
+
+			// Install CRD after the first discovery
+			fake.Resources = append(fake.Resources, &metav1.APIResourceList{
+				GroupVersion: gvrCrd.GroupVersion().String(),
+				APIResources: []metav1.APIResource{{Name: "k8srequiredlabels", Kind: "K8sRequiredLabels"}},
+			})
+			m.lastDiscovery = time.Now().Add(-tt.fields.age)
+
+			got, err := m.ResourceFor(tt.args.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("k8sRefreshingRESTMapper.ResourceFor() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("k8sRefreshingRESTMapper.ResourceFor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -6,25 +6,37 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
 	"github.com/s3studio/cloud-bench-checker/internal"
 	"github.com/s3studio/cloud-bench-checker/pkg/auth"
+	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
 
 	"github.com/agiledragon/gomonkey/v2"
+	"github.com/spf13/viper"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
 func TestCallK8sList(t *testing.T) {
 	listRes := unstructured.UnstructuredList{}
 	var patchList *gomonkey.Patches
 	patchGetK8sClient := gomonkey.ApplyFunc(getK8sClient,
-		func(p auth.IAuthProvider) (*k8sClient, error) {
+		func(p auth.IAuthProvider, kubeContext string) (*k8sClient, error) {
 			c := &dynamic.DynamicClient{}
 			orig := c.Resource(schema.GroupVersionResource{})
 			patchList = gomonkey.ApplyMethodFunc(orig, "List",
@@ -32,7 +44,7 @@ func TestCallK8sList(t *testing.T) {
 					return &listRes, nil
 				})
 			m := meta.PriorityRESTMapper{}
-			return &k8sClient{c, &m, ""}, nil
+			return &k8sClient{c, &m, "", nil}, nil
 		})
 	defer patchGetK8sClient.Reset()
 	patchResourceFor := gomonkey.ApplyMethodFunc(meta.PriorityRESTMapper{}, "ResourceFor",
@@ -110,3 +122,463 @@ func TestCallK8sList(t *testing.T) {
 		})
 	}
 }
+
+func TestCallK8sGet(t *testing.T) {
+	getRes := unstructured.Unstructured{Object: map[string]any{"kind": "Role"}}
+	var patchGet *gomonkey.Patches
+	patchGetK8sClient := gomonkey.ApplyFunc(getK8sClient,
+		func(p auth.IAuthProvider, kubeContext string) (*k8sClient, error) {
+			c := &dynamic.DynamicClient{}
+			orig := c.Resource(schema.GroupVersionResource{})
+			patchGet = gomonkey.ApplyMethodFunc(orig, "Get",
+				func(ctx context.Context, name string, opts metav1.GetOptions, subresources ...string) (*unstructured.Unstructured, error) {
+					if name == "not_found" {
+						return nil, k8serrors.NewNotFound(schema.GroupResource{Resource: "roles"}, name)
+					}
+					return &getRes, nil
+				})
+			m := meta.PriorityRESTMapper{}
+			return &k8sClient{c, &m, "", nil}, nil
+		})
+	defer patchGetK8sClient.Reset()
+	patchResourceFor := gomonkey.ApplyMethodFunc(meta.PriorityRESTMapper{}, "ResourceFor",
+		func(input schema.GroupVersionResource) (schema.GroupVersionResource, error) {
+			if input.Resource == "invalid" {
+				return schema.GroupVersionResource{}, errors.New("mock invalid resource err")
+			}
+			return schema.GroupVersionResource{}, nil
+		})
+	defer patchResourceFor.Reset()
+	defer func() {
+		if patchGet != nil {
+			patchGet.Reset()
+		}
+	}()
+	rmGet, _ := internal.JsonMarshal(getRes.UnstructuredContent())
+	rmNull, _ := internal.JsonMarshal(nil)
+
+	type args struct {
+		namespace      string
+		group          string
+		version        string
+		resource       string
+		name           string
+		subresource    string
+		ignoreNotFound bool
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *json.RawMessage
+		wantErr bool
+	}{
+		{
+			"Valid result of cluster-scoped resource",
+			args{"", "", "", "clusterroles", "mock", "", false},
+			rmGet,
+			false,
+		},
+		{
+			"Valid result of namespaced resource with version",
+			args{"mock_ns", "rbac.authorization.k8s.io", "v1", "roles", "mock", "", false},
+			rmGet,
+			false,
+		},
+		{
+			"Valid result of subresource",
+			args{"mock_ns", "apps", "v1", "deployments", "mock", "status", false},
+			rmGet,
+			false,
+		},
+		{
+			"Valid result of object not found ignored",
+			args{"mock_ns", "", "", "roles", "not_found", "", true},
+			rmNull,
+			false,
+		},
+		{
+			"object not found",
+			args{"mock_ns", "", "", "roles", "not_found", "", false},
+			nil,
+			true,
+		},
+		{
+			"unsupported subresource",
+			args{"mock_ns", "", "", "pods", "mock", "log", false},
+			nil,
+			true,
+		},
+		{
+			"empty name",
+			args{"mock_ns", "", "", "roles", "", "", false},
+			nil,
+			true,
+		},
+		{
+			"failed to find resource",
+			args{"", "", "", "invalid", "mock", "", false},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CallK8sGet(nil, "", tt.args.namespace, tt.args.group, tt.args.version, tt.args.resource,
+				tt.args.name, tt.args.subresource, tt.args.ignoreNotFound)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CallK8sGet() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CallK8sGet() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCallK8sRaw(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/nodes/node-1/proxy/configz":
+			fmt.Fprintf(w, "{\"kubeletconfig\": {\"authentication\": {\"anonymous\": {\"enabled\": %s}}}}\n",
+				r.URL.Query().Get("mock"))
+		case "/metrics":
+			fmt.Fprintln(w, "# HELP mock")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	baseURL, _ := url.Parse(server.URL)
+	restClient, err := rest.NewRESTClient(baseURL, "", rest.ClientContentConfig{}, nil, server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	patchGetK8sClient := gomonkey.ApplyFunc(getK8sClient,
+		func(p auth.IAuthProvider, kubeContext string) (*k8sClient, error) {
+			return &k8sClient{nil, nil, "", restClient}, nil
+		})
+	defer patchGetK8sClient.Reset()
+	rmConfigz, _ := internal.JsonMarshal(map[string]any{
+		"kubeletconfig": map[string]any{"authentication": map[string]any{"anonymous": map[string]any{"enabled": false}}},
+	})
+
+	type args struct {
+		path       string
+		extraParam map[string]any
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *json.RawMessage
+		wantErr bool
+	}{
+		{
+			"Valid result",
+			args{"/api/v1/nodes/node-1/proxy/configz", map[string]any{"mock": false}},
+			rmConfigz,
+			false,
+		},
+		{
+			"response is not json",
+			args{"/metrics", nil},
+			nil,
+			true,
+		},
+		{
+			"path not found",
+			args{"/undefined", nil},
+			nil,
+			true,
+		},
+		{
+			"path is not absolute",
+			args{"api/v1/nodes", nil},
+			nil,
+			true,
+		},
+		{
+			"type of query parameter not supported",
+			args{"/api/v1/nodes/node-1/proxy/configz", map[string]any{"mock": []string{}}},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CallK8sRaw(nil, "", tt.args.path, tt.args.extraParam)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CallK8sRaw() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CallK8sRaw() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// mockK8sAuthProvider: IAuthProvider with the given pathname of kubeconfig
+type mockK8sAuthProvider struct {
+	pathname string
+}
+
+func (p *mockK8sAuthProvider) GetProfile(cloudType def.CloudType) (*viper.Viper, error) {
+	return nil, auth.ProfileNotDefinedError{}
+}
+
+func (p *mockK8sAuthProvider) GetProfilePathname(cloudType def.CloudType) (string, error) {
+	if len(p.pathname) == 0 {
+		return "", auth.ProfileNotDefinedError{}
+	}
+	return p.pathname, nil
+}
+
+// writeMockKubeconfig: Write a kubeconfig with the given contexts
+func writeMockKubeconfig(t *testing.T, pathname string, contexts ...string) {
+	config := clientcmdapi.NewConfig()
+	config.Clusters["mock"] = &clientcmdapi.Cluster{Server: "https://127.0.0.1:6443"}
+	config.AuthInfos["mock"] = &clientcmdapi.AuthInfo{Token: "mock"}
+	for _, name := range contexts {
+		config.Contexts[name] = &clientcmdapi.Context{Cluster: "mock", AuthInfo: "mock"}
+	}
+	if len(contexts) > 0 {
+		config.CurrentContext = contexts[0]
+	}
+	if err := clientcmd.WriteToFile(*config, pathname); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetK8sContexts(t *testing.T) {
+	dirFleet := t.TempDir()
+	writeMockKubeconfig(t, filepath.Join(dirFleet, "b.yaml"), "staging")
+	writeMockKubeconfig(t, filepath.Join(dirFleet, "a.yaml"), "prod", "dev")
+	writeMockKubeconfig(t, filepath.Join(dirFleet, ".hidden"), "hidden")
+	if err := os.Mkdir(filepath.Join(dirFleet, "subdir"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	dirInvalid := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dirInvalid, "invalid.yaml"), []byte("{invalid"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	type args struct {
+		authProvider auth.IAuthProvider
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []string
+		wantErr bool
+	}{
+		{
+			"Valid result of directory",
+			args{&mockK8sAuthProvider{dirFleet}},
+			[]string{"a.yaml:dev", "a.yaml:prod", "b.yaml:staging"},
+			false,
+		},
+		{
+			"Valid result of single kubeconfig",
+			args{&mockK8sAuthProvider{filepath.Join(dirFleet, "a.yaml")}},
+			nil,
+			false,
+		},
+		{
+			"Valid result of empty directory",
+			args{&mockK8sAuthProvider{t.TempDir()}},
+			nil,
+			false,
+		},
+		{
+			"failed to read kubeconfig in directory",
+			args{&mockK8sAuthProvider{dirInvalid}},
+			nil,
+			true,
+		},
+		{
+			"profile not defined",
+			args{&mockK8sAuthProvider{}},
+			nil,
+			true,
+		},
+		{
+			"nil pointor of IAuthProvider",
+			args{nil},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetK8sContexts(tt.args.authProvider)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetK8sContexts() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetK8sContexts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getK8sRestConfig(t *testing.T) {
+	dirFleet := t.TempDir()
+	writeMockKubeconfig(t, filepath.Join(dirFleet, "a.yaml"), "prod", "dev")
+
+	type args struct {
+		kubeconfigPathname string
+		kubeContext        string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			"Valid result of current context",
+			args{filepath.Join(dirFleet, "a.yaml"), ""},
+			"mock",
+			false,
+		},
+		{
+			"Valid result of given context",
+			args{dirFleet, "a.yaml:dev"},
+			"mock",
+			false,
+		},
+		{
+			"context not found",
+			args{dirFleet, "a.yaml:undefined"},
+			"",
+			true,
+		},
+		{
+			"invalid context without separator",
+			args{dirFleet, "a.yaml"},
+			"",
+			true,
+		},
+		{
+			"invalid context out of directory",
+			args{dirFleet, "../a.yaml:dev"},
+			"",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getK8sRestConfig(tt.args.kubeconfigPathname, tt.args.kubeContext)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getK8sRestConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != nil && got.BearerToken != tt.want {
+				t.Errorf("getK8sRestConfig() = %v, want %v", got.BearerToken, tt.want)
+			}
+		})
+	}
+}
+
+func TestCallK8sListWithContext(t *testing.T) {
+	patchCallK8sList := gomonkey.ApplyFunc(callK8sList,
+		func(authProvider auth.IAuthProvider, kubeContext string, namespace string, group string, version string, resource string,
+			listOpts map[string]any) (*json.RawMessage, error) {
+			return internal.JsonMarshal(map[string]any{"context": kubeContext})
+		})
+	defer patchCallK8sList.Reset()
+	rmDefault, _ := internal.JsonMarshal(map[string]any{"context": ""})
+	rmContext, _ := internal.JsonMarshal(map[string]any{"context": "a.yaml:dev"})
+
+	type args struct {
+		authProvider auth.IAuthProvider
+		kubeContext  string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *json.RawMessage
+		wantErr bool
+	}{
+		{
+			"Valid result of current context",
+			args{nil, ""},
+			rmDefault,
+			false,
+		},
+		{
+			"Valid result of given context",
+			args{nil, "a.yaml:dev"},
+			rmContext,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CallK8sListWithContext(tt.args.authProvider, tt.args.kubeContext, "", "", "", "mock_rs", nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CallK8sListWithContext() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CallK8sListWithContext() = %v, want %v", string(*got), string(*tt.want))
+			}
+		})
+	}
+}
+
+func TestGetK8sVersionWithContext(t *testing.T) {
+	patchGetK8sClient := gomonkey.ApplyFunc(getK8sClient,
+		func(p auth.IAuthProvider, kubeContext string) (*k8sClient, error) {
+			if kubeContext == "invalid" {
+				return nil, errors.New("mock error")
+			}
+			return &k8sClient{nil, nil, "v1.30.0-" + kubeContext, nil}, nil
+		})
+	defer patchGetK8sClient.Reset()
+
+	type args struct {
+		authProvider auth.IAuthProvider
+		kubeContext  string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			"Valid result of current context",
+			args{nil, ""},
+			"v1.30.0-",
+			false,
+		},
+		{
+			"Valid result of given context",
+			args{nil, "dev"},
+			"v1.30.0-dev",
+			false,
+		},
+		{
+			"failed to get client",
+			args{nil, "invalid"},
+			"",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetK8sVersionWithContext(tt.args.authProvider, tt.args.kubeContext)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetK8sVersionWithContext() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetK8sVersionWithContext() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,442 @@
+// Connector for OpenStack authenticated by Keystone v3
+
+package connector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/s3studio/cloud-bench-checker/internal"
+	"github.com/s3studio/cloud-bench-checker/pkg/auth"
+	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
+
+	"go.uber.org/ratelimit"
+)
+
+const (
+	OS_AUTH_URL                      = "OS_AUTH_URL"
+	OS_USERNAME                      = "OS_USERNAME"
+	OS_PASSWORD                      = "OS_PASSWORD"
+	OS_USER_DOMAIN_NAME              = "OS_USER_DOMAIN_NAME"
+	OS_PROJECT_ID                    = "OS_PROJECT_ID"
+	OS_PROJECT_NAME                  = "OS_PROJECT_NAME"
+	OS_PROJECT_DOMAIN_NAME           = "OS_PROJECT_DOMAIN_NAME"
+	OS_APPLICATION_CREDENTIAL_ID     = "OS_APPLICATION_CREDENTIAL_ID"
+	OS_APPLICATION_CREDENTIAL_SECRET = "OS_APPLICATION_CREDENTIAL_SECRET"
+	OS_REGION_NAME                   = "OS_REGION_NAME"
+	OS_INTERFACE                     = "OS_INTERFACE"
+
+	OPENSTACK_DEFAULT_DOMAIN    = "Default"
+	OPENSTACK_DEFAULT_INTERFACE = "public"
+
+	// Placeholder of project id in path and string values of extraParam
+	OPENSTACK_PROJECT_PLACEHOLDER = "{project}"
+
+	// Key added to the response object, with the marker of the next page as its value
+	OPENSTACK_NEXT_MARKER = "next_marker"
+	// Key of the response object wrapping a response of json array, e.g. listing of Swift
+	OPENSTACK_LIST_KEY = "items"
+
+	// Token is renewed before it actually expires
+	_openstackTokenRenewBefore = 5 * time.Minute
+)
+
+// Keys in profile whose values are scrubbed by Recorder
+var _recorderSecretOpenStack = []string{OS_PASSWORD, OS_APPLICATION_CREDENTIAL_SECRET}
+
+// openstackCatalogEntry: Service in the catalog returned by Keystone
+type openstackCatalogEntry struct {
+	Type      string `json:"type"`
+	Endpoints []struct {
+		Interface string `json:"interface"`
+		Region    string `json:"region"`
+		RegionId  string `json:"region_id"`
+		URL       string `json:"url"`
+	} `json:"endpoints"`
+}
+
+// openstackTokenResp: Body of response of Keystone when issuing a token
+type openstackTokenResp struct {
+	Token struct {
+		ExpiresAt time.Time               `json:"expires_at"`
+		Catalog   []openstackCatalogEntry `json:"catalog"`
+		Project   struct {
+			Id string `json:"id"`
+		} `json:"project"`
+	} `json:"token"`
+}
+
+// Bind token and catalog with the definition of authentication
+type openstackClient struct {
+	hc        *http.Client
+	authURL   string
+	authBody  []byte
+	region    string
+	iface     string
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+	projectId string
+	catalog   []openstackCatalogEntry
+}
+
+func createOpenStackClient(p auth.IAuthProvider) (*openstackClient, error) {
+	if p == nil {
+		return nil, errors.New("nil pointor of IAuthProvider")
+	}
+
+	v, err := p.GetProfile(def.OPENSTACK)
+	if err != nil {
+		return nil, err
+	}
+	if err := auth.IsAllSet(v, []string{OS_AUTH_URL}); err != nil {
+		return nil, err
+	}
+
+	identity := make(map[string]any)
+	authReq := map[string]any{"identity": identity}
+	if v.IsSet(OS_APPLICATION_CREDENTIAL_ID) {
+		// Application credential is bound to a project, so scope is not allowed
+		if err := auth.IsAllSet(v, []string{OS_APPLICATION_CREDENTIAL_SECRET}); err != nil {
+			return nil, err
+		}
+		identity["methods"] = []string{"application_credential"}
+		identity["application_credential"] = map[string]any{
+			"id":     v.GetString(OS_APPLICATION_CREDENTIAL_ID),
+			"secret": v.GetString(OS_APPLICATION_CREDENTIAL_SECRET),
+		}
+	} else {
+		if err := auth.IsAllSet(v, []string{OS_USERNAME, OS_PASSWORD}); err != nil {
+			return nil, err
+		}
+		identity["methods"] = []string{"password"}
+		identity["password"] = map[string]any{
+			"user": map[string]any{
+				"name":     v.GetString(OS_USERNAME),
+				"password": v.GetString(OS_PASSWORD),
+				"domain":   map[string]any{"name": getStringOrDefault(v.GetString(OS_USER_DOMAIN_NAME), OPENSTACK_DEFAULT_DOMAIN)},
+			},
+		}
+
+		if v.IsSet(OS_PROJECT_ID) {
+			authReq["scope"] = map[string]any{
+				"project": map[string]any{"id": v.GetString(OS_PROJECT_ID)},
+			}
+		} else if v.IsSet(OS_PROJECT_NAME) {
+			authReq["scope"] = map[string]any{
+				"project": map[string]any{
+					"name":   v.GetString(OS_PROJECT_NAME),
+					"domain": map[string]any{"name": getStringOrDefault(v.GetString(OS_PROJECT_DOMAIN_NAME), OPENSTACK_DEFAULT_DOMAIN)},
+				},
+			}
+		} else {
+			return nil, fmt.Errorf("failed to read key from profile: %s or %s", OS_PROJECT_ID, OS_PROJECT_NAME)
+		}
+	}
+
+	authBody, err := json.Marshal(map[string]any{"auth": authReq})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request of authentication: %w", err)
+	}
+
+	authURL := strings.TrimRight(v.GetString(OS_AUTH_URL), "/")
+	if !strings.HasSuffix(authURL, "/v3") {
+		authURL += "/v3"
+	}
+
+	client := openstackClient{
+		hc:       &http.Client{Timeout: 60 * time.Second},
+		authURL:  authURL + "/auth/tokens",
+		authBody: authBody,
+		region:   v.GetString(OS_REGION_NAME),
+		iface:    getStringOrDefault(v.GetString(OS_INTERFACE), OPENSTACK_DEFAULT_INTERFACE),
+	}
+
+	// Authenticate at once so that an invalid profile is not cached
+	if err := client.authenticate(); err != nil {
+		return nil, err
+	}
+
+	return &client, nil
+}
+
+var (
+	_mapOpenStackClient internal.SyncMap[*openstackClient]
+
+	_rlOpenStack = ratelimit.New(10, ratelimit.WithoutSlack)
+)
+
+func getOpenStackClient(p auth.IAuthProvider) (*openstackClient, error) {
+	key := fmt.Sprintf("%p_default", p)
+	return _mapOpenStackClient.LoadOrCreate(key, func() (any, error) {
+		return createOpenStackClient(p)
+	}, nil)
+}
+
+// authenticate: Issue a new token from Keystone, the caller must hold the lock or own the client
+func (c *openstackClient) authenticate() error {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, c.authURL, bytes.NewReader(c.authBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	_rlOpenStack.Take()
+	resp, err := c.hc.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to invoke api of authentication: %w", err)
+	}
+
+	defer resp.Body.Close()
+	byResp, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to authenticate with status %d: %s", resp.StatusCode, string(byResp))
+	}
+
+	token := resp.Header.Get("X-Subject-Token")
+	if len(token) == 0 {
+		return errors.New("invalid response of authentication, missing X-Subject-Token")
+	}
+
+	var tokenResp openstackTokenResp
+	if err := internal.JsonUnmarshal(byResp, &tokenResp); err != nil {
+		return fmt.Errorf("failed to unmarshal response as json: %w", err)
+	}
+
+	c.token = token
+	c.expiresAt = tokenResp.Token.ExpiresAt
+	c.projectId = tokenResp.Token.Project.Id
+	c.catalog = tokenResp.Token.Catalog
+
+	return nil
+}
+
+// getToken: Get a valid token and renew it if necessary
+// @return: Token
+// @return: Id of project scoped
+// @return: Catalog of services
+// @return: Error
+func (c *openstackClient) getToken() (string, string, []openstackCatalogEntry, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.expiresAt.IsZero() && time.Until(c.expiresAt) < _openstackTokenRenewBefore {
+		if err := c.authenticate(); err != nil {
+			return "", "", nil, err
+		}
+	}
+
+	return c.token, c.projectId, c.catalog, nil
+}
+
+// resolveOpenStackEndpoint: Find url of endpoint of service type in catalog
+// @param: catalog: Catalog of services
+// @param: service: Type of service, e.g. "compute"
+// @param: region: Name or id of region, any region is matched if empty
+// @param: iface: Interface of endpoint, e.g. "public"
+// @return: Url of endpoint
+// @return: Error
+func resolveOpenStackEndpoint(catalog []openstackCatalogEntry, service string, region string, iface string) (string, error) {
+	for _, entry := range catalog {
+		if entry.Type != service {
+			continue
+		}
+
+		for _, endpoint := range entry.Endpoints {
+			if endpoint.Interface != iface {
+				continue
+			}
+			if len(region) > 0 && endpoint.Region != region && endpoint.RegionId != region {
+				continue
+			}
+
+			return strings.TrimRight(endpoint.URL, "/"), nil
+		}
+	}
+
+	return "", fmt.Errorf("failed to find endpoint of service \"%s\" with interface \"%s\" in region \"%s\"", service, iface, region)
+}
+
+// isOpenStackCatalogURL: Check whether the url has the same scheme and host as any endpoint in catalog
+func isOpenStackCatalogURL(catalog []openstackCatalogEntry, u *url.URL) bool {
+	for _, entry := range catalog {
+		for _, endpoint := range entry.Endpoints {
+			if isSameOrigin(u, parseAbsoluteURL(endpoint.URL)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// CallOpenStack: Send a GET request to OpenStack and parse response
+//
+// A response of json array is wrapped in an object with the key of "items".
+// The marker of the next page, got from link of "next" in the response,
+// is added to the response object with the key of "next_marker".
+// @param: authProvider: IAuthProvider to provide profile of auth
+// @param: service: Type of service in catalog, e.g. "compute", "network", "volumev3" or "object-store"
+// @param: path: Path appended to the url of endpoint, or full url on an endpoint in the catalog
+// @param: extraParam: Query parameters, where "{project}" in string values is replaced by project id
+// @return: Response data from OpenStack
+// @return: Error
+func CallOpenStack(authProvider auth.IAuthProvider, service string, path string, extraParam map[string]any) (
+	*json.RawMessage, error) {
+	return recordCall(authProvider, def.OPENSTACK, _recorderSecretOpenStack, "CallOpenStack", []any{service, path, extraParam},
+		func() (*json.RawMessage, error) {
+			return callOpenStack(authProvider, service, path, extraParam)
+		})
+}
+
+// callOpenStack: Implementation of CallOpenStack without Recorder
+func callOpenStack(authProvider auth.IAuthProvider, service string, path string, extraParam map[string]any) (
+	*json.RawMessage, error) {
+	client, err := getOpenStackClient(authProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	token, projectId, catalog, err := client.getToken()
+	if err != nil {
+		return nil, err
+	}
+
+	path = strings.ReplaceAll(path, OPENSTACK_PROJECT_PLACEHOLDER, projectId)
+	URL := path
+	if u := parseAbsoluteURL(path); u != nil {
+		// The token is sent to the full url, so it must be on an endpoint in the catalog
+		if !isOpenStackCatalogURL(catalog, u) {
+			return nil, fmt.Errorf("url of OpenStack is not on any endpoint in the catalog: %s", u.Redacted())
+		}
+	} else {
+		endpoint, err := resolveOpenStackEndpoint(catalog, service, client.region, client.iface)
+		if err != nil {
+			return nil, err
+		}
+		if len(path) > 0 {
+			URL = fmt.Sprintf("%s/%s", endpoint, strings.TrimLeft(path, "/"))
+		} else {
+			URL = endpoint
+		}
+	}
+
+	query := url.Values{}
+	for k, v := range extraParam {
+		if err := addQueryValue(query, k, v, replacePlaceholder(OPENSTACK_PROJECT_PLACEHOLDER, projectId)); err != nil {
+			return nil, err
+		}
+	}
+	if len(query) > 0 {
+		URL = fmt.Sprintf("%s?%s", URL, query.Encode())
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Auth-Token", token)
+
+	_rlOpenStack.Take()
+	resp, err := client.hc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to invoke api: %w", err)
+	}
+
+	defer resp.Body.Close()
+	byResp, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNoContent {
+		// Swift returns 204 for an empty listing
+		byResp = []byte("[]")
+	} else if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("response indicates failure with status %d: %s", resp.StatusCode, string(byResp))
+	}
+
+	return openstackNormalizeResp(byResp, query.Get("limit"))
+}
+
+// openstackNormalizeResp: Normalize response so that it can be parsed with PAGE_MARKER
+//
+// - json object: marker of the next page is got from "next" in "{resource}_links" or "next" (Glance)
+//
+// - json array: wrapped with the key of "items", and the "name" of the last item is used as marker
+// of the next page if the number of items reaches limit (Swift)
+func openstackNormalizeResp(byResp []byte, limit string) (*json.RawMessage, error) {
+	var anyResp any
+	if err := internal.JsonUnmarshal(byResp, &anyResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response as json: %w", err)
+	}
+
+	var respMap map[string]any
+	nextMarker := ""
+	switch p := anyResp.(type) {
+	case map[string]any:
+		respMap = p
+		for k, v := range p {
+			if k == "next" {
+				// Glance returns link of the next page directly
+				if href, ok := v.(string); ok {
+					nextMarker = getMarkerFromLink(href)
+				}
+			} else if strings.HasSuffix(k, "_links") {
+				links, _ := v.([]any)
+				for _, link := range links {
+					mapLink, _ := link.(map[string]any)
+					if rel, _ := mapLink["rel"].(string); rel == "next" {
+						href, _ := mapLink["href"].(string)
+						nextMarker = getMarkerFromLink(href)
+					}
+				}
+			}
+		}
+	case []any:
+		respMap = map[string]any{OPENSTACK_LIST_KEY: p}
+		if len(p) > 0 && len(limit) > 0 && fmt.Sprint(len(p)) == limit {
+			if mapLast, ok := p[len(p)-1].(map[string]any); ok {
+				nextMarker, _ = mapLast["name"].(string)
+			}
+		}
+	default:
+		return nil, errors.New("invalid response, neither json object nor json array")
+	}
+
+	if len(nextMarker) > 0 {
+		respMap[OPENSTACK_NEXT_MARKER] = nextMarker
+	}
+
+	return internal.JsonMarshal(respMap)
+}
+
+// getMarkerFromLink: Get value of query parameter "marker" from a link
+func getMarkerFromLink(href string) string {
+	u, err := url.Parse(href)
+	if err != nil {
+		return ""
+	}
+	return u.Query().Get("marker")
+}
+
+func getStringOrDefault(val string, defaultVal string) string {
+	if len(val) == 0 {
+		return defaultVal
+	}
+	return val
+}
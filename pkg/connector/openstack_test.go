@@ -0,0 +1,434 @@
+// Connector for OpenStack authenticated by Keystone v3
+
+package connector
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/s3studio/cloud-bench-checker/pkg/auth"
+	"github.com/s3studio/cloud-bench-checker/test"
+)
+
+// newMockOpenStackServer: Mock of Keystone and services in its catalog
+func newMockOpenStackServer() *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v3/auth/tokens" {
+			var req map[string]any
+			json.NewDecoder(r.Body).Decode(&req)
+			identity, _ := req["auth"].(map[string]any)["identity"].(map[string]any)
+			if methods, _ := identity["methods"].([]any); len(methods) == 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if pwd, ok := identity["password"].(map[string]any); ok &&
+				pwd["user"].(map[string]any)["password"] != "mock_password" {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"error":{"code":401}}`))
+				return
+			}
+
+			w.Header().Set("X-Subject-Token", "mock_token")
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"token":{"expires_at":"` + time.Now().Add(time.Hour).UTC().Format(time.RFC3339) + `",` +
+				`"project":{"id":"mock-project"},"catalog":[` +
+				`{"type":"compute","endpoints":[` +
+				`{"interface":"internal","region_id":"r1","region":"r1","url":"http://internal"},` +
+				`{"interface":"public","region_id":"r1","region":"r1","url":"` + server.URL + `/compute/v2.1/"}]},` +
+				`{"type":"object-store","endpoints":[` +
+				`{"interface":"public","region_id":"r1","region":"r1","url":"` + server.URL + `/swift/v1/AUTH_mock-project"}]}` +
+				`]}}`))
+			return
+		}
+		if r.Header.Get("X-Auth-Token") != "mock_token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/compute/v2.1/servers":
+			w.Write([]byte(`{"servers":[{"id":"` + r.URL.Query().Get("tenant_id") + `"}],` +
+				`"servers_links":[{"rel":"next","href":"http://mock/servers?limit=1&marker=m1"}]}`))
+		case "/compute/v2.1/servers/mock-project":
+			w.Write([]byte(`{"server":{"id":"mock-project"}}`))
+		case "/swift/v1/AUTH_mock-project":
+			if r.URL.Query().Get("marker") == "c2" {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			w.Write([]byte(`[{"name":"c1","count":1},{"name":"c2","count":2}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"itemNotFound":{"code":404}}`))
+		}
+	}))
+	return server
+}
+
+func setupEnvOpenStack(t *testing.T, serverURL string) {
+	envMap := map[string]string{
+		"OS_AUTH_URL":     serverURL + "/v3/",
+		"OS_USERNAME":     "mock_user",
+		"OS_PASSWORD":     "mock_password",
+		"OS_PROJECT_NAME": "mock_project",
+		"OS_REGION_NAME":  "r1",
+	}
+	for k, v := range envMap {
+		t.Setenv(k, v)
+	}
+}
+
+func Test_createOpenStackClient(t *testing.T) {
+	server := newMockOpenStackServer()
+	defer server.Close()
+	setupEnvOpenStack(t, server.URL)
+
+	type args struct {
+		p auth.IAuthProvider
+	}
+	tests := []struct {
+		name string
+		env  map[string]string
+		args args
+		//want    *openstackClient
+		wantErr bool
+	}{
+		{
+			"Valid result of password",
+			nil,
+			args{auth.NewAuthFileProvider(test.Test_conf_openstack)},
+			false,
+		},
+		{
+			"Valid result of application credential",
+			map[string]string{"OS_APPLICATION_CREDENTIAL_ID": "mock_id", "OS_APPLICATION_CREDENTIAL_SECRET": "mock_secret"},
+			args{auth.NewAuthFileProvider(test.Test_conf_openstack)},
+			false,
+		},
+		{
+			"Missing secret of application credential",
+			map[string]string{"OS_APPLICATION_CREDENTIAL_ID": "mock_id"},
+			args{auth.NewAuthFileProvider(test.Test_conf_openstack)},
+			true,
+		},
+		{
+			"Wrong password",
+			map[string]string{"OS_PASSWORD": "wrong"},
+			args{auth.NewAuthFileProvider(test.Test_conf_openstack)},
+			true,
+		},
+		{
+			"Profile not defined",
+			nil,
+			args{auth.NewAuthFileProvider(test.Test_conf_invalid)},
+			true,
+		},
+		{
+			"Key not set",
+			nil,
+			args{&test.MockKeyNotSetAuthProvider{}},
+			true,
+		},
+		{
+			"nil pointor of IAuthProvider",
+			nil,
+			args{nil},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			got, err := createOpenStackClient(tt.args.p)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("createOpenStackClient() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if (got == nil) != tt.wantErr {
+				t.Errorf("createOpenStackClient() = %v, want a valid pointer", got)
+			}
+		})
+	}
+}
+
+func Test_createOpenStackClient_missingProject(t *testing.T) {
+	setupEnvOpenStack(t, "http://mock")
+	os.Unsetenv("OS_PROJECT_NAME")
+
+	if _, err := createOpenStackClient(auth.NewAuthFileProvider(test.Test_conf_openstack)); err == nil {
+		t.Errorf("createOpenStackClient() error = nil, wantErr true")
+	}
+}
+
+func Test_getOpenStackClient(t *testing.T) {
+	server := newMockOpenStackServer()
+	defer server.Close()
+	setupEnvOpenStack(t, server.URL)
+
+	type args struct {
+		p auth.IAuthProvider
+	}
+	tests := []struct {
+		name string
+		args args
+		//want    *openstackClient
+		wantErr bool
+	}{
+		{
+			"Valid result",
+			args{auth.NewAuthFileProvider(test.Test_conf_openstack)},
+			false,
+		},
+		{
+			"Profile not defined",
+			args{auth.NewAuthFileProvider(test.Test_conf_invalid)},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getOpenStackClient(tt.args.p)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getOpenStackClient() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if (got == nil) != tt.wantErr {
+				t.Errorf("getOpenStackClient() = %v, want a valid pointer", got)
+			}
+		})
+	}
+}
+
+func Test_openstackClient_getToken(t *testing.T) {
+	server := newMockOpenStackServer()
+	defer server.Close()
+	setupEnvOpenStack(t, server.URL)
+
+	client, err := createOpenStackClient(auth.NewAuthFileProvider(test.Test_conf_openstack))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Expiring token is renewed
+	client.token = "expiring"
+	client.expiresAt = time.Now().Add(time.Minute)
+	token, projectId, _, err := client.getToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "mock_token" || projectId != "mock-project" {
+		t.Errorf("openstackClient.getToken() = %v, %v, want mock_token, mock-project", token, projectId)
+	}
+	if time.Until(client.expiresAt) < _openstackTokenRenewBefore {
+		t.Errorf("openstackClient.getToken() expiresAt = %v, want renewed", client.expiresAt)
+	}
+}
+
+func Test_resolveOpenStackEndpoint(t *testing.T) {
+	var catalog []openstackCatalogEntry
+	json.Unmarshal([]byte(`[{"type":"network","endpoints":[`+
+		`{"interface":"public","region_id":"r1","region":"r1","url":"http://r1/"},`+
+		`{"interface":"public","region_id":"r2","region":"r2","url":"http://r2"},`+
+		`{"interface":"admin","region_id":"r2","region":"r2","url":"http://admin"}]}]`), &catalog)
+
+	type args struct {
+		service string
+		region  string
+		iface   string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{"Any region", args{"network", "", "public"}, "http://r1", false},
+		{"Specified region", args{"network", "r2", "public"}, "http://r2", false},
+		{"Specified interface", args{"network", "r2", "admin"}, "http://admin", false},
+		{"Region not found", args{"network", "r3", "public"}, "", true},
+		{"Service not found", args{"compute", "", "public"}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveOpenStackEndpoint(catalog, tt.args.service, tt.args.region, tt.args.iface)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("resolveOpenStackEndpoint() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("resolveOpenStackEndpoint() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCallOpenStack(t *testing.T) {
+	server := newMockOpenStackServer()
+	defer server.Close()
+	setupEnvOpenStack(t, server.URL)
+
+	authProvider := auth.NewAuthFileProvider(test.Test_conf_openstack)
+	var rmServers json.RawMessage = []byte(`{"next_marker":"m1","servers":[{"id":"mock-project"}],` +
+		`"servers_links":[{"href":"http://mock/servers?limit=1\u0026marker=m1","rel":"next"}]}`)
+	var rmServer json.RawMessage = []byte(`{"server":{"id":"mock-project"}}`)
+	var rmContainers json.RawMessage = []byte(`{"items":[{"count":1,"name":"c1"},{"count":2,"name":"c2"}],"next_marker":"c2"}`)
+	var rmContainersNoMore json.RawMessage = []byte(`{"items":[{"count":1,"name":"c1"},{"count":2,"name":"c2"}]}`)
+	var rmEmpty json.RawMessage = []byte(`{"items":[]}`)
+
+	type args struct {
+		authProvider auth.IAuthProvider
+		service      string
+		path         string
+		extraParam   map[string]any
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *json.RawMessage
+		wantErr bool
+	}{
+		{
+			"Valid result with link of next page",
+			args{authProvider, "compute", "servers", map[string]any{"tenant_id": "{project}"}},
+			&rmServers,
+			false,
+		},
+		{
+			"Valid result with project in path",
+			args{authProvider, "compute", "/servers/{project}", nil},
+			&rmServer,
+			false,
+		},
+		{
+			"Valid result of json array reaching limit",
+			args{authProvider, "object-store", "", map[string]any{"limit": 2}},
+			&rmContainers,
+			false,
+		},
+		{
+			"Valid result of json array not reaching limit",
+			args{authProvider, "object-store", "", map[string]any{"limit": 3}},
+			&rmContainersNoMore,
+			false,
+		},
+		{
+			"Valid result of no content",
+			args{authProvider, "object-store", "", map[string]any{"marker": "c2"}},
+			&rmEmpty,
+			false,
+		},
+		{
+			"Valid result of full url",
+			args{authProvider, "", server.URL + "/compute/v2.1/servers/{project}", nil},
+			&rmServer,
+			false,
+		},
+		{
+			"Full url not in catalog",
+			args{authProvider, "", "http://mock/compute/v2.1/servers/{project}", nil},
+			nil,
+			true,
+		},
+		{
+			"Response indicates failure",
+			args{authProvider, "compute", "notfound", nil},
+			nil,
+			true,
+		},
+		{
+			"Service not found in catalog",
+			args{authProvider, "network", "v2.0/networks", nil},
+			nil,
+			true,
+		},
+		{
+			"Unsupported type of query param",
+			args{authProvider, "compute", "servers", map[string]any{"limit": 1.5}},
+			nil,
+			true,
+		},
+		{
+			"Profile not defined",
+			args{auth.NewAuthFileProvider(test.Test_conf_invalid), "compute", "servers", nil},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CallOpenStack(tt.args.authProvider, tt.args.service, tt.args.path, tt.args.extraParam)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CallOpenStack() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CallOpenStack() = %v, want %v", string(*got), string(*tt.want))
+			}
+		})
+	}
+}
+
+func Test_openstackNormalizeResp(t *testing.T) {
+	type args struct {
+		byResp []byte
+		limit  string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			"Link of next page of Glance",
+			args{[]byte(`{"images":[],"next":"/v2/images?marker=i1"}`), ""},
+			`{"images":[],"next":"/v2/images?marker=i1","next_marker":"i1"}`,
+			false,
+		},
+		{
+			"Links without next page",
+			args{[]byte(`{"volumes":[],"volumes_links":[{"rel":"self","href":"http://mock?marker=v1"}]}`), ""},
+			`{"volumes":[],"volumes_links":[{"href":"http://mock?marker=v1","rel":"self"}]}`,
+			false,
+		},
+		{
+			"Number is kept",
+			args{[]byte(`{"size":12345678901234567890}`), ""},
+			`{"size":12345678901234567890}`,
+			false,
+		},
+		{
+			"Neither object nor array",
+			args{[]byte(`"string"`), ""},
+			"",
+			true,
+		},
+		{
+			"Invalid json",
+			args{[]byte(`{`), ""},
+			"",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := openstackNormalizeResp(tt.args.byResp, tt.args.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("openstackNormalizeResp() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && strings.Compare(string(*got), tt.want) != 0 {
+				t.Errorf("openstackNormalizeResp() = %v, want %v", string(*got), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,329 @@
+// Recorder to record and replay calls of connectors with cassette files
+
+package connector
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/s3studio/cloud-bench-checker/internal"
+	"github.com/s3studio/cloud-bench-checker/pkg/auth"
+	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
+)
+
+// RecorderMode: Mode of Recorder
+type RecorderMode string
+
+const (
+	// Calls are sent to the cloud without being recorded
+	RECORDER_OFF RecorderMode = "off"
+	// Calls are sent to the cloud and recorded into the cassette
+	RECORDER_RECORD RecorderMode = "record"
+	// Calls are answered from the cassette without accessing the cloud
+	RECORDER_REPLAY RecorderMode = "replay"
+)
+
+const (
+	CASSETTE_VERSION = 1
+	// Value to replace the secrets in the cassette
+	RECORDER_SCRUBBED = "***"
+)
+
+// Keys of json objects whose value is scrubbed in the cassette if it is a string,
+// compared in lower case with "_" and "-" removed
+var _recorderScrubKeys = map[string]bool{
+	"authorization":   true,
+	"password":        true,
+	"secret":          true,
+	"secretkey":       true,
+	"secretaccesskey": true,
+	"clientsecret":    true,
+	"accesskeysecret": true,
+	"token":           true,
+	"accesstoken":     true,
+	"refreshtoken":    true,
+	"idtoken":         true,
+	"sessiontoken":    true,
+	"xsubjecttoken":   true,
+	"privatekey":      true,
+	"apikey":          true,
+	"signature":       true,
+}
+
+// CassetteInteraction: A recorded call of connector
+type CassetteInteraction struct {
+	// Name of the Call* function
+	Call string `json:"call"`
+	// Parameters of the call except IAuthProvider, in a json array
+	Request json.RawMessage `json:"request"`
+	// Response of the call, omitted if error occurred
+	Response json.RawMessage `json:"response,omitempty"`
+	// Error message of the call
+	Error string `json:"error,omitempty"`
+}
+
+// Cassette: Content of cassette file
+type Cassette struct {
+	Version      int                   `json:"version"`
+	Interactions []CassetteInteraction `json:"interactions"`
+}
+
+// Recorder: Record calls of connectors into a cassette file or replay them from it
+type Recorder struct {
+	mode     RecorderMode
+	pathname string
+
+	mu       sync.Mutex
+	cassette Cassette
+	// Index of the next interaction to be replayed by key of request
+	replayIndex map[string]int
+}
+
+var _recorder *Recorder
+
+// SetRecorder: Set global Recorder used by all Call* functions of connectors
+// @param: r: Recorder instance, nil to disable the Recorder
+func SetRecorder(r *Recorder) {
+	_recorder = r
+}
+
+// NewRecorder: Create a Recorder
+//
+// The cassette file is loaded in RECORDER_REPLAY mode,
+// and saved by Recorder.Save in RECORDER_RECORD mode.
+// @param: mode: Mode of Recorder
+// @param: pathname: Pathname of cassette file
+// @return: Pointer of Recorder
+// @return: Error
+func NewRecorder(mode RecorderMode, pathname string) (*Recorder, error) {
+	r := Recorder{
+		mode:        mode,
+		pathname:    pathname,
+		cassette:    Cassette{Version: CASSETTE_VERSION, Interactions: []CassetteInteraction{}},
+		replayIndex: make(map[string]int),
+	}
+
+	switch mode {
+	case RECORDER_OFF, RECORDER_RECORD:
+	case RECORDER_REPLAY:
+		by, err := os.ReadFile(pathname)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cassette: %w", err)
+		}
+		if err := json.Unmarshal(by, &r.cassette); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal cassette: %w", err)
+		}
+		if r.cassette.Version != CASSETTE_VERSION {
+			return nil, fmt.Errorf("unsupported version of cassette: %d", r.cassette.Version)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported mode of recorder: %s", mode)
+	}
+
+	return &r, nil
+}
+
+// Mode: Get mode of Recorder
+// @return: Mode of Recorder
+func (r *Recorder) Mode() RecorderMode {
+	return r.mode
+}
+
+// Save: Save recorded interactions to the cassette file, only available in RECORDER_RECORD mode
+// @return: Error
+func (r *Recorder) Save() error {
+	if r.mode != RECORDER_RECORD {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	by, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal cassette: %w", err)
+	}
+	if err := os.WriteFile(r.pathname, by, 0600); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Recorder) record(call string, request json.RawMessage, response json.RawMessage, errCall error) {
+	interaction := CassetteInteraction{
+		Call:     call,
+		Request:  request,
+		Response: response,
+	}
+	if errCall != nil {
+		interaction.Response = nil
+		interaction.Error = errCall.Error()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+}
+
+// Interactions with the same request are replayed in the recorded order,
+// and the last one is replayed repeatedly after all of them have been replayed
+func (r *Recorder) replay(call string, request json.RawMessage) (*CassetteInteraction, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := call + string(request)
+	var matched []*CassetteInteraction
+	for i := range r.cassette.Interactions {
+		interaction := &r.cassette.Interactions[i]
+		if interaction.Call != call {
+			continue
+		}
+
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, interaction.Request); err != nil {
+			continue
+		}
+		if bytes.Equal(buf.Bytes(), request) {
+			matched = append(matched, interaction)
+		}
+	}
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("no interaction recorded in cassette for %s with request %s", call, string(request))
+	}
+
+	index := r.replayIndex[key]
+	if index < len(matched)-1 {
+		r.replayIndex[key] = index + 1
+	} else {
+		index = len(matched) - 1
+	}
+
+	return matched[index], nil
+}
+
+// recordCall: Call fn through the global Recorder
+// @param: authProvider: IAuthProvider to get secrets to be scrubbed
+// @param: cloudType: Cloud type of the profile containing secrets
+// @param: secretKeys: Keys in the profile whose values are scrubbed
+// @param: call: Name of the Call* function
+// @param: request: Parameters of the call except IAuthProvider
+// @param: fn: Function which actually sends the request to the cloud
+// @return: Response, which is unmarshaled from the cassette in RECORDER_REPLAY mode
+// @return: Error
+func recordCall[T any](authProvider auth.IAuthProvider, cloudType def.CloudType, secretKeys []string,
+	call string, request []any, fn func() (T, error)) (T, error) {
+	r := _recorder
+	if r == nil || r.mode == RECORDER_OFF {
+		return fn()
+	}
+
+	var nilVal T
+	secrets := getRecorderSecrets(authProvider, cloudType, secretKeys)
+	byRequest, err := scrubJson(request, secrets)
+	if err != nil {
+		return nilVal, fmt.Errorf("failed to marshal request for recorder: %w", err)
+	}
+
+	if r.mode == RECORDER_REPLAY {
+		interaction, err := r.replay(call, byRequest)
+		if err != nil {
+			return nilVal, err
+		}
+		if len(interaction.Error) > 0 {
+			return nilVal, errors.New(interaction.Error)
+		}
+
+		// Response in the cassette file may be indented
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, interaction.Response); err != nil {
+			return nilVal, fmt.Errorf("failed to unmarshal response in cassette: %w", err)
+		}
+
+		var res T
+		if err := internal.JsonUnmarshal(buf.Bytes(), &res); err != nil {
+			return nilVal, fmt.Errorf("failed to unmarshal response in cassette: %w", err)
+		}
+		return res, nil
+	}
+
+	res, errCall := fn()
+	var byResponse json.RawMessage
+	if errCall == nil {
+		if byResponse, err = scrubJson(res, secrets); err != nil {
+			return nilVal, fmt.Errorf("failed to marshal response for recorder: %w", err)
+		}
+	}
+	r.record(call, byRequest, byResponse, errCall)
+
+	return res, errCall
+}
+
+func getRecorderSecrets(authProvider auth.IAuthProvider, cloudType def.CloudType, secretKeys []string) []string {
+	if authProvider == nil || len(secretKeys) == 0 {
+		return nil
+	}
+
+	v, err := authProvider.GetProfile(cloudType)
+	if err != nil {
+		// No secret is available if the profile is not defined
+		return nil
+	}
+
+	var secrets []string
+	for _, key := range secretKeys {
+		if secret := v.GetString(key); len(secret) > 0 {
+			secrets = append(secrets, secret)
+		}
+	}
+
+	return secrets
+}
+
+// scrubJson: Marshal v as compact json with secrets scrubbed
+func scrubJson(v any, secrets []string) (json.RawMessage, error) {
+	by, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var data any
+	if err := internal.JsonUnmarshal(by, &data); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(scrubValue(data, secrets))
+}
+
+func scrubValue(data any, secrets []string) any {
+	switch val := data.(type) {
+	case map[string]any:
+		for k, v := range val {
+			normalizedKey := strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(k))
+			if _, ok := v.(string); ok && _recorderScrubKeys[normalizedKey] {
+				// Only strings are scrubbed, keeping objects such as "secret" volumes of k8s for replay
+				val[k] = RECORDER_SCRUBBED
+			} else {
+				val[k] = scrubValue(v, secrets)
+			}
+		}
+		return val
+	case []any:
+		for i, v := range val {
+			val[i] = scrubValue(v, secrets)
+		}
+		return val
+	case string:
+		for _, secret := range secrets {
+			val = strings.ReplaceAll(val, secret, RECORDER_SCRUBBED)
+		}
+		return val
+	default:
+		return data
+	}
+}
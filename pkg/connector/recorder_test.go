@@ -0,0 +1,269 @@
+// Recorder to record and replay calls of connectors with cassette files
+
+package connector
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/s3studio/cloud-bench-checker/pkg/auth"
+	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
+	"github.com/s3studio/cloud-bench-checker/test"
+)
+
+func TestNewRecorder(t *testing.T) {
+	dir := t.TempDir()
+	validCassette := filepath.Join(dir, "valid.json")
+	os.WriteFile(validCassette, []byte(`{"version":1,"interactions":[]}`), 0600)
+	invalidVersion := filepath.Join(dir, "invalid_version.json")
+	os.WriteFile(invalidVersion, []byte(`{"version":0}`), 0600)
+	invalidJson := filepath.Join(dir, "invalid_json.json")
+	os.WriteFile(invalidJson, []byte(`invalid`), 0600)
+
+	type args struct {
+		mode     RecorderMode
+		pathname string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			"Valid result of off",
+			args{RECORDER_OFF, ""},
+			false,
+		},
+		{
+			"Valid result of record",
+			args{RECORDER_RECORD, filepath.Join(dir, "new.json")},
+			false,
+		},
+		{
+			"Valid result of replay",
+			args{RECORDER_REPLAY, validCassette},
+			false,
+		},
+		{
+			"Cassette not exists",
+			args{RECORDER_REPLAY, filepath.Join(dir, "not_exists.json")},
+			true,
+		},
+		{
+			"Unsupported version of cassette",
+			args{RECORDER_REPLAY, invalidVersion},
+			true,
+		},
+		{
+			"Invalid cassette",
+			args{RECORDER_REPLAY, invalidJson},
+			true,
+		},
+		{
+			"Unsupported mode",
+			args{"invalid", ""},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewRecorder(tt.args.mode, tt.args.pathname)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewRecorder() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got.Mode() != tt.args.mode {
+				t.Errorf("NewRecorder() mode = %v, want %v", got.Mode(), tt.args.mode)
+			}
+		})
+	}
+}
+
+func Test_recordCall(t *testing.T) {
+	t.Setenv("TENCENTCLOUD_SECRET_KEY", "mock_secret_key")
+	authProvider := auth.NewAuthFileProvider(test.Test_conf_env)
+	pathname := filepath.Join(t.TempDir(), "cassette.json")
+
+	recorder, _ := NewRecorder(RECORDER_RECORD, pathname)
+	SetRecorder(recorder)
+	defer SetRecorder(nil)
+
+	count := 0
+	fnCall := func(param string) (map[string]any, error) {
+		return recordCall(authProvider, def.TENCENT_CLOUD, []string{TENCENTCLOUD_SECRET_KEY},
+			"CallMock", []any{param}, func() (map[string]any, error) {
+				count++
+				if param == "invalid" {
+					return nil, errors.New("mock error")
+				}
+				return map[string]any{
+					"count":    count,
+					"password": "mock_password",
+					"list":     []any{"with mock_secret_key"},
+				}, nil
+			})
+	}
+	fnCall("valid")
+	fnCall("valid")
+	fnCall("invalid")
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Recorder.Save() error = %v", err)
+	}
+
+	byCassette, _ := os.ReadFile(pathname)
+	if strings.Contains(string(byCassette), "mock_password") || strings.Contains(string(byCassette), "mock_secret_key") {
+		t.Errorf("Recorder.Save() secrets not scrubbed: %s", string(byCassette))
+	}
+
+	recorder, _ = NewRecorder(RECORDER_REPLAY, pathname)
+	SetRecorder(recorder)
+
+	type args struct {
+		param string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    map[string]any
+		wantErr bool
+	}{
+		{
+			"Valid result of first interaction",
+			args{"valid"},
+			map[string]any{"count": json.Number("1"), "password": RECORDER_SCRUBBED, "list": []any{"with " + RECORDER_SCRUBBED}},
+			false,
+		},
+		{
+			"Valid result of second interaction",
+			args{"valid"},
+			map[string]any{"count": json.Number("2"), "password": RECORDER_SCRUBBED, "list": []any{"with " + RECORDER_SCRUBBED}},
+			false,
+		},
+		{
+			"Last interaction replayed repeatedly",
+			args{"valid"},
+			map[string]any{"count": json.Number("2"), "password": RECORDER_SCRUBBED, "list": []any{"with " + RECORDER_SCRUBBED}},
+			false,
+		},
+		{
+			"Error replayed",
+			args{"invalid"},
+			nil,
+			true,
+		},
+		{
+			"No interaction recorded",
+			args{"not_recorded"},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fnCall(tt.args.param)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("recordCall() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("recordCall() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if count != 3 {
+		t.Errorf("recordCall() called the cloud %d times, want 3", count)
+	}
+}
+
+func TestRecorder_CallHttpApi(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Link", `<http://mock/?page=2>; rel="next"`)
+		w.Write([]byte(`{"token":"` + r.Header.Get("Authorization") + `","name":"mock"}`))
+	}))
+	t.Setenv("HTTP_API_BASE_URL", server.URL)
+	t.Setenv("HTTP_API_AUTH_TYPE", "bearer")
+	t.Setenv("HTTP_API_TOKEN", "mock_token")
+	authProvider := auth.NewAuthFileProvider(test.Test_conf_http_api)
+	cmd := &def.ConfHttpCmd{URL: "repos"}
+	pathname := filepath.Join(t.TempDir(), "cassette.json")
+
+	recorder, _ := NewRecorder(RECORDER_RECORD, pathname)
+	SetRecorder(recorder)
+	defer SetRecorder(nil)
+	if _, _, err := CallHttpApi(authProvider, cmd, "", map[string]any{"page": 1}); err != nil {
+		t.Fatalf("CallHttpApi() error = %v", err)
+	}
+	recorder.Save()
+	server.Close()
+
+	byCassette, _ := os.ReadFile(pathname)
+	if strings.Contains(string(byCassette), "mock_token") {
+		t.Errorf("Recorder.Save() secrets not scrubbed: %s", string(byCassette))
+	}
+
+	recorder, _ = NewRecorder(RECORDER_REPLAY, pathname)
+	SetRecorder(recorder)
+	got, got1, err := CallHttpApi(auth.NewAuthFileProvider(test.Test_conf_invalid), cmd, "", map[string]any{"page": 1})
+	if err != nil {
+		t.Fatalf("CallHttpApi() error = %v", err)
+	}
+	if want := `{"name":"mock","token":"***"}`; string(*got) != want {
+		t.Errorf("CallHttpApi() got = %v, want %v", string(*got), want)
+	}
+	if want1 := "http://mock/?page=2"; got1 != want1 {
+		t.Errorf("CallHttpApi() got1 = %v, want %v", got1, want1)
+	}
+}
+
+func TestRecorder_CallK8sGet(t *testing.T) {
+	pod := json.RawMessage(`{"kind":"Pod","metadata":{"name":"mock"},"spec":{"volumes":[{"name":"cert","secret":{"secretName":"mock-cert"}}]}}`)
+	patchCallK8sGet := gomonkey.ApplyFunc(callK8sGet,
+		func(authProvider auth.IAuthProvider, kubeContext string, namespace string, group string, version string, resource string,
+			name string, subresource string, ignoreNotFound bool) (*json.RawMessage, error) {
+			return &pod, nil
+		})
+	patchGetK8sClient := gomonkey.ApplyFunc(getK8sClient,
+		func(p auth.IAuthProvider, kubeContext string) (*k8sClient, error) {
+			return &k8sClient{nil, nil, "v1.30.0", nil}, nil
+		})
+	pathname := filepath.Join(t.TempDir(), "cassette.json")
+
+	recorder, _ := NewRecorder(RECORDER_RECORD, pathname)
+	SetRecorder(recorder)
+	defer SetRecorder(nil)
+	if _, err := CallK8sGet(nil, "dev", "default", "", "v1", "pods", "mock", "", false); err != nil {
+		t.Fatalf("CallK8sGet() error = %v", err)
+	}
+	if _, err := GetK8sVersionWithContext(nil, "dev"); err != nil {
+		t.Fatalf("GetK8sVersionWithContext() error = %v", err)
+	}
+	recorder.Save()
+	patchCallK8sGet.Reset()
+	patchGetK8sClient.Reset()
+
+	recorder, _ = NewRecorder(RECORDER_REPLAY, pathname)
+	SetRecorder(recorder)
+	got, err := CallK8sGet(nil, "dev", "default", "", "v1", "pods", "mock", "", false)
+	if err != nil {
+		t.Fatalf("CallK8sGet() error = %v", err)
+	}
+	if string(*got) != string(pod) {
+		t.Errorf("CallK8sGet() = %v, want %v", string(*got), string(pod))
+	}
+	gotVersion, err := GetK8sVersionWithContext(nil, "dev")
+	if err != nil {
+		t.Fatalf("GetK8sVersionWithContext() error = %v", err)
+	}
+	if want := "v1.30.0"; gotVersion != want {
+		t.Errorf("GetK8sVersionWithContext() = %v, want %v", gotVersion, want)
+	}
+}
@@ -0,0 +1,63 @@
+// Regions of cloud used to list resources in multiple regions
+
+package connector
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/s3studio/cloud-bench-checker/internal"
+	"github.com/s3studio/cloud-bench-checker/pkg/auth"
+	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
+)
+
+// parseRegions: Parse list of regions from response of DescribeRegions
+// @param: res: Response of DescribeRegions
+// @param: jsonPath: JsonPath to the list of regions
+// @return: List of regions
+// @return: Error
+func parseRegions(res *json.RawMessage, jsonPath string) ([]string, error) {
+	rmRegions, err := internal.ParseJsonPath(res, jsonPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse regions: %w", err)
+	}
+
+	var regions []string
+	if err := json.Unmarshal(*rmRegions, &regions); err != nil {
+		return nil, fmt.Errorf("failed to parse regions: %w", err)
+	}
+
+	return regions, nil
+}
+
+// GetProfileRegion: Get the region set in the profile of the cloud
+// @param: authProvider: IAuthProvider to provide profile of auth
+// @param: cloudType: Type of the cloud
+// @return: Region of profile, or empty string if the cloud has no region in profile
+// @return: Error
+func GetProfileRegion(authProvider auth.IAuthProvider, cloudType def.CloudType) (string, error) {
+	var key string
+	switch cloudType {
+	case def.TENCENT_CLOUD, def.TENCENT_COS:
+		key = TENCENTCLOUD_REGION
+	case def.ALIYUN_CLOUD, def.ALIYUN_OSS:
+		key = ALIYUN_REGION
+	case def.AWS:
+		key = AWS_REGION
+	case def.OPENSTACK:
+		key = OS_REGION_NAME
+	default:
+		return "", nil
+	}
+
+	if authProvider == nil {
+		return "", errors.New("nil pointor of IAuthProvider")
+	}
+	v, err := authProvider.GetProfile(cloudType)
+	if err != nil {
+		return "", err
+	}
+
+	return v.GetString(key), nil
+}
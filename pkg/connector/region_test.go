@@ -0,0 +1,123 @@
+// Regions of cloud used to list resources in multiple regions
+
+package connector
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/s3studio/cloud-bench-checker/internal"
+	"github.com/s3studio/cloud-bench-checker/pkg/auth"
+	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
+	"github.com/s3studio/cloud-bench-checker/test"
+)
+
+func Test_parseRegions(t *testing.T) {
+	rmValid, _ := internal.JsonMarshal(map[string]any{
+		"RegionSet": []any{map[string]any{"Region": "region-1"}, map[string]any{"Region": "region-2"}},
+	})
+	rmEmpty, _ := internal.JsonMarshal(map[string]any{"RegionSet": []any{}})
+	rmInvalid, _ := internal.JsonMarshal(map[string]any{"RegionSet": "invalid"})
+
+	type args struct {
+		res      *json.RawMessage
+		jsonPath string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []string
+		wantErr bool
+	}{
+		{
+			"Valid result",
+			args{rmValid, "$.RegionSet[*].Region"},
+			[]string{"region-1", "region-2"},
+			false,
+		},
+		{
+			"Valid result of no region",
+			args{rmEmpty, "$.RegionSet[*].Region"},
+			[]string{},
+			false,
+		},
+		{
+			"failed to parse regions with JsonPath",
+			args{rmValid, "invalid"},
+			nil,
+			true,
+		},
+		{
+			"failed to parse regions as list of string",
+			args{rmInvalid, "$.RegionSet"},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseRegions(tt.args.res, tt.args.jsonPath)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseRegions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseRegions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetProfileRegion(t *testing.T) {
+	os.Setenv("AWS_REGION", "us-east-1")
+
+	type args struct {
+		authProvider auth.IAuthProvider
+		cloudType    def.CloudType
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			"Valid result",
+			args{auth.NewAuthFileProvider(test.Test_conf_aws), def.AWS},
+			"us-east-1",
+			false,
+		},
+		{
+			"Valid result of cloud without region",
+			args{nil, def.K8S},
+			"",
+			false,
+		},
+		{
+			"failed to get profile",
+			args{auth.NewAuthFileProvider(test.Test_conf_invalid), def.AWS},
+			"",
+			true,
+		},
+		{
+			"nil pointor of IAuthProvider",
+			args{nil, def.AWS},
+			"",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetProfileRegion(tt.args.authProvider, tt.args.cloudType)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetProfileRegion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetProfileRegion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
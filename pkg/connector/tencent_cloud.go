@@ -23,7 +23,10 @@ const (
 	TENCENTCLOUD_REGION     = "TENCENTCLOUD_REGION"
 )
 
-func createTencentCloudClient(p auth.IAuthProvider) (*common.Client, error) {
+// Keys in profile whose values are scrubbed by Recorder
+var _recorderSecretTencentCloud = []string{TENCENTCLOUD_SECRET_ID, TENCENTCLOUD_SECRET_KEY}
+
+func createTencentCloudClient(p auth.IAuthProvider, region string) (*common.Client, error) {
 	if p == nil {
 		return nil, errors.New("nil pointor of IAuthProvider")
 	}
@@ -32,15 +35,23 @@ func createTencentCloudClient(p auth.IAuthProvider) (*common.Client, error) {
 	if err != nil {
 		return nil, err
 	}
-	if err := auth.IsAllSet(v, []string{TENCENTCLOUD_SECRET_ID, TENCENTCLOUD_SECRET_KEY, TENCENTCLOUD_REGION}); err != nil {
+	requiredKeys := []string{TENCENTCLOUD_SECRET_ID, TENCENTCLOUD_SECRET_KEY}
+	if len(region) == 0 {
+		// Region in the profile is used by default
+		requiredKeys = append(requiredKeys, TENCENTCLOUD_REGION)
+	}
+	if err := auth.IsAllSet(v, requiredKeys); err != nil {
 		return nil, err
 	}
+	if len(region) == 0 {
+		region = v.GetString(TENCENTCLOUD_REGION)
+	}
 
 	credential := common.NewCredential(
 		v.GetString(TENCENTCLOUD_SECRET_ID),
 		v.GetString(TENCENTCLOUD_SECRET_KEY))
 	cpf := profile.NewClientProfile()
-	client := common.NewCommonClient(credential, v.GetString(TENCENTCLOUD_REGION), cpf)
+	client := common.NewCommonClient(credential, region, cpf)
 	return client, nil
 }
 
@@ -50,14 +61,20 @@ var (
 	_rlTencentCloud = ratelimit.New(10, ratelimit.WithoutSlack)
 )
 
-func getTencentCloudClient(p auth.IAuthProvider) (*common.Client, error) {
+// getTencentCloudClient: Get client of the region from cache, or create a new one
+//
+// Empty region stands for the region in the profile
+func getTencentCloudClient(p auth.IAuthProvider, region string) (*common.Client, error) {
 	key := fmt.Sprintf("%p_default", p)
+	if len(region) > 0 {
+		key = fmt.Sprintf("%p_%s", p, region)
+	}
 	return _mapTencentCloudClient.LoadOrCreate(key, func() (any, error) {
-		return createTencentCloudClient(p)
+		return createTencentCloudClient(p, region)
 	}, nil)
 }
 
-// CallTencentCloud: Send a request to Tencent cloud and parse response
+// CallTencentCloud: Send a request to Tencent cloud in the region of the profile and parse response
 // @param: authProvider: IAuthProvider to provide profile of auth
 // @param: service: Parameter for Tencent cloud common request
 // @param: version: Parameter for Tencent cloud common request
@@ -67,7 +84,51 @@ func getTencentCloudClient(p auth.IAuthProvider) (*common.Client, error) {
 // @return: Error
 func CallTencentCloud(authProvider auth.IAuthProvider, service string, version string, action string, extraParam map[string]any) (
 	*json.RawMessage, error) {
-	client, err := getTencentCloudClient(authProvider)
+	return recordCall(authProvider, def.TENCENT_CLOUD, _recorderSecretTencentCloud, "CallTencentCloud", []any{service, version, action, extraParam},
+		func() (*json.RawMessage, error) {
+			return callTencentCloud(authProvider, "", service, version, action, extraParam)
+		})
+}
+
+// CallTencentCloudWithRegion: Send a request to Tencent cloud in the given region and parse response
+// @param: authProvider: IAuthProvider to provide profile of auth
+// @param: region: Region of the request, the region of the profile is used if empty
+// @param: service: Parameter for Tencent cloud common request
+// @param: version: Parameter for Tencent cloud common request
+// @param: action: Parameter for Tencent cloud common request
+// @param: extraParam: Extra Parameter provided to Tencent cloud
+// @return: Response data from Tencent cloud
+// @return: Error
+func CallTencentCloudWithRegion(authProvider auth.IAuthProvider, region string, service string, version string, action string,
+	extraParam map[string]any) (*json.RawMessage, error) {
+	if len(region) == 0 {
+		return CallTencentCloud(authProvider, service, version, action, extraParam)
+	}
+
+	return recordCall(authProvider, def.TENCENT_CLOUD, _recorderSecretTencentCloud, "CallTencentCloudWithRegion",
+		[]any{region, service, version, action, extraParam},
+		func() (*json.RawMessage, error) {
+			return callTencentCloud(authProvider, region, service, version, action, extraParam)
+		})
+}
+
+// GetTencentCloudRegions: Get all regions available to the account with DescribeRegions of CVM
+// @param: authProvider: IAuthProvider to provide profile of auth
+// @return: List of regions
+// @return: Error
+func GetTencentCloudRegions(authProvider auth.IAuthProvider) ([]string, error) {
+	res, err := CallTencentCloud(authProvider, "cvm", "2017-03-12", "DescribeRegions", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe regions: %w", err)
+	}
+
+	return parseRegions(res, "$.RegionSet[*].Region")
+}
+
+// callTencentCloud: Implementation of CallTencentCloud without Recorder
+func callTencentCloud(authProvider auth.IAuthProvider, region string, service string, version string, action string,
+	extraParam map[string]any) (*json.RawMessage, error) {
+	client, err := getTencentCloudClient(authProvider, region)
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +63,7 @@ func Test_createTencentCloudClient(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := createTencentCloudClient(tt.args.p)
+			got, err := createTencentCloudClient(tt.args.p, "")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("createTencentCloudClient() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -100,7 +100,7 @@ func Test_getTencentCloudClient(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := getTencentCloudClient(tt.args.p)
+			got, err := getTencentCloudClient(tt.args.p, "")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("getTencentCloudClient() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -179,3 +179,105 @@ func TestCallTencentCloud(t *testing.T) {
 		})
 	}
 }
+
+func setupSendWithRegionTencent() *gomonkey.Patches {
+	return gomonkey.ApplyMethod(&common.Client{}, "Send",
+		func(c *common.Client, request tchttp.Request, response tchttp.Response) error {
+			if request.GetAction() == "DescribeRegions" {
+				return response.(*tchttp.CommonResponse).UnmarshalJSON(
+					[]byte(`{"Response":{"RegionSet":[{"Region":"region-1"},{"Region":"region-2"}]}}`))
+			}
+			return response.(*tchttp.CommonResponse).UnmarshalJSON([]byte(`{"Response":"` + c.GetRegion() + `"}`))
+		})
+}
+
+func TestCallTencentCloudWithRegion(t *testing.T) {
+	setupEnvTencent()
+	patchSend := setupSendWithRegionTencent()
+	defer patchSend.Reset()
+	rmProfileRegion, _ := internal.JsonMarshal("mock_region")
+	rmRegion, _ := internal.JsonMarshal("region-1")
+
+	type args struct {
+		authProvider auth.IAuthProvider
+		region       string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *json.RawMessage
+		wantErr bool
+	}{
+		{
+			"Valid result in region of profile",
+			args{auth.NewAuthFileProvider(test.Test_conf_env), ""},
+			rmProfileRegion,
+			false,
+		},
+		{
+			"Valid result in given region",
+			args{auth.NewAuthFileProvider(test.Test_conf_env), "region-1"},
+			rmRegion,
+			false,
+		},
+		{
+			"Profile not defined",
+			args{auth.NewAuthFileProvider(test.Test_conf_invalid), "region-1"},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CallTencentCloudWithRegion(tt.args.authProvider, tt.args.region, "", "", "", make(map[string]any))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CallTencentCloudWithRegion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CallTencentCloudWithRegion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetTencentCloudRegions(t *testing.T) {
+	setupEnvTencent()
+	patchSend := setupSendWithRegionTencent()
+	defer patchSend.Reset()
+
+	type args struct {
+		authProvider auth.IAuthProvider
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []string
+		wantErr bool
+	}{
+		{
+			"Valid result",
+			args{auth.NewAuthFileProvider(test.Test_conf_env)},
+			[]string{"region-1", "region-2"},
+			false,
+		},
+		{
+			"failed to describe regions",
+			args{auth.NewAuthFileProvider(test.Test_conf_invalid)},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetTencentCloudRegions(tt.args.authProvider)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetTencentCloudRegions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetTencentCloudRegions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -82,6 +82,15 @@ func getTencentCOSClient(authProvider auth.IAuthProvider, bucketName string) (*c
 // @return: Response data from Tencent COS
 // @return: Error
 func CallTencentCOS(authProvider auth.IAuthProvider, bucketName string, service string, action string) (
+	*json.RawMessage, error) {
+	return recordCall(authProvider, def.TENCENT_COS, _recorderSecretTencentCloud, "CallTencentCOS", []any{bucketName, service, action},
+		func() (*json.RawMessage, error) {
+			return callTencentCOS(authProvider, bucketName, service, action)
+		})
+}
+
+// callTencentCOS: Implementation of CallTencentCOS without Recorder
+func callTencentCOS(authProvider auth.IAuthProvider, bucketName string, service string, action string) (
 	*json.RawMessage, error) {
 	client, err := getTencentCOSClient(authProvider, bucketName)
 	if err != nil {
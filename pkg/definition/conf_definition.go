@@ -14,6 +14,10 @@ const (
 	ALIYUN_OSS    CloudType = "aliyun_oss"
 	K8S           CloudType = "k8s"
 	AZURE         CloudType = "azure"
+	AWS           CloudType = "aws"
+	GCP           CloudType = "gcp"
+	OPENSTACK     CloudType = "openstack"
+	HTTP_API      CloudType = "http_api"
 )
 
 type ParamType string
@@ -32,6 +36,7 @@ const (
 	PAGE_CURPAGE_SIZE   PaginationType = 2
 	PAGE_NOPAGEINATION  PaginationType = 3
 	PAGE_MARKER         PaginationType = 4
+	PAGE_LINK_HEADER    PaginationType = 5
 )
 
 type OutputFormat string
@@ -48,6 +53,10 @@ type ConfOption struct {
 	OutputMetadata []string     `yaml:"output_metadata"`
 	OutputRiskOnly bool         `yaml:"output_risk_only"`
 	ServerHideYaml bool         `yaml:"server_hide_yaml"`
+	// Interval in seconds to discover resources of k8s server again, 0 to discover again only if resource not found
+	K8sDiscoveryInterval int `yaml:"k8s_discovery_interval"`
+	// File of waivers to suppress accepted risks, relative to the directory of conf file
+	WaiverFile string `yaml:"waiver_file"`
 }
 
 type ConfProfile map[string]string
@@ -93,11 +102,73 @@ type ConfK8sListCmd struct {
 	ListOptions map[string]any `yaml:"list_options"`
 }
 
+type ConfK8sGetCmd struct {
+	Namespace         string `yaml:"namespace"`
+	NamespaceJsonPath string `yaml:"namespace_jsonpath"` // JsonPath to extract namespace from data of the step, overriding Namespace
+	Group             string `yaml:"group"`
+	Version           string `yaml:"version"`
+	Resource          string `yaml:"resource"`
+	Subresource       string `yaml:"subresource"`
+	IgnoreNotFound    bool   `yaml:"ignore_not_found"` // Extract null instead of failing if the object is not found
+}
+
+type ConfK8sRawCmd struct {
+	Path       string         `yaml:"path"`        // Absolute path of the request, where "{id}" is replaced by id
+	ExtraParam map[string]any `yaml:"extra_param"` // Query parameters of the request
+}
+
+// AzureApi: API of Azure to send requests to
+type AzureApi string
+
+const (
+	AZURE_API_ARM   AzureApi = "arm"   // Azure Resource Manager, default value
+	AZURE_API_GRAPH AzureApi = "graph" // Microsoft Graph, for Entra ID (Azure AD)
+)
+
 type ConfAzureCmd struct {
-	Provider string `yaml:"provider"`
-	Version  string `yaml:"version"`
-	RsType   string `yaml:"rs_type"` // Resource type
-	Action   string `yaml:"action"`
+	Api           AzureApi       `yaml:"api"`
+	Provider      string         `yaml:"provider"`
+	Version       string         `yaml:"version"`
+	RsType        string         `yaml:"rs_type"` // Resource type
+	Action        string         `yaml:"action"`
+	ResourceGroup string         `yaml:"resource_group"`
+	ExtraParam    map[string]any `yaml:"extra_param"` // Query parameters such as "$filter"
+
+	// Path of child resources under each resource listed, such as "blobServices/default/containers"
+	ChildPath string `yaml:"child_path"`
+	// Version of API of child resources, the same as Version if empty
+	ChildVersion string `yaml:"child_version"`
+}
+
+type ConfAWSCmd struct {
+	Service    string         `yaml:"service"`
+	Version    string         `yaml:"version"`
+	Action     string         `yaml:"action"`
+	ExtraParam map[string]any `yaml:"extra_param"`
+}
+
+type ConfGCPCmd struct {
+	Service    string         `yaml:"service"`
+	Version    string         `yaml:"version"`
+	Method     string         `yaml:"method"`
+	Path       string         `yaml:"path"`
+	ExtraParam map[string]any `yaml:"extra_param"`
+}
+
+type ConfOpenStackCmd struct {
+	Service    string         `yaml:"service"` // Type of service in catalog
+	Path       string         `yaml:"path"`
+	ExtraParam map[string]any `yaml:"extra_param"`
+}
+
+type ConfHttpCmd struct {
+	Method string            `yaml:"method"`
+	URL    string            `yaml:"url"`    // Template of url, relative to base url in profile if not starting with "http"
+	Header map[string]string `yaml:"header"` // Templates of header values
+	Query  map[string]string `yaml:"query"`  // Templates of query values
+	Body   string            `yaml:"body"`   // Template of request body
+	// Prefix of keys in profile for base url and auth, "HTTP_API" by default
+	ProfilePrefix string `yaml:"profile_prefix"`
 }
 
 type ConfListCmd struct {
@@ -107,6 +178,10 @@ type ConfListCmd struct {
 	AliyunOSS    ConfAliyunOSSCmd    `yaml:"aliyun_oss"`
 	K8sList      ConfK8sListCmd      `yaml:"k8s_list"`
 	Azure        ConfAzureCmd        `yaml:"azure"`
+	AWS          ConfAWSCmd          `yaml:"aws"`
+	GCP          ConfGCPCmd          `yaml:"gcp"`
+	OpenStack    ConfOpenStackCmd    `yaml:"openstack"`
+	HttpApi      ConfHttpCmd         `yaml:"http_api"`
 
 	DataListJsonPath    string `yaml:"data_list_json_path"`
 	ConvertObjectToList bool   `yaml:"convert_object_to_list"`
@@ -130,10 +205,28 @@ type ConfConstraintK8s struct {
 	Version string `yaml:"version"`
 }
 
+type ConfConstraintAzureProvider struct {
+	Namespace  string `yaml:"namespace"`   // Resource provider required to be registered, such as "Microsoft.Storage"
+	RsType     string `yaml:"rs_type"`     // Resource type of provider required to be available, optional
+	ApiVersion string `yaml:"api_version"` // Version of api required to be available for rs_type, optional
+}
+
+type ConfConstraintAzure struct {
+	Providers []ConfConstraintAzureProvider `yaml:"providers"`
+}
+
 type ConfConstraint struct {
-	ConstraintK8s ConfConstraintK8s `yaml:"k8s"`
+	ConstraintK8s   ConfConstraintK8s   `yaml:"k8s"`
+	ConstraintAzure ConfConstraintAzure `yaml:"azure"`
+	ProfileKeys     []string            `yaml:"profile_keys"` // Keys required to be set in the profile
+	Regions         []string            `yaml:"regions"`      // Allowlist of regions
 }
 
+const (
+	REGION_ALL       = "all" // Discover all regions available to the account
+	SUBSCRIPTION_ALL = "all" // Discover all subscriptions visible to the service principal of Azure
+)
+
 type ConfListor struct {
 	Id         int            `yaml:"id"`
 	CloudType  CloudType      `yaml:"cloud_type"`
@@ -141,6 +234,12 @@ type ConfListor struct {
 	ListCmd    ConfListCmd    `yaml:"list_cmd"`
 	Paginator  ConfPaginator  `yaml:"paginator"`
 	Constraint ConfConstraint `yaml:"constraint"`
+	Regions    []string       `yaml:"regions"` // Regions to list resources in, or REGION_ALL
+
+	// Subscriptions of Azure to list resources in, or SUBSCRIPTION_ALL
+	Subscriptions []string `yaml:"subscriptions"`
+	// Management group of Azure whose subscriptions to list resources in
+	ManagementGroup string `yaml:"management_group"`
 }
 
 type ConfExtractCmd struct {
@@ -160,25 +259,46 @@ type ConfExtractCmd struct {
 	Aliyun          ConfAliyunCloudCmd  `yaml:"aliyun"`
 	AliyunOSS       ConfAliyunOSSCmd    `yaml:"aliyun_oss"`
 	Azure           ConfAzureCmd        `yaml:"azure"`
-	// Extract command for k8s is not required at this time
+	AWS             ConfAWSCmd          `yaml:"aws"`
+	GCP             ConfGCPCmd          `yaml:"gcp"`
+	OpenStack       ConfOpenStackCmd    `yaml:"openstack"`
+	HttpApi         ConfHttpCmd         `yaml:"http_api"`
+	K8sGet          ConfK8sGetCmd       `yaml:"k8s_get"`
+	K8sRaw          ConfK8sRawCmd       `yaml:"k8s_raw"`
 
 	// Way to extract prop using a list of commands as chain,
-	// need to be checked before decommenting
-	//
-	// CmdChain []ConfExtractCmd `yaml:"cmd_chain"`
+	// each of which uses the prop extracted by the previous one as its raw data
+	CmdChain []ConfExtractCmd `yaml:"cmd_chain"`
 }
 
 type ConfValidator struct {
 	ValidateSchema   string            `yaml:"validate_schema"`
-	DynValidateValue map[string]string `yaml:"dyn_validate_value"` // Modify value in validate_schema
+	ValidateCel      string            `yaml:"validate_cel"`       // Expression of CEL used instead of validate_schema
+	ValidateRego     string            `yaml:"validate_rego"`      // Module of Rego used instead of validate_schema
+	DynValidateValue map[string]string `yaml:"dyn_validate_value"` // Modify value in validate_schema, validate_cel or validate_rego
 	ValueJsonPath    string            `yaml:"value_jsonpath"`     // JsonPath to extract the actual value to be displayed
+	Reason           bool              `yaml:"reason"`             // Output the branches of validate_schema matched
+}
+
+type ConfJoinListor struct {
+	Listor             int    `yaml:"listor"`               // Id of secondary Listor
+	KeyJsonPath        string `yaml:"key_jsonpath"`         // JsonPath to extract key from item of secondary Listor
+	PrimaryKeyJsonPath string `yaml:"primary_key_jsonpath"` // JsonPath to extract key from item of primary Listor
+	As                 string `yaml:"as"`                   // Key added to primary item with the list of matched items
+}
+
+type ConfAggregate struct {
+	Enabled  bool   `yaml:"enabled"`  // Validate props of all items as a single document
+	JsonPath string `yaml:"jsonpath"` // JsonPath to project the list of props of all items
 }
 
 type ConfChecker struct {
-	CloudType  CloudType      `yaml:"cloud_type"`
-	Listor     []int          `yaml:"listor"`
-	ExtractCmd ConfExtractCmd `yaml:"extract_cmd"`
-	Validator  ConfValidator  `yaml:"validator"`
+	CloudType  CloudType        `yaml:"cloud_type"`
+	Listor     []int            `yaml:"listor"`
+	Join       []ConfJoinListor `yaml:"join"` // Secondary Listors joined to each item of Listor
+	ExtractCmd ConfExtractCmd   `yaml:"extract_cmd"`
+	Aggregate  ConfAggregate    `yaml:"aggregate"`
+	Validator  ConfValidator    `yaml:"validator"`
 }
 
 type ConfBaseline struct {
@@ -187,6 +307,21 @@ type ConfBaseline struct {
 	Checker  []ConfChecker     `yaml:"checker"`
 }
 
+type ConfWaiver struct {
+	Tag           []string          `yaml:"tag"`           // Tags of Baseline, matching any of them
+	Metadata      map[string]string `yaml:"metadata"`      // Metadata of Baseline, matching all of them
+	CloudType     CloudType         `yaml:"cloud_type"`    // Cloud type of result
+	ResourceId    string            `yaml:"resource_id"`   // Id of resource
+	ResourceName  string            `yaml:"resource_name"` // Regexp of name of resource
+	Justification string            `yaml:"justification"`
+	Owner         string            `yaml:"owner"`
+	Expiry        string            `yaml:"expiry"` // Date of expiry in the format of "2006-01-02", or time in RFC3339
+}
+
+type ConfWaiverFile struct {
+	Waiver []ConfWaiver `yaml:"waiver"`
+}
+
 type ConfFile struct {
 	Option   ConfOption     `yaml:"option"`
 	Profile  ConfProfile    `yaml:"profile"`
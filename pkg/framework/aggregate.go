@@ -0,0 +1,120 @@
+// Aggregation of props of all items in a Checker to be validated as a single document
+
+package framework
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/s3studio/cloud-bench-checker/internal"
+	"github.com/s3studio/cloud-bench-checker/pkg/auth"
+	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
+)
+
+// getProfileNameProvider: Get provider of the name of profile that the raw data of Checker is collected with
+//
+// IDataProvider recording the names of profile, e.g. SnapshotDataProvider, is preferred,
+// as its raw data is not collected with the profile of the current run.
+// @param: authProvider: IAuthProvider of the current run
+// @param: dataProvider: IDataProvider to provide raw data
+// @return: Provider of the name of profile, nil if not available
+func getProfileNameProvider(authProvider auth.IAuthProvider, dataProvider IDataProvider) auth.IProfileNameProvider {
+	if nameProvider, ok := dataProvider.(auth.IProfileNameProvider); ok {
+		return nameProvider
+	}
+
+	nameProvider, _ := authProvider.(auth.IProfileNameProvider)
+	return nameProvider
+}
+
+// getAggregateId: Get id of the aggregated prop, which stands for the account or profile
+//
+// IdConst of ExtractCmd is used if defined,
+// otherwise the id is derived from the name of profile as "{cloud_type}:{profile}"
+// @param: conf: Definition of Checker
+// @param: nameProvider: Provider of the name of profile got by getProfileNameProvider
+// @return: Id of the aggregated prop
+// @return: Error
+func getAggregateId(conf *def.ConfChecker, nameProvider auth.IProfileNameProvider) (string, error) {
+	if len(conf.ExtractCmd.IdConst) > 0 {
+		return conf.ExtractCmd.IdConst, nil
+	}
+
+	if nameProvider == nil {
+		return "", errors.New("missing id_const for aggregate, name of profile is not available")
+	}
+
+	profileName, err := nameProvider.GetProfileName(conf.CloudType)
+	if err != nil {
+		return "", fmt.Errorf("failed to get name of profile for id of aggregate: %w", err)
+	}
+
+	return fmt.Sprintf("%s:%s", conf.CloudType, profileName), nil
+}
+
+// isAggregateBypassed: Check whether aggregate is bypassed because the profile of the cloud is not defined
+//
+// Listors are bypassed without any data if the profile is not defined,
+// and an aggregate of no item should not be validated either.
+// The profile is the one that the raw data is collected with, e.g. the one recorded in the snapshot,
+// and raw data is still aggregated if provided otherwise.
+// @param: conf: Definition of Checker
+// @param: nameProvider: Provider of the name of profile got by getProfileNameProvider
+// @param: fnGetData: Function to get raw data of a Listor
+// @return: Whether aggregate is bypassed
+// @return: Error
+func isAggregateBypassed(conf *def.ConfChecker, nameProvider auth.IProfileNameProvider, fnGetData func(listorId int) ([]*json.RawMessage, error)) (
+	bool, error) {
+	if nameProvider == nil {
+		return false, nil
+	}
+	_, err := nameProvider.GetProfileName(conf.CloudType)
+	pndError := auth.ProfileNotDefinedError{}
+	if !errors.As(err, &pndError) {
+		return false, nil
+	}
+
+	for _, listorId := range conf.Listor {
+		rawData, err := fnGetData(listorId)
+		if err != nil {
+			return false, err
+		}
+		if len(rawData) > 0 {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// aggregateProp: Combine props of all items into a json array as the prop of a single CheckerProp
+//
+// The array is projected with JsonPath of ConfAggregate if defined.
+// An empty array is used if there is no item, so that a rule such as
+// "at least one item must exist" can still be validated.
+// @param: conf: Definition of aggregate
+// @param: id: Id of the aggregated prop
+// @param: propList: Props of all items
+// @return: Aggregated prop
+// @return: Error
+func aggregateProp(conf *def.ConfAggregate, id string, propList CheckerPropList) (*CheckerProp, error) {
+	propArray := make([]*json.RawMessage, 0, len(propList))
+	for _, eachProp := range propList {
+		propArray = append(propArray, eachProp.Prop)
+	}
+
+	prop, err := internal.JsonMarshal(propArray)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal props to aggregate: %w", err)
+	}
+
+	if len(conf.JsonPath) > 0 {
+		prop, err = internal.ParseJsonPath(prop, conf.JsonPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse JsonPath of aggregate: %w", err)
+		}
+	}
+
+	return &CheckerProp{Id: id, Prop: prop}, nil
+}
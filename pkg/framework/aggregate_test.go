@@ -0,0 +1,269 @@
+// Aggregation of props of all items in a Checker to be validated as a single document
+
+package framework
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/s3studio/cloud-bench-checker/internal"
+	"github.com/s3studio/cloud-bench-checker/pkg/auth"
+	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
+	"github.com/s3studio/cloud-bench-checker/test"
+)
+
+func Test_getProfileNameProvider(t *testing.T) {
+	authProvider := auth.NewAuthFileProvider(test.Test_conf_file)
+	snapshotDp := &SnapshotDataProvider{}
+
+	type args struct {
+		authProvider auth.IAuthProvider
+		dataProvider IDataProvider
+	}
+	tests := []struct {
+		name string
+		args args
+		want auth.IProfileNameProvider
+	}{
+		{
+			"Valid result of snapshot",
+			args{authProvider, snapshotDp},
+			snapshotDp,
+		},
+		{
+			"Valid result of IAuthProvider",
+			args{authProvider, &SyncMapDataProvider{}},
+			authProvider,
+		},
+		{
+			"name of profile is not available",
+			args{&test.MockKeyNotSetAuthProvider{}, nil},
+			nil,
+		},
+		{
+			"nil pointor of providers",
+			args{nil, nil},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getProfileNameProvider(tt.args.authProvider, tt.args.dataProvider); got != tt.want {
+				t.Errorf("getProfileNameProvider() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getAggregateId(t *testing.T) {
+	type args struct {
+		conf         *def.ConfChecker
+		nameProvider auth.IProfileNameProvider
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			"Valid result with IdConst",
+			args{
+				&def.ConfChecker{CloudType: def.TENCENT_CLOUD, ExtractCmd: def.ConfExtractCmd{IdConst: "account"}},
+				nil,
+			},
+			"account",
+			false,
+		},
+		{
+			"Valid result with name of profile",
+			args{
+				&def.ConfChecker{CloudType: def.TENCENT_CLOUD},
+				auth.NewAuthFileProvider(test.Test_conf_file),
+			},
+			"tencent_cloud:file",
+			false,
+		},
+		{
+			"Valid result with name of profile in snapshot",
+			args{
+				&def.ConfChecker{CloudType: def.TENCENT_CLOUD},
+				&SnapshotDataProvider{Manifest: SnapshotManifest{Profile: map[string]string{"tencent_cloud": "snapshot"}}},
+			},
+			"tencent_cloud:snapshot",
+			false,
+		},
+		{
+			"name of profile is not available",
+			args{
+				&def.ConfChecker{CloudType: def.TENCENT_CLOUD},
+				nil,
+			},
+			"",
+			true,
+		},
+		{
+			"failed to get name of profile",
+			args{
+				&def.ConfChecker{CloudType: def.ALIYUN_CLOUD},
+				auth.NewAuthFileProvider(test.Test_conf_file),
+			},
+			"",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getAggregateId(tt.args.conf, tt.args.nameProvider)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getAggregateId() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("getAggregateId() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_isAggregateBypassed(t *testing.T) {
+	rm, _ := internal.JsonMarshal("mock")
+	fnGetData := func(listorId int) ([]*json.RawMessage, error) {
+		switch listorId {
+		case 1:
+			return nil, nil
+		case 2:
+			return []*json.RawMessage{rm}, nil
+		default:
+			return nil, errors.New("mock error")
+		}
+	}
+
+	snapshotDp := &SnapshotDataProvider{Manifest: SnapshotManifest{Profile: map[string]string{"aliyun": "snapshot"}}}
+
+	type args struct {
+		conf         *def.ConfChecker
+		nameProvider auth.IProfileNameProvider
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			"Bypassed without profile",
+			args{&def.ConfChecker{CloudType: def.ALIYUN_CLOUD, Listor: []int{1}}, auth.NewAuthFileProvider(test.Test_conf_file)},
+			true,
+			false,
+		},
+		{
+			"Not bypassed with profile",
+			args{&def.ConfChecker{CloudType: def.TENCENT_CLOUD, Listor: []int{1}}, auth.NewAuthFileProvider(test.Test_conf_file)},
+			false,
+			false,
+		},
+		{
+			"Not bypassed with data provided without profile",
+			args{&def.ConfChecker{CloudType: def.ALIYUN_CLOUD, Listor: []int{1, 2}}, auth.NewAuthFileProvider(test.Test_conf_file)},
+			false,
+			false,
+		},
+		{
+			"Bypassed without profile in snapshot",
+			args{&def.ConfChecker{CloudType: def.TENCENT_CLOUD, Listor: []int{1}}, snapshotDp},
+			true,
+			false,
+		},
+		{
+			"Not bypassed with profile in snapshot",
+			args{&def.ConfChecker{CloudType: def.ALIYUN_CLOUD, Listor: []int{1}}, snapshotDp},
+			false,
+			false,
+		},
+		{
+			"Not bypassed with nil pointor of IProfileNameProvider",
+			args{&def.ConfChecker{CloudType: def.ALIYUN_CLOUD, Listor: []int{1}}, nil},
+			false,
+			false,
+		},
+		{
+			"failed to get raw data",
+			args{&def.ConfChecker{CloudType: def.ALIYUN_CLOUD, Listor: []int{3}}, auth.NewAuthFileProvider(test.Test_conf_file)},
+			false,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := isAggregateBypassed(tt.args.conf, tt.args.nameProvider, fnGetData)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("isAggregateBypassed() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("isAggregateBypassed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_aggregateProp(t *testing.T) {
+	rm1, _ := internal.JsonMarshal(map[string]any{"enabled": true})
+	rm2, _ := internal.JsonMarshal(map[string]any{"enabled": false})
+	propList := CheckerPropList{{Id: "1", Prop: rm1}, {Id: "2", Prop: rm2}}
+	rmAll, _ := internal.JsonMarshal([]*json.RawMessage{rm1, rm2})
+	rmProjected, _ := internal.JsonMarshal([]bool{true, false})
+	rmEmpty, _ := internal.JsonMarshal([]any{})
+
+	type args struct {
+		conf     *def.ConfAggregate
+		id       string
+		propList CheckerPropList
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *CheckerProp
+		wantErr bool
+	}{
+		{
+			"Valid result",
+			args{&def.ConfAggregate{Enabled: true}, "account", propList},
+			&CheckerProp{Id: "account", Prop: rmAll},
+			false,
+		},
+		{
+			"Valid result with JsonPath",
+			args{&def.ConfAggregate{Enabled: true, JsonPath: "$[*].enabled"}, "account", propList},
+			&CheckerProp{Id: "account", Prop: rmProjected},
+			false,
+		},
+		{
+			"Valid result without item",
+			args{&def.ConfAggregate{Enabled: true}, "account", nil},
+			&CheckerProp{Id: "account", Prop: rmEmpty},
+			false,
+		},
+		{
+			"failed to parse JsonPath",
+			args{&def.ConfAggregate{Enabled: true, JsonPath: "invalid"}, "account", propList},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := aggregateProp(tt.args.conf, tt.args.id, tt.args.propList)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("aggregateProp() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("aggregateProp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
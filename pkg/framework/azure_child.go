@@ -0,0 +1,63 @@
+// Listing of nested child resources of Azure
+
+package framework
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/s3studio/cloud-bench-checker/internal"
+	"github.com/s3studio/cloud-bench-checker/pkg/auth"
+	"github.com/s3studio/cloud-bench-checker/pkg/connector"
+	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
+)
+
+// listAzureChildren: List child resources under each parent resource of Azure
+//
+// The endpoint of child resources is "{id of parent}/{ChildPath}",
+// and all pages of child resources are listed following nextLink.
+// @param: authProvider: IAuthProvider to provide profile of auth
+// @param: conf: Command of Azure with ChildPath defined
+// @param: parentList: List of parent resources, each must have key of "id"
+// @return: List of child resources of all parents
+// @return: Error
+func listAzureChildren(authProvider auth.IAuthProvider, conf *def.ConfAzureCmd, parentList []*json.RawMessage) (
+	[]*json.RawMessage, error) {
+	version := conf.ChildVersion
+	if len(version) == 0 {
+		version = conf.Version
+	}
+
+	childList := make([]*json.RawMessage, 0, len(parentList))
+	for _, parent := range parentList {
+		parentId, err := internal.ParseJsonPathStr(parent, "$.id")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get id of parent resource: %w", err)
+		}
+		if len(parentId) == 0 {
+			return nil, errors.New("id of parent resource is empty")
+		}
+
+		endpoint := fmt.Sprintf("%s/%s", parentId, conf.ChildPath)
+		for len(endpoint) > 0 {
+			res, err := connector.CallAzureWithEndpoint(authProvider, version, endpoint, "")
+			if err != nil {
+				return nil, fmt.Errorf("failed to list child resources of %s: %w", parentId, err)
+			}
+
+			var page struct {
+				Value    []*json.RawMessage `json:"value"`
+				NextLink string             `json:"nextLink"`
+			}
+			if err := internal.JsonUnmarshal(*res, &page); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal child resources of %s: %w", parentId, err)
+			}
+
+			childList = append(childList, page.Value...)
+			endpoint = page.NextLink
+		}
+	}
+
+	return childList, nil
+}
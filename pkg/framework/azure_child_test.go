@@ -0,0 +1,96 @@
+// Listing of nested child resources of Azure
+
+package framework
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/s3studio/cloud-bench-checker/internal"
+	"github.com/s3studio/cloud-bench-checker/pkg/auth"
+	"github.com/s3studio/cloud-bench-checker/pkg/connector"
+	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
+
+	"github.com/agiledragon/gomonkey/v2"
+)
+
+func Test_listAzureChildren(t *testing.T) {
+	patchCallAzure := gomonkey.ApplyFunc(connector.CallAzureWithEndpoint,
+		func(authProvider auth.IAuthProvider, version string, endpoint string, action string) (*json.RawMessage, error) {
+			switch endpoint {
+			case "/parent-1/child":
+				return internal.JsonMarshal(map[string]any{"value": []any{map[string]any{"id": version}}, "nextLink": "next"})
+			case "next":
+				return internal.JsonMarshal(map[string]any{"value": []any{map[string]any{"id": "child-2"}}})
+			case "/parent-2/child":
+				return internal.JsonMarshal(map[string]any{"value": []any{}})
+			default:
+				return nil, errors.New("mock error")
+			}
+		})
+	defer patchCallAzure.Reset()
+	fnParent := func(id string) *json.RawMessage {
+		rm, _ := internal.JsonMarshal(map[string]any{"id": id})
+		return rm
+	}
+	rmVersion, _ := internal.JsonMarshal(map[string]any{"id": "mock_version"})
+	rmChildVersion, _ := internal.JsonMarshal(map[string]any{"id": "mock_child_version"})
+	rmChild2, _ := internal.JsonMarshal(map[string]any{"id": "child-2"})
+	rmNoId, _ := internal.JsonMarshal(map[string]any{"name": "mock"})
+
+	type args struct {
+		conf       *def.ConfAzureCmd
+		parentList []*json.RawMessage
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []*json.RawMessage
+		wantErr bool
+	}{
+		{
+			"Valid result",
+			args{
+				&def.ConfAzureCmd{Version: "mock_version", ChildPath: "child"},
+				[]*json.RawMessage{fnParent("/parent-1"), fnParent("/parent-2")},
+			},
+			[]*json.RawMessage{rmVersion, rmChild2},
+			false,
+		},
+		{
+			"Valid result of child version",
+			args{
+				&def.ConfAzureCmd{Version: "mock_version", ChildPath: "child", ChildVersion: "mock_child_version"},
+				[]*json.RawMessage{fnParent("/parent-1")},
+			},
+			[]*json.RawMessage{rmChildVersion, rmChild2},
+			false,
+		},
+		{
+			"id of parent resource is empty",
+			args{&def.ConfAzureCmd{ChildPath: "child"}, []*json.RawMessage{rmNoId}},
+			nil,
+			true,
+		},
+		{
+			"failed to list child resources",
+			args{&def.ConfAzureCmd{ChildPath: "child"}, []*json.RawMessage{fnParent("/invalid")}},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := listAzureChildren(nil, tt.args.conf, tt.args.parentList)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("listAzureChildren() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("listAzureChildren() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -69,7 +69,7 @@ func (b *Baseline) GetListorId() []int {
 	listorIds := make([]int, 0, len(b.conf.Checker))
 
 	for _, checker := range b.conf.Checker {
-		for _, idAdd := range checker.Listor {
+		for _, idAdd := range GetCheckerListorId(&checker) {
 			bAdd := true
 			for _, idExist := range listorIds {
 				if idAdd == idExist {
@@ -102,8 +102,9 @@ func (b *Baseline) GetProp(opts ...GetPropOption) BaselinePropList {
 		go func(target *CheckerPropList) {
 			singleCheckerProp, err := checker.GetProp(opts...)
 			if err != nil {
-				// Print error and skip the current checker
+				// Print error and keep it to be reported as the result of the current checker
 				glog().Println(err)
+				*target = append(*target, &CheckerProp{Error: err.Error()})
 			} else {
 				*target = append(*target, singleCheckerProp...)
 			}
@@ -122,20 +123,23 @@ func (b *Baseline) GetProp(opts ...GetPropOption) BaselinePropList {
 // NOTE: The length of the list of data must be the same as the length of checkers,
 // as each item in the list is sent to a checker in order
 // @param: data: List of properties to be validated
+// @param: opts: Options to pass to checker.Validate
 // @return: List of validation results
 // @return: Error
-func (b *Baseline) Validate(data BaselinePropList) ([]*ValidateResult, error) {
+func (b *Baseline) Validate(data BaselinePropList, opts ...ValidateOption) ([]*ValidateResult, error) {
 	if len(data) != len(b.checker) {
 		return nil, errors.New("size mismatch between props and checkers, please review the given data")
 	}
 
 	var validateResult []*ValidateResult
 	for i, checker := range b.checker {
-		singleResult, err := checker.Validate(data[i])
+		singleResult, err := checker.Validate(data[i], opts...)
 		if err != nil {
-			// Print error and skip the current checker
+			// Print error and report it as the result of the current checker
 			glog().Println(err)
-			continue
+			errResult := ValidateResult{CloudType: checker.conf.CloudType}
+			errResult.setError(err)
+			singleResult = []*ValidateResult{&errResult}
 		}
 
 		validateResult = append(validateResult, singleResult...)
@@ -144,6 +148,12 @@ func (b *Baseline) Validate(data BaselinePropList) ([]*ValidateResult, error) {
 	return validateResult, nil
 }
 
+// GetTag: Get the tags defined in Baseline.conf
+// @return: tags
+func (b *Baseline) GetTag() []string {
+	return b.conf.Tag
+}
+
 // GetMetadata: Get the metadata defined in Baseline.conf
 // @return: metadata
 func (b *Baseline) GetMetadata() *map[string]string {
@@ -168,7 +178,7 @@ func (b *Baseline) GetMetadata() *map[string]string {
 //     while the data can be shared and processed between the 2 servers.
 //
 // @param: hashType: Method of hash
-// @param: listorHashList: Prepared hash of the Listors in the Checker
+// @param: listorHashList: Prepared hash of the Listors in the Checker, in the order of GetCheckerListorId
 // @return: Hash value
 // @return: Error
 func (b *Baseline) GetHash(hashType crypto.Hash, listorHashList [][]*[]byte) ([]byte, error) {
@@ -177,7 +187,7 @@ func (b *Baseline) GetHash(hashType crypto.Hash, listorHashList [][]*[]byte) ([]
 	}
 
 	for i, c := range b.conf.Checker {
-		if len(listorHashList[i]) != len(c.Listor) {
+		if len(listorHashList[i]) != len(GetCheckerListorId(&c)) {
 			return nil, fmt.Errorf("size mismatch between Checker and given hash list of #%d", i)
 		}
 	}
@@ -208,10 +218,39 @@ func (b *Baseline) GetHash(hashType crypto.Hash, listorHashList [][]*[]byte) ([]
 
 		delete(objItem, "Listor")
 		objItem["ListorHash"] = listorHashList[i]
+		if objJoin, ok := objItem["Join"].([]any); ok {
+			// Id of secondary Listor is also replaced by the hash in ListorHash
+			for _, join := range objJoin {
+				if objJoinItem, ok := join.(map[string]any); ok {
+					delete(objJoinItem, "Listor")
+				}
+			}
+		}
 
 		// Also remove validation info
 		delete(objItem, "Validator")
+
+		// Keep hash of Baseline unchanged if the keys added later are not used
+		deleteEmptyKeys(objItem, "Join", "Aggregate")
+		if objExtractCmd, ok := objItem["ExtractCmd"].(map[string]any); ok {
+			deleteEmptyExtractCmdKeys(objExtractCmd)
+		}
 	}
 
 	return CalcHash(hashType, objForHash)
 }
+
+// deleteEmptyExtractCmdKeys: Remove keys of ConfExtractCmd added later if not used, including those in CmdChain
+func deleteEmptyExtractCmdKeys(objExtractCmd map[string]any) {
+	deleteEmptyKeys(objExtractCmd, "AWS", "GCP", "OpenStack", "HttpApi", "K8sGet", "K8sRaw", "CmdChain")
+	if objAzure, ok := objExtractCmd["Azure"].(map[string]any); ok {
+		deleteEmptyKeys(objAzure, "Api", "ResourceGroup", "ExtraParam", "ChildPath", "ChildVersion")
+	}
+	if objChain, ok := objExtractCmd["CmdChain"].([]any); ok {
+		for _, step := range objChain {
+			if objStep, ok := step.(map[string]any); ok {
+				deleteEmptyExtractCmdKeys(objStep)
+			}
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/agiledragon/gomonkey/v2"
+	"github.com/s3studio/cloud-bench-checker/internal"
 	"github.com/s3studio/cloud-bench-checker/pkg/auth"
 	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
 )
@@ -38,7 +39,7 @@ func TestNewBaseline(t *testing.T) {
 			&Baseline{
 				conf: &validConf,
 				checker: []*Checker{
-					{conf: &validConf.Checker[0]},
+					{conf: &validConf.Checker[0], validators: internal.NewLruCache[*checkerValidator](MAX_CACHED_VALIDATORS)},
 				},
 			},
 		},
@@ -122,6 +123,16 @@ func TestBaseline_GetListorId(t *testing.T) {
 			mockBaseline,
 			[]int{1},
 		},
+		{
+			"Valid result with join",
+			NewBaseline(&def.ConfBaseline{
+				Checker: []def.ConfChecker{
+					{Listor: []int{1}, Join: []def.ConfJoinListor{{Listor: 2}}},
+					{Listor: []int{2, 3}},
+				},
+			}, nil, nil),
+			[]int{1, 2, 3},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -142,7 +153,7 @@ func setupChecker() func() {
 			return CheckerPropList{&mockValidCheckProp}, nil
 		})
 	patchValidate := gomonkey.ApplyMethodFunc(&Checker{}, "Validate",
-		func(data CheckerPropList) ([]*ValidateResult, error) {
+		func(data CheckerPropList, _ ...ValidateOption) ([]*ValidateResult, error) {
 			if data == nil {
 				return nil, errors.New("mock invalid Checker.Validate")
 			}
@@ -170,7 +181,7 @@ func TestBaseline_GetProp(t *testing.T) {
 			mockBaseline,
 			BaselinePropList{
 				{&mockValidCheckProp},
-				nil,
+				{&CheckerProp{Error: "mock invalid Checker.GetProp"}},
 			},
 		},
 	}
@@ -202,7 +213,15 @@ func TestBaseline_Validate(t *testing.T) {
 			"Valid result",
 			mockBaseline,
 			args{prop},
-			[]*ValidateResult{&mockValidResult},
+			[]*ValidateResult{&mockValidResult, &mockValidResult},
+			false,
+		},
+		{
+			"Valid result with error of checker",
+			mockBaseline,
+			args{BaselinePropList{{&mockValidCheckProp}, nil}},
+			[]*ValidateResult{&mockValidResult,
+				{CloudType: "invalid", Outcome: OUTCOME_ERROR, Error: "mock invalid Checker.Validate"}},
 			false,
 		},
 		{
@@ -276,6 +295,20 @@ func TestBaseline_GetHash(t *testing.T) {
 			"d00701fd7e5e81a594329de7bf063a60e8dc803f95a30b3afc089b1b14338589", // hardcode value
 			false,
 		},
+		{
+			"Valid result with join",
+			NewBaseline(&def.ConfBaseline{
+				Checker: []def.ConfChecker{{Listor: []int{1}, Join: []def.ConfJoinListor{{Listor: 2, As: "mock"}}}},
+			}, nil, nil),
+			args{
+				crypto.SHA256,
+				[][]*[]byte{
+					{&mockHash, &mockHash},
+				},
+			},
+			"2d60de8fc4e60cc54c2221075f2a6af07b4e5c04d6291e940680b40245d11dae", // hardcode value, independent of the id of secondary Listor
+			false,
+		},
 		{
 			"size mismatch between Checker and given hash list",
 			NewBaseline(&def.ConfBaseline{
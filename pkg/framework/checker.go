@@ -3,10 +3,12 @@
 package framework
 
 import (
+	"crypto"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"maps"
+	"net/url"
 	"reflect"
 	"strings"
 
@@ -14,8 +16,6 @@ import (
 	"github.com/s3studio/cloud-bench-checker/pkg/auth"
 	"github.com/s3studio/cloud-bench-checker/pkg/connector"
 	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
-
-	"github.com/xeipuuv/gojsonschema"
 )
 
 // Checker: Used to extract properties and validate them
@@ -29,10 +29,8 @@ import (
 type Checker struct {
 	// Definition of Checker
 	conf *def.ConfChecker
-	// Instance of validator of JsonSchema
-	validator *gojsonschema.Schema
-	// Flag to avoid repeating the same errors
-	validatorLoadFailed bool
+	// Cache of validators created, with key of the values of DynValidateValue
+	validators *internal.LruCache[*checkerValidator]
 	// IAuthProvider to provide profile of auth
 	authProvider auth.IAuthProvider
 	// IDataProvider to provide raw data
@@ -44,7 +42,12 @@ type Checker struct {
 // @param: authProvider: IAuthProvider to provide profile of auth
 // @param: dataProvider: IDataProvider to provide raw data
 func NewChecker(conf *def.ConfChecker, authProvider auth.IAuthProvider, dataProvider IDataProvider) *Checker {
-	return &Checker{conf: conf, authProvider: authProvider, dataProvider: dataProvider}
+	return &Checker{
+		conf:         conf,
+		validators:   internal.NewLruCache[*checkerValidator](MAX_CACHED_VALIDATORS),
+		authProvider: authProvider,
+		dataProvider: dataProvider,
+	}
 }
 
 // SetAuthProvider: Set new authProvider
@@ -65,6 +68,16 @@ type CheckerProp struct {
 	Id string
 	// Human readable name of the resource
 	Name string
+	// Region of the resource, empty if listed in the region of profile
+	Region string `json:",omitempty"`
+	// Subscription of Azure of the resource, empty if listed in the subscription of profile
+	Subscription string `json:",omitempty"`
+	// Context of k8s of the resource, empty if listed in the current context of kubeconfig
+	K8sContext string `json:",omitempty"`
+	// Description of constraint not satisfied if the Listor or its scope is not applicable
+	NotApplicable string `json:",omitempty"`
+	// Description of error if failed to get properties
+	Error string `json:",omitempty"`
 	// Properties extracted
 	Prop *json.RawMessage
 }
@@ -105,6 +118,12 @@ func SetDataProviderOpt(val IDataProvider) GetPropOption {
 type CheckerPropList []*CheckerProp
 
 // GetProp: Extract Id, Name (if required) and properties of the raw data
+//
+// If aggregate is enabled, props of all items are combined into a single CheckerProp.
+// Items standing for a Listor or a scope not applicable are kept as CheckerProp with NotApplicable set,
+// and excluded from aggregate.
+// Items standing for a scope failed to list data in are kept as CheckerProp with Error set,
+// and the aggregate is not done then, as it would be incomplete.
 // @param: opts: Additional options
 // @return: List of properties extracted from raw data
 // @return: Error
@@ -125,28 +144,92 @@ func (c *Checker) GetProp(opts ...GetPropOption) (CheckerPropList, error) {
 		dataProvider = c.dataProvider
 	}
 
-	var checkerPropList CheckerPropList
-	for _, listorId := range c.conf.Listor {
-		var eachListorData []*json.RawMessage
-		var err error
+	fnGetData := func(listorId int) ([]*json.RawMessage, error) {
 		if dataProvider == nil {
 			return nil, errors.New("failed to get raw data, provider is nil")
 		}
 		if cloudType, err := dataProvider.GetCloudTypeByListorId(listorId); err != nil {
 			return nil, fmt.Errorf("failed to get cloud type from provider: %w", err)
 		} else if cloudType == "" {
-			// No data of Listor in the cloud, bypass to the next Listor
-			continue
+			// No data of Listor in the cloud
+			return nil, nil
 		} else if cloudType != string(c.conf.CloudType) {
 			return nil, fmt.Errorf("cloud type of data \"%s\" mismatch cloud type of Checker \"%s\"",
 				cloudType, c.conf.CloudType)
 		}
-		if eachListorData, err = dataProvider.GetRawDataByListorId(listorId); err != nil {
+
+		rawData, err := dataProvider.GetRawDataByListorId(listorId)
+		if err != nil {
 			return nil, fmt.Errorf("failed to get raw data from provider: %w", err)
 		}
+		return rawData, nil
+	}
+
+	index, err := buildJoinIndex(c.conf.Join, fnGetData)
+	if err != nil {
+		return nil, err
+	}
+
+	var aggregateId string
+	if c.conf.Aggregate.Enabled {
+		nameProvider := getProfileNameProvider(authProvider, dataProvider)
+		if bypassed, err := isAggregateBypassed(c.conf, nameProvider, fnGetData); err != nil {
+			return nil, err
+		} else if bypassed {
+			// It's ok to bypass here, as Listors do without the profile
+			return nil, nil
+		}
+		if aggregateId, err = getAggregateId(c.conf, nameProvider); err != nil {
+			return nil, err
+		}
+	}
+
+	var checkerPropList, notApplicableList, errorList CheckerPropList
+	for _, listorId := range c.conf.Listor {
+		eachListorData, err := fnGetData(listorId)
+		if err != nil {
+			return nil, err
+		}
 
 		for _, rawData := range eachListorData {
-			eachData := &CheckerProp{Prop: rawData}
+			if reason := getNotApplicableOfRawData(rawData); reason != "" {
+				// Neither joined nor extracted, and excluded from aggregate
+				notApplicableList = append(notApplicableList, &CheckerProp{
+					Id:            aggregateId,
+					Region:        getRegionOfRawData(rawData),
+					Subscription:  getSubscriptionOfRawData(rawData),
+					K8sContext:    getK8sContextOfRawData(rawData),
+					NotApplicable: reason,
+					Prop:          rawData,
+				})
+				continue
+			}
+			if desc := getErrorOfRawData(rawData); desc != "" {
+				// Neither joined nor extracted, and fails the aggregate
+				errorList = append(errorList, &CheckerProp{
+					Id:           aggregateId,
+					Region:       getRegionOfRawData(rawData),
+					Subscription: getSubscriptionOfRawData(rawData),
+					K8sContext:   getK8sContextOfRawData(rawData),
+					Error:        desc,
+					Prop:         rawData,
+				})
+				continue
+			}
+
+			joinedData, err := joinItem(rawData, index)
+			if err != nil {
+				return nil, err
+			}
+
+			// Items of aggregate are not required to have their own ids
+			eachData := &CheckerProp{
+				Id:           aggregateId,
+				Region:       getRegionOfRawData(rawData),
+				Subscription: getSubscriptionOfRawData(rawData),
+				K8sContext:   getK8sContextOfRawData(rawData),
+				Prop:         joinedData,
+			}
 			eachData, err = getPropWithCmd(authProvider, *eachData, &c.conf.ExtractCmd, c.conf.CloudType)
 			if err != nil {
 				return nil, err
@@ -157,13 +240,35 @@ func (c *Checker) GetProp(opts ...GetPropOption) (CheckerPropList, error) {
 
 	}
 
-	return checkerPropList, nil
+	if c.conf.Aggregate.Enabled && len(errorList) > 0 {
+		// Data of the failed scopes is missing, so only the errors are reported
+		checkerPropList = nil
+	} else if c.conf.Aggregate.Enabled && (len(checkerPropList) > 0 || len(notApplicableList) == 0) {
+		aggregatedData, err := aggregateProp(&c.conf.Aggregate, aggregateId, checkerPropList)
+		if err != nil {
+			return nil, err
+		}
+		checkerPropList = CheckerPropList{aggregatedData}
+	}
+
+	return append(append(checkerPropList, notApplicableList...), errorList...), nil
+}
+
+// MAX_CMD_CHAIN_DEPTH: Max depth of nested CmdChain in ConfExtractCmd
+const MAX_CMD_CHAIN_DEPTH = 5
+
+// cmdChainState: State shared by all steps of CmdChain when extracting prop of one raw data
+type cmdChainState struct {
+	// Depth of CmdChain of the current step, 0 for the top level command
+	depth int
+	// Steps already executed with the same command and id, used to detect cycle
+	visited map[string]bool
 }
 
 // getPropWithCmd: Extract Id, Name and properties from previous data acoording to ConfExtractCmd
 //
 // Priority:
-// (TODO) 1. Extract from sub commands described in CmdChain (skip below)
+// 1. Extract from sub commands described in CmdChain (skip below)
 // 2. Extract from jsonpath described in ExtractJsonPath (skip below)
 // 3. Extract from cloud associated with cloudType
 // @param: authProvider: IAuthProvider to provide profile of auth
@@ -174,6 +279,16 @@ func (c *Checker) GetProp(opts ...GetPropOption) (CheckerPropList, error) {
 // @return: Error
 func getPropWithCmd(authProvider auth.IAuthProvider, previousData CheckerProp, conf *def.ConfExtractCmd, cloudType def.CloudType) (
 	*CheckerProp, error) {
+	return getPropWithCmdInChain(authProvider, previousData, conf, cloudType, &cmdChainState{visited: make(map[string]bool)})
+}
+
+// getPropWithCmdInChain: Implementation of getPropWithCmd with state of CmdChain
+//
+// In each step of CmdChain, id is derived again from the prop extracted by the previous step
+// with IdConst or IdJsonPath of the step, or inherited from the previous step if neither is defined.
+// Only the prop extracted by the last step is used, and Id and Name remain those of the top level command.
+func getPropWithCmdInChain(authProvider auth.IAuthProvider, previousData CheckerProp, conf *def.ConfExtractCmd, cloudType def.CloudType,
+	state *cmdChainState) (*CheckerProp, error) {
 	// previousData is a copy of the original value, except for the pointer of Prop
 	checkerProp := &previousData
 	var err error
@@ -190,6 +305,22 @@ func getPropWithCmd(authProvider auth.IAuthProvider, previousData CheckerProp, c
 		return nil, errors.New("invalid property, id is empty")
 	}
 
+	if state.depth > 0 && len(conf.CmdChain) == 0 && reflect.DeepEqual(conf.ExtractJsonPath, def.ConfJsonPathCmd{}) {
+		// Calling the cloud with the same command and the same id again means the chain goes around in a cycle,
+		// regardless of how the id is derived. Steps only extracting with jsonpath never call the cloud and are not tracked
+		stepConf := *conf
+		stepConf.IdJsonPath, stepConf.NameJsonPath, stepConf.IdConst, stepConf.NormalizeId = "", "", "", false
+		hash, err := CalcHash(crypto.SHA256, stepConf)
+		if err != nil {
+			return nil, err
+		}
+		key := fmt.Sprintf("%x_%s", hash, checkerProp.Id)
+		if state.visited[key] {
+			return nil, fmt.Errorf("cycle detected in cmd_chain, command with id \"%s\" already executed", checkerProp.Id)
+		}
+		state.visited[key] = true
+	}
+
 	if len(conf.NameJsonPath) > 0 {
 		checkerProp.Name, err = internal.ParseJsonPathStr(checkerProp.Prop, conf.NameJsonPath)
 		if err != nil {
@@ -197,25 +328,41 @@ func getPropWithCmd(authProvider auth.IAuthProvider, previousData CheckerProp, c
 		}
 	}
 
-	// Need to be checked before decommenting
-	//
-	// if len(conf.CmdChain) > 0 {
-	// 	for _, subCmd := range conf.CmdChain {
-	// 		checkerProp, err = getPropWithCmd(authProvider, *checkerProp, &subCmd, cloudType)
-	// 		if err != nil {
-	// 			return nil, fmt.Errorf("failed to get prop with commands chain: %w", err)
-	// 		}
-	// 	}
-	// } else
-	if !reflect.DeepEqual(conf.ExtractJsonPath, def.ConfJsonPathCmd{}) {
+	if len(conf.CmdChain) > 0 {
+		if state.depth >= MAX_CMD_CHAIN_DEPTH {
+			return nil, fmt.Errorf("depth of cmd_chain exceeds the limit of %d", MAX_CMD_CHAIN_DEPTH)
+		}
+
+		stepProp := &CheckerProp{
+			Id:           checkerProp.Id,
+			Region:       checkerProp.Region,
+			Subscription: checkerProp.Subscription,
+			K8sContext:   checkerProp.K8sContext,
+			Prop:         checkerProp.Prop,
+		}
+		for i := range conf.CmdChain {
+			stepProp, err = getPropWithCmdInChain(authProvider, *stepProp, &conf.CmdChain[i], cloudType,
+				&cmdChainState{depth: state.depth + 1, visited: state.visited})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get prop in step %d of cmd_chain: %w", i+1, err)
+			}
+		}
+
+		checkerProp.Prop = stepProp.Prop
+	} else if !reflect.DeepEqual(conf.ExtractJsonPath, def.ConfJsonPathCmd{}) {
 		extractedProp, err := internal.ParseJsonPath(checkerProp.Prop, conf.ExtractJsonPath.Path)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse JsonPath: %w", err)
 		}
 
 		checkerProp.Prop = extractedProp
+	} else if cloudType == def.K8S {
+		checkerProp.Prop, err = getPropWithK8s(authProvider, checkerProp, conf)
+		if err != nil {
+			return nil, err
+		}
 	} else {
-		checkerProp.Prop, err = getPropWithCloud(authProvider, cloudType, checkerProp.Id, conf)
+		checkerProp.Prop, err = getPropWithCloud(authProvider, cloudType, checkerProp.Id, checkerProp.Region, conf)
 		if err != nil {
 			return nil, err
 		}
@@ -229,7 +376,67 @@ func getPropWithCmd(authProvider auth.IAuthProvider, previousData CheckerProp, c
 	return checkerProp, nil
 }
 
-func getPropWithCloud(authProvider auth.IAuthProvider, cloudType def.CloudType, id string, conf *def.ConfExtractCmd) (*json.RawMessage, error) {
+// getPropWithK8s: Get prop of the resource from k8s
+//
+// The raw path of K8sRaw is requested if defined, otherwise the k8s object named by the id of checkerProp is got.
+// Both are sent to the k8s context which the data is listed in.
+func getPropWithK8s(authProvider auth.IAuthProvider, checkerProp *CheckerProp, conf *def.ConfExtractCmd) (*json.RawMessage, error) {
+	if authProvider == nil {
+		return nil, errors.New("nil pointor of IAuthProvider of Checker")
+	}
+
+	if len(conf.K8sRaw.Path) > 0 {
+		if !strings.Contains(conf.K8sRaw.Path, ID_PLACEHOLDER) {
+			return nil, fmt.Errorf("missing %s in path for getting prop from k8s", ID_PLACEHOLDER)
+		}
+
+		return connector.CallK8sRaw(authProvider, checkerProp.K8sContext,
+			strings.ReplaceAll(conf.K8sRaw.Path, ID_PLACEHOLDER, url.PathEscape(checkerProp.Id)), conf.K8sRaw.ExtraParam)
+	}
+
+	return getK8sObject(authProvider, checkerProp, &conf.K8sGet)
+}
+
+// getK8sObject: Get the k8s object named by the id of checkerProp
+//
+// Namespace is extracted from the data of the step if NamespaceJsonPath is defined.
+func getK8sObject(authProvider auth.IAuthProvider, checkerProp *CheckerProp, conf *def.ConfK8sGetCmd) (*json.RawMessage, error) {
+	if len(conf.Resource) == 0 {
+		return nil, errors.New("missing resource for getting prop from k8s")
+	}
+
+	namespace := conf.Namespace
+	if len(conf.NamespaceJsonPath) > 0 {
+		var err error
+		if namespace, err = internal.ParseJsonPathStr(checkerProp.Prop, conf.NamespaceJsonPath); err != nil {
+			return nil, fmt.Errorf("failed to get namespace: %w", err)
+		}
+		if len(namespace) == 0 {
+			return nil, errors.New("invalid property, namespace is empty")
+		}
+	}
+
+	res, err := connector.CallK8sGet(authProvider, checkerProp.K8sContext, namespace, conf.Group, conf.Version, conf.Resource,
+		checkerProp.Id, conf.Subresource, conf.IgnoreNotFound)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		// null of object not found may be replayed as nil pointor
+		return internal.JsonMarshal(nil)
+	}
+
+	return res, nil
+}
+
+// ID_PLACEHOLDER: Placeholder in path of GCP, OpenStack and url of HTTP API to be replaced by id
+const ID_PLACEHOLDER = "{id}"
+
+// getPropWithCloud: Get prop of the resource from cloud
+//
+// Region is only used by Tencent cloud, Aliyun and Aliyun OSS, and the region of profile is used if empty
+func getPropWithCloud(authProvider auth.IAuthProvider, cloudType def.CloudType, id string, region string, conf *def.ConfExtractCmd) (
+	*json.RawMessage, error) {
 	if authProvider == nil {
 		return nil, errors.New("nil pointor of IAuthProvider of Checker")
 	}
@@ -248,8 +455,9 @@ func getPropWithCloud(authProvider auth.IAuthProvider, cloudType def.CloudType,
 			return nil, err
 		}
 
-		return connector.CallTencentCloud(
+		return connector.CallTencentCloudWithRegion(
 			authProvider,
+			region,
 			conf.TencentCloud.Service,
 			conf.TencentCloud.Version,
 			conf.TencentCloud.Action,
@@ -275,8 +483,9 @@ func getPropWithCloud(authProvider auth.IAuthProvider, cloudType def.CloudType,
 			return nil, err
 		}
 
-		return connector.CallAliyunCloud(
+		return connector.CallAliyunCloudWithRegion(
 			authProvider,
+			region,
 			conf.Aliyun.Endpoint,
 			conf.Aliyun.EndpointWithRegion,
 			conf.Aliyun.Version,
@@ -284,19 +493,114 @@ func getPropWithCloud(authProvider auth.IAuthProvider, cloudType def.CloudType,
 			extraParam,
 		)
 	case def.ALIYUN_OSS:
-		return connector.CallAliyunOSS(
+		return connector.CallAliyunOSSWithRegion(
 			authProvider,
+			region,
 			id, // Treat id as bucketName
 			conf.AliyunOSS.Action,
 			nil, // Ignored if not listing buckets
 		)
 	case def.AZURE:
-		return connector.CallAzureWithEndpoint(
+		switch conf.Azure.Api {
+		case "", def.AZURE_API_ARM:
+			return connector.CallAzureWithEndpointAndParam(
+				authProvider,
+				conf.Azure.Version,
+				id,
+				conf.Azure.Action,
+				conf.Azure.ExtraParam,
+			)
+		case def.AZURE_API_GRAPH:
+			// Id of Microsoft Graph is not a path, so rs_type is prepended as the path of resources,
+			// with id escaped as a single segment of path
+			endpoint := id
+			if len(conf.Azure.RsType) > 0 {
+				endpoint = fmt.Sprintf("%s/%s", conf.Azure.RsType, url.PathEscape(id))
+			}
+			return connector.CallAzureGraph(
+				authProvider,
+				conf.Azure.Version,
+				endpoint,
+				conf.Azure.Action,
+				conf.Azure.ExtraParam,
+			)
+		default:
+			return nil, fmt.Errorf("unknown api of Azure: %s", conf.Azure.Api)
+		}
+	case def.AWS:
+		if len(conf.IdParamName) == 0 {
+			return nil, errors.New("missing IdParamName for getting prop from AWS")
+		}
+
+		extraParam := maps.Clone(conf.AWS.ExtraParam)
+		if extraParam == nil {
+			extraParam = make(map[string]any)
+		}
+		if err := internal.AddParamString(extraParam, conf.IdParamName, id, conf.IdParamType); err != nil {
+			return nil, err
+		}
+
+		return connector.CallAWS(
 			authProvider,
-			conf.Azure.Version,
-			id,
-			conf.Azure.Action,
+			conf.AWS.Service,
+			conf.AWS.Version,
+			conf.AWS.Action,
+			extraParam,
 		)
+	case def.GCP:
+		extraParam := maps.Clone(conf.GCP.ExtraParam)
+		if extraParam == nil {
+			extraParam = make(map[string]any)
+		}
+		if len(conf.IdParamName) > 0 {
+			if err := internal.AddParamString(extraParam, conf.IdParamName, id, conf.IdParamType); err != nil {
+				return nil, err
+			}
+		} else if !strings.Contains(conf.GCP.Path, ID_PLACEHOLDER) {
+			return nil, fmt.Errorf("missing IdParamName or %s in path for getting prop from GCP", ID_PLACEHOLDER)
+		}
+
+		return connector.CallGCP(
+			authProvider,
+			conf.GCP.Service,
+			conf.GCP.Version,
+			conf.GCP.Method,
+			strings.ReplaceAll(conf.GCP.Path, ID_PLACEHOLDER, url.PathEscape(id)),
+			extraParam,
+		)
+	case def.OPENSTACK:
+		extraParam := maps.Clone(conf.OpenStack.ExtraParam)
+		if extraParam == nil {
+			extraParam = make(map[string]any)
+		}
+		if len(conf.IdParamName) > 0 {
+			if err := internal.AddParamString(extraParam, conf.IdParamName, id, conf.IdParamType); err != nil {
+				return nil, err
+			}
+		} else if !strings.Contains(conf.OpenStack.Path, ID_PLACEHOLDER) {
+			return nil, fmt.Errorf("missing IdParamName or %s in path for getting prop from OpenStack", ID_PLACEHOLDER)
+		}
+
+		return connector.CallOpenStack(
+			authProvider,
+			conf.OpenStack.Service,
+			strings.ReplaceAll(conf.OpenStack.Path, ID_PLACEHOLDER, url.PathEscape(id)),
+			extraParam,
+		)
+	case def.HTTP_API:
+		param := make(map[string]any)
+		if len(conf.IdParamName) > 0 {
+			if err := internal.AddParamString(param, conf.IdParamName, id, conf.IdParamType); err != nil {
+				return nil, err
+			}
+		} else if !strings.Contains(conf.HttpApi.URL, ID_PLACEHOLDER) {
+			return nil, fmt.Errorf("missing IdParamName or %s in url for getting prop from HTTP API", ID_PLACEHOLDER)
+		}
+
+		cmd := conf.HttpApi
+		cmd.URL = strings.ReplaceAll(cmd.URL, ID_PLACEHOLDER, url.PathEscape(id))
+		res, _, err := connector.CallHttpApi(authProvider, &cmd, "", param)
+		return res, err
 	default:
 		return nil, fmt.Errorf("invalid cloud type: %s", cloudType)
 	}
@@ -310,76 +614,258 @@ type ValidateResult struct {
 	Id string
 	// Human readable name of the resource
 	Name string
-	// Indicate if the property has failed the benchmark check
+	// Region of the resource, empty if listed in the region of profile
+	Region string
+	// Subscription of Azure of the resource, empty if listed in the subscription of profile
+	Subscription string
+	// Context of k8s of the resource, empty if listed in the current context of kubeconfig
+	K8sContext string
+	// Indicate if the property has failed the benchmark check, the same as Outcome of OUTCOME_FAIL
 	InRisk bool
-	// Actual value of the property to be displayed
+	// Outcome of validation
+	Outcome ValidateOutcome
+	// Actual value of the property to be displayed, or description of constraint not satisfied if not applicable
 	Value string
+	// Description of error if Outcome is OUTCOME_ERROR
+	Error string
+	// Messages of Rego, or branches of JsonSchema matched if reason is required by validator, if Outcome is OUTCOME_FAIL
+	Reason string
+	// Description of the waiver applied if Outcome is OUTCOME_WAIVED, or of the expired waiver matched
+	Waiver string
+}
+
+// ValidateOutcome: Outcome of validation of a resource
+type ValidateOutcome string
+
+const (
+	OUTCOME_PASS           ValidateOutcome = "pass"           // The resource passes the benchmark check
+	OUTCOME_FAIL           ValidateOutcome = "fail"           // The resource fails the benchmark check and is in risk
+	OUTCOME_NOT_APPLICABLE ValidateOutcome = "not_applicable" // The Listor or its scope does not satisfy the constraint
+	OUTCOME_ERROR          ValidateOutcome = "error"          // Failed to get or validate properties
+	OUTCOME_WAIVED         ValidateOutcome = "waived"         // The resource fails the benchmark check, but the risk is accepted by a waiver
+)
+
+// MAX_CACHED_VALIDATORS: Max number of validators cached in each Checker,
+// as values of params requested are arbitrary, e.g. by users of apiserver
+const MAX_CACHED_VALIDATORS = 64
+
+// validateOpt: Options of Validate
+type validateOpt struct {
+	// Parameters overriding DynValidateValue
+	params map[string]string
+	// IAuthProvider whose params file of profile overrides DynValidateValue
+	ap auth.IAuthProvider
+}
+
+// ValidateOption: Functional options used in Validate
+type ValidateOption func(opt *validateOpt) error
+
+// SetParamsOpt: Set validateOpt.params
+//
+// Parameters overriding the values of DynValidateValue with the same keys, other keys are ignored
+// @param: val: Value for parameters
+func SetParamsOpt(val map[string]string) ValidateOption {
+	return func(options *validateOpt) error {
+		options.params = val
+		return nil
+	}
+}
+
+// SetValidateAuthProviderOpt: Set validateOpt.ap
+//
+// It should be the same provider passed to GetProp, so that the params file of the same profile is used
+// @param: val: Value for auth provider
+func SetValidateAuthProviderOpt(val auth.IAuthProvider) ValidateOption {
+	return func(options *validateOpt) error {
+		options.ap = val
+		return nil
+	}
+}
+
+// checkerValidator: Validator created for the values of DynValidateValue
+type checkerValidator struct {
+	// Instance of validator of JsonSchema, CEL or Rego
+	validator iValidator
+	// Finder of reasons of validation, nil if not required
+	reasonFinder *reasonFinder
+	// Error of creating validator, kept to avoid creating it again
+	err error
 }
 
 // Validate: Validate properties and generate result
+//
+// Every property has a result, and errors of each property are reported as OUTCOME_ERROR.
+// Values of DynValidateValue are overridden by the params file of profile first, then by the params of options.
+// The profile is provided by the IAuthProvider of options if set, or Checker.authProvider otherwise.
 // @param: data: Properties extracted from the step of GetProp
+// @param: opts: Additional options
 // @return: Result of validation
 // @return: Error
-func (c *Checker) Validate(data CheckerPropList) ([]*ValidateResult, error) {
-	if err := c.createValidator(); err != nil {
-		return nil, err
+func (c *Checker) Validate(data CheckerPropList, opts ...ValidateOption) ([]*ValidateResult, error) {
+	var optAll validateOpt
+	for _, opt := range opts {
+		if err := opt(&optAll); err != nil {
+			return nil, err
+		}
 	}
-	if c.validator == nil {
-		// createValidator has failed for more than once,
-		// just return to avoid repeating the same errors
-		return nil, nil
+
+	authProvider := optAll.ap
+	if authProvider == nil {
+		authProvider = c.authProvider
+	}
+
+	var cv *checkerValidator
+	dynValue, validatorErr := c.getDynValidateValue(authProvider, optAll.params)
+	if validatorErr == nil {
+		cv, validatorErr = c.createValidator(dynValue)
 	}
 
 	var validateResultList = make([]*ValidateResult, 0, len(data))
 	for _, eachProp := range data {
 		eachResult := ValidateResult{
-			CloudType: c.conf.CloudType,
-			Id:        eachProp.Id,
-			Name:      eachProp.Name,
+			CloudType:    c.conf.CloudType,
+			Id:           eachProp.Id,
+			Name:         eachProp.Name,
+			Region:       eachProp.Region,
+			Subscription: eachProp.Subscription,
+			K8sContext:   eachProp.K8sContext,
 		}
+		validateResultList = append(validateResultList, &eachResult)
 
-		jsResult, err := c.validator.Validate(gojsonschema.NewBytesLoader(*eachProp.Prop))
+		if eachProp.NotApplicable != "" {
+			eachResult.Outcome = OUTCOME_NOT_APPLICABLE
+			eachResult.Value = eachProp.NotApplicable
+			continue
+		}
+		if eachProp.Error != "" {
+			eachResult.setError(errors.New(eachProp.Error))
+			continue
+		}
+		if validatorErr != nil {
+			eachResult.setError(validatorErr)
+			continue
+		}
+		if eachProp.Prop == nil {
+			eachResult.setError(errors.New("failed to validate prop: nil pointor of prop"))
+			continue
+		}
+
+		inRisk, messages, err := cv.validator.validate(eachProp.Prop)
 		if err != nil {
-			// Print error and skip the current property
-			glog().Printf("Failed to validate prop: %v\n", err)
+			eachResult.setError(fmt.Errorf("failed to validate prop: %w", err))
 			continue
 		}
-		eachResult.InRisk = jsResult.Valid()
+		eachResult.InRisk = inRisk
 
 		if len(c.conf.Validator.ValueJsonPath) > 0 {
 			eachResult.Value, err = internal.ParseJsonPathStr(eachProp.Prop, c.conf.Validator.ValueJsonPath)
 			if err != nil {
-				// Print error and skip the current property
-				glog().Printf("Failed to get actual value with jsonPath: %v\n", err)
+				eachResult.setError(fmt.Errorf("failed to get actual value with jsonPath: %w", err))
 				continue
 			}
 		}
 
-		validateResultList = append(validateResultList, &eachResult)
+		if eachResult.InRisk {
+			eachResult.Outcome = OUTCOME_FAIL
+			if len(messages) > 0 {
+				eachResult.Reason = strings.Join(messages, "; ")
+			} else if cv.reasonFinder != nil {
+				if reasons, err := cv.reasonFinder.find(eachProp.Prop); err != nil {
+					// Print error and keep the result without reason
+					glog().Printf("Failed to find reason: %v\n", err)
+				} else {
+					eachResult.Reason = strings.Join(reasons, "; ")
+				}
+			}
+		} else {
+			eachResult.Outcome = OUTCOME_PASS
+		}
 	}
 
 	return validateResultList, nil
 }
 
-// createValidator: Try to create validator according to definition of Checker.conf.Validator
+// setError: Set the outcome of result to OUTCOME_ERROR
+// @param: err: Error of the result
+func (r *ValidateResult) setError(err error) {
+	r.InRisk = false
+	r.Outcome = OUTCOME_ERROR
+	r.Error = err.Error()
+}
+
+// getDynValidateValue: Get values of DynValidateValue overridden by params
 //
-// If failed, the same error will not be repeated on the next call
+// Only keys defined in DynValidateValue are overridden.
+// @param: authProvider: IAuthProvider to provide the params file of profile
+// @param: params: Params of options, which take precedence over the params file of profile
+// @return: Values of DynValidateValue
 // @return: Error
-func (c *Checker) createValidator() error {
-	if c.validator == nil && !c.validatorLoadFailed {
-		schema := c.conf.Validator.ValidateSchema
-		for k, v := range c.conf.Validator.DynValidateValue {
-			schema = strings.ReplaceAll(schema, fmt.Sprintf("%%%s%%", k), v) // replace %k% to v
+func (c *Checker) getDynValidateValue(authProvider auth.IAuthProvider, params map[string]string) (
+	map[string]string, error) {
+	if len(c.conf.Validator.DynValidateValue) == 0 {
+		return nil, nil
+	}
+
+	profileParams, err := getProfileParams(authProvider, c.conf.CloudType)
+	if err != nil {
+		return nil, err
+	}
+
+	dynValue := maps.Clone(c.conf.Validator.DynValidateValue)
+	for _, overrides := range []map[string]string{profileParams, params} {
+		for k, v := range overrides {
+			if _, ok := dynValue[k]; ok {
+				dynValue[k] = v
+			}
 		}
+	}
 
-		var err error
-		c.validator, err = gojsonschema.NewSchema(gojsonschema.NewStringLoader(schema))
+	return dynValue, nil
+}
+
+// createValidator: Try to create validator according to definition of Checker.conf.Validator
+//
+// Validators are cached for each distinct values of DynValidateValue,
+// up to MAX_CACHED_VALIDATORS with the least recently used one evicted,
+// and if failed, the same error is returned on the next call without creating it again.
+// Validators using time-relative variables are not cached but created on each call,
+// as the variables are expanded with the current time on creation.
+// @param: dynValue: Values of DynValidateValue used instead of those in definition
+// @return: Validator created
+// @return: Error
+func (c *Checker) createValidator(dynValue map[string]string) (*checkerValidator, error) {
+	conf := c.conf.Validator
+	conf.DynValidateValue = dynValue
+
+	fnCreate := func() (any, error) {
+		validator, err := newValidator(&conf)
 		if err != nil {
-			c.validator = nil
-			c.validatorLoadFailed = true
-			return fmt.Errorf("failed to create jsonschema: %w", err)
+			return &checkerValidator{err: err}, nil
+		}
+
+		cv := checkerValidator{validator: validator}
+		// Branches matched are only available for JsonSchema, while messages of Rego are always outputted
+		if jsValidator, ok := validator.(*jsonSchemaValidator); ok && conf.Reason {
+			if cv.reasonFinder, err = newReasonFinder(jsValidator.schema); err != nil {
+				return &checkerValidator{err: err}, nil
+			}
 		}
+		return &cv, nil
+	}
+
+	if hasTimeVariables(&conf) {
+		created, _ := fnCreate()
+		cv := created.(*checkerValidator)
+		return cv, cv.err
 	}
 
-	return nil
+	// Keys of map are sorted by json.Marshal
+	byKey, err := json.Marshal(dynValue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal params to json: %w", err)
+	}
+
+	cv, _ := c.validators.LoadOrCreate(string(byKey), fnCreate, nil)
+
+	return cv, cv.err
 }
@@ -4,8 +4,13 @@ package framework
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/s3studio/cloud-bench-checker/internal"
 	"github.com/s3studio/cloud-bench-checker/pkg/auth"
@@ -31,7 +36,7 @@ func TestNewChecker(t *testing.T) {
 		{
 			"Valid result",
 			args{&validConf, nil, nil},
-			&Checker{conf: &validConf},
+			&Checker{conf: &validConf, validators: internal.NewLruCache[*checkerValidator](MAX_CACHED_VALIDATORS)},
 		},
 	}
 	for _, tt := range tests {
@@ -134,6 +139,110 @@ func TestChecker_GetProp(t *testing.T) {
 	mockDp := SyncMapDataProvider{}
 	mockDp.DataMap.Store(1, []*json.RawMessage{rm})
 	mockDp.CtMap.Store(1, VALID_CT)
+	rmVnet, _ := internal.JsonMarshal(map[string]any{"id": "vnet", "location": "east"})
+	rmWatcher, _ := internal.JsonMarshal(map[string]any{"id": "watcher", "location": "east"})
+	rmJoined, _ := internal.JsonMarshal(map[string]any{"id": "vnet", "location": "east", "watcher": []any{map[string]any{"id": "watcher", "location": "east"}}})
+	joinDp := SyncMapDataProvider{}
+	joinDp.DataMap.Store(1, []*json.RawMessage{rmVnet})
+	joinDp.CtMap.Store(1, VALID_CT)
+	joinDp.DataMap.Store(2, []*json.RawMessage{rmWatcher})
+	joinDp.CtMap.Store(2, VALID_CT)
+	checkerJoin := NewChecker(&def.ConfChecker{
+		CloudType: VALID_CT,
+		Listor:    []int{1},
+		Join:      []def.ConfJoinListor{{Listor: 2, KeyJsonPath: "$.location", PrimaryKeyJsonPath: "$.location", As: "watcher"}},
+		ExtractCmd: def.ConfExtractCmd{
+			IdJsonPath:      "$.id",
+			ExtractJsonPath: def.ConfJsonPathCmd{Path: "$"},
+		},
+	}, nil, &joinDp)
+	checkerJoinInvalid := NewChecker(&def.ConfChecker{
+		CloudType: VALID_CT,
+		Listor:    []int{1},
+		Join:      []def.ConfJoinListor{{Listor: 2, KeyJsonPath: "$.location", PrimaryKeyJsonPath: "$.location"}},
+	}, nil, &joinDp)
+	checkerJoinNotObject := setupCheckerData()
+	checkerJoinNotObject.conf.Join = []def.ConfJoinListor{{Listor: 1, KeyJsonPath: "$", PrimaryKeyJsonPath: "$", As: "mock"}}
+	confAggregate := def.ConfChecker{
+		CloudType: VALID_CT,
+		Listor:    []int{1},
+		ExtractCmd: def.ConfExtractCmd{
+			IdConst:         "account",
+			ExtractJsonPath: def.ConfJsonPathCmd{Path: "$"},
+		},
+		Aggregate: def.ConfAggregate{Enabled: true},
+	}
+	checkerAggregate := NewChecker(&confAggregate, nil, &joinDp)
+	checkerAggregateEmpty := NewChecker(&confAggregate, nil, &SyncMapDataProvider{})
+	confAggregateInvalid := confAggregate
+	confAggregateInvalid.ExtractCmd.IdConst = ""
+	checkerAggregateInvalid := NewChecker(&confAggregateInvalid, nil, &joinDp)
+	rmAggregated, _ := internal.JsonMarshal([]any{map[string]any{"id": "vnet", "location": "east"}})
+	noProfileAuthProvider := auth.NewAuthFileProvider(def.ConfProfile{})
+	confAggregateNoProfile := confAggregate
+	confAggregateNoProfile.CloudType = def.TENCENT_CLOUD
+	checkerAggregateNoProfile := NewChecker(&confAggregateNoProfile, noProfileAuthProvider, &SyncMapDataProvider{})
+	confAggregateNoProfileNoIdConst := confAggregateNoProfile
+	confAggregateNoProfileNoIdConst.ExtractCmd.IdConst = ""
+	checkerAggregateNoProfileNoIdConst := NewChecker(&confAggregateNoProfileNoIdConst, noProfileAuthProvider, &SyncMapDataProvider{})
+	snapshotDp := SyncMapDataProvider{}
+	snapshotDp.DataMap.Store(1, []*json.RawMessage{rmVnet})
+	snapshotDp.CtMap.Store(1, string(def.TENCENT_CLOUD))
+	checkerAggregateNoProfileWithData := NewChecker(&confAggregateNoProfile, noProfileAuthProvider, &snapshotDp)
+	recordedSnapshotDp := SnapshotDataProvider{
+		Manifest: SnapshotManifest{Profile: map[string]string{string(def.TENCENT_CLOUD): "recorded"}},
+		dataMap:  map[int][]*json.RawMessage{1: {rmVnet}},
+		ctMap:    map[int]string{1: string(def.TENCENT_CLOUD)},
+	}
+	checkerAggregateSnapshot := NewChecker(&confAggregateNoProfileNoIdConst, noProfileAuthProvider, &recordedSnapshotDp)
+	checkerAggregateSnapshotNoProfile := NewChecker(&confAggregateNoProfileNoIdConst, noProfileAuthProvider, &SnapshotDataProvider{})
+	rmAggregatedEmpty, _ := internal.JsonMarshal([]any{})
+	rmRegion, _ := internal.JsonMarshal(map[string]any{"id": "vnet", RAW_DATA_REGION_KEY: "region-1"})
+	regionDp := SyncMapDataProvider{}
+	regionDp.DataMap.Store(1, []*json.RawMessage{rmRegion})
+	regionDp.CtMap.Store(1, VALID_CT)
+	checkerRegion := NewChecker(&def.ConfChecker{
+		CloudType: VALID_CT,
+		Listor:    []int{1},
+		ExtractCmd: def.ConfExtractCmd{
+			IdJsonPath:      "$.id",
+			ExtractJsonPath: def.ConfJsonPathCmd{Path: "$"},
+		},
+	}, nil, &regionDp)
+	rmNotApplicable, _ := internal.JsonMarshal(map[string]any{
+		RAW_DATA_NOT_APPLICABLE_KEY: "constraint not satisfied", RAW_DATA_REGION_KEY: "region-2"})
+	notApplicableDp := SyncMapDataProvider{}
+	notApplicableDp.DataMap.Store(1, []*json.RawMessage{rmRegion, rmNotApplicable})
+	notApplicableDp.CtMap.Store(1, VALID_CT)
+	notApplicableDp.DataMap.Store(2, []*json.RawMessage{rmNotApplicable})
+	notApplicableDp.CtMap.Store(2, VALID_CT)
+	checkerNotApplicable := NewChecker(&def.ConfChecker{
+		CloudType: VALID_CT,
+		Listor:    []int{1},
+		ExtractCmd: def.ConfExtractCmd{
+			IdJsonPath:      "$.id",
+			ExtractJsonPath: def.ConfJsonPathCmd{Path: "$"},
+		},
+	}, nil, &notApplicableDp)
+	checkerAggregateNotApplicable := NewChecker(&confAggregate, nil, &notApplicableDp)
+	confAggregateAllNotApplicable := confAggregate
+	confAggregateAllNotApplicable.Listor = []int{2}
+	checkerAggregateAllNotApplicable := NewChecker(&confAggregateAllNotApplicable, nil, &notApplicableDp)
+	rmError, _ := internal.JsonMarshal(map[string]any{
+		RAW_DATA_ERROR_KEY: "failed to list data in region region-3", RAW_DATA_REGION_KEY: "region-3"})
+	errorDp := SyncMapDataProvider{}
+	errorDp.DataMap.Store(1, []*json.RawMessage{rmRegion, rmNotApplicable, rmError})
+	errorDp.CtMap.Store(1, VALID_CT)
+	checkerError := NewChecker(&def.ConfChecker{
+		CloudType: VALID_CT,
+		Listor:    []int{1},
+		ExtractCmd: def.ConfExtractCmd{
+			IdJsonPath:      "$.id",
+			ExtractJsonPath: def.ConfJsonPathCmd{Path: "$"},
+		},
+	}, nil, &errorDp)
+	checkerAggregateError := NewChecker(&confAggregate, nil, &errorDp)
+	rmAggregatedRegion, _ := internal.JsonMarshal([]any{map[string]any{"id": "vnet", RAW_DATA_REGION_KEY: "region-1"}})
 
 	type args struct {
 		opts []GetPropOption
@@ -211,6 +320,152 @@ func TestChecker_GetProp(t *testing.T) {
 			nil,
 			true,
 		},
+		{
+			"Valid result with join",
+			checkerJoin,
+			args{nil},
+			CheckerPropList{
+				{Id: "vnet", Prop: rmJoined},
+			},
+			false,
+		},
+		{
+			"failed to build index of join",
+			checkerJoinInvalid,
+			args{nil},
+			nil,
+			true,
+		},
+		{
+			"failed to join item which is not an object",
+			checkerJoinNotObject,
+			args{nil},
+			nil,
+			true,
+		},
+		{
+			"Valid result with region",
+			checkerRegion,
+			args{nil},
+			CheckerPropList{
+				{Id: "vnet", Region: "region-1", Prop: rmRegion},
+			},
+			false,
+		},
+		{
+			"Valid result with region not applicable",
+			checkerNotApplicable,
+			args{nil},
+			CheckerPropList{
+				{Id: "vnet", Region: "region-1", Prop: rmRegion},
+				{Region: "region-2", NotApplicable: "constraint not satisfied", Prop: rmNotApplicable},
+			},
+			false,
+		},
+		{
+			"Valid result with aggregate excluding region not applicable",
+			checkerAggregateNotApplicable,
+			args{nil},
+			CheckerPropList{
+				{Id: "account", Prop: rmAggregatedRegion},
+				{Id: "account", Region: "region-2", NotApplicable: "constraint not satisfied", Prop: rmNotApplicable},
+			},
+			false,
+		},
+		{
+			"Valid result with error of region",
+			checkerError,
+			args{nil},
+			CheckerPropList{
+				{Id: "vnet", Region: "region-1", Prop: rmRegion},
+				{Region: "region-2", NotApplicable: "constraint not satisfied", Prop: rmNotApplicable},
+				{Region: "region-3", Error: "failed to list data in region region-3", Prop: rmError},
+			},
+			false,
+		},
+		{
+			"Valid result without aggregate for error of region",
+			checkerAggregateError,
+			args{nil},
+			CheckerPropList{
+				{Id: "account", Region: "region-2", NotApplicable: "constraint not satisfied", Prop: rmNotApplicable},
+				{Id: "account", Region: "region-3", Error: "failed to list data in region region-3", Prop: rmError},
+			},
+			false,
+		},
+		{
+			"Valid result with aggregate of all not applicable",
+			checkerAggregateAllNotApplicable,
+			args{nil},
+			CheckerPropList{
+				{Id: "account", Region: "region-2", NotApplicable: "constraint not satisfied", Prop: rmNotApplicable},
+			},
+			false,
+		},
+		{
+			"Valid result with aggregate",
+			checkerAggregate,
+			args{nil},
+			CheckerPropList{
+				{Id: "account", Prop: rmAggregated},
+			},
+			false,
+		},
+		{
+			"Valid result with aggregate of no item",
+			checkerAggregateEmpty,
+			args{nil},
+			CheckerPropList{
+				{Id: "account", Prop: rmAggregatedEmpty},
+			},
+			false,
+		},
+		{
+			"Aggregate bypassed without profile",
+			checkerAggregateNoProfile,
+			args{nil},
+			nil,
+			false,
+		},
+		{
+			"Aggregate bypassed without profile and IdConst",
+			checkerAggregateNoProfileNoIdConst,
+			args{nil},
+			nil,
+			false,
+		},
+		{
+			"Valid result with aggregate of data provided without profile",
+			checkerAggregateNoProfileWithData,
+			args{nil},
+			CheckerPropList{
+				{Id: "account", Prop: rmAggregated},
+			},
+			false,
+		},
+		{
+			"Valid result with aggregate of snapshot",
+			checkerAggregateSnapshot,
+			args{nil},
+			CheckerPropList{
+				{Id: "tencent_cloud:recorded", Prop: rmAggregated},
+			},
+			false,
+		},
+		{
+			"Aggregate bypassed without profile in snapshot",
+			checkerAggregateSnapshotNoProfile,
+			args{nil},
+			nil,
+			false,
+		},
+		{
+			"failed to get id of aggregate",
+			checkerAggregateInvalid,
+			args{nil},
+			nil,
+			true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -379,173 +634,894 @@ func Test_getPropWithCmd(t *testing.T) {
 	}
 }
 
-func Test_getPropWithCloud(t *testing.T) {
-	rm, _ := internal.JsonMarshal("mock")
-	patchCallTencentCloud := gomonkey.ApplyFunc(connector.CallTencentCloud,
+// setupCmdChain: Patch all connectors used by Checker to echo the id received as {"id":"{id}/child"}
+func setupCmdChain() func() {
+	echo := func(id string) (*json.RawMessage, error) {
+		if strings.HasSuffix(id, "invalid") {
+			return nil, errors.New("mock invalid id")
+		}
+		return internal.JsonMarshal(map[string]string{"id": id + "/child"})
+	}
+	getId := func(param map[string]any) string {
+		id, _ := param["mock_name"].(string)
+		return id
+	}
+	// Id is escaped in path, and unescaped by the server
+	getPathId := func(path string) string {
+		id, _ := url.PathUnescape(strings.TrimPrefix(path, "mock/"))
+		return id
+	}
+
+	patches := gomonkey.ApplyFunc(connector.CallTencentCloud,
 		func(authProvider auth.IAuthProvider, service string, version string, action string, extraParam map[string]any) (*json.RawMessage, error) {
-			return rm, nil
+			return echo(getId(extraParam))
 		})
-	defer patchCallTencentCloud.Reset()
-	patchCallTencentCOS := gomonkey.ApplyFunc(connector.CallTencentCOS,
+	patches.ApplyFunc(connector.CallTencentCOS,
 		func(authProvider auth.IAuthProvider, bucketName string, service string, action string) (*json.RawMessage, error) {
-			return rm, nil
+			return echo(bucketName)
 		})
-	defer patchCallTencentCOS.Reset()
-	patchCallAliyunCloud := gomonkey.ApplyFunc(connector.CallAliyunCloud,
+	patches.ApplyFunc(connector.CallAliyunCloud,
 		func(authProvider auth.IAuthProvider, endpoint string, bEpWithRegion bool, version string, action string, extraParam map[string]any) (*json.RawMessage, error) {
-			return rm, nil
+			return echo(getId(extraParam))
 		})
-	defer patchCallAliyunCloud.Reset()
-	patchCallAliyunOSS := gomonkey.ApplyFunc(connector.CallAliyunOSS,
+	patches.ApplyFunc(connector.CallAliyunOSS,
 		func(authProvider auth.IAuthProvider, bucketName string, action string, extraParam map[string]any) (*json.RawMessage, error) {
-			return rm, nil
+			return echo(bucketName)
 		})
-	defer patchCallAliyunOSS.Reset()
-	patchCallAzure := gomonkey.ApplyFunc(connector.CallAzureWithEndpoint,
+	patches.ApplyFunc(connector.CallAzureWithEndpoint,
 		func(authProvider auth.IAuthProvider, version string, endpoint string, action string) (*json.RawMessage, error) {
-			return rm, nil
+			return echo(endpoint)
 		})
-	defer patchCallAzure.Reset()
+	patches.ApplyFunc(connector.CallAWS,
+		func(authProvider auth.IAuthProvider, service string, version string, action string, extraParam map[string]any) (*json.RawMessage, error) {
+			return echo(getId(extraParam))
+		})
+	patches.ApplyFunc(connector.CallGCP,
+		func(authProvider auth.IAuthProvider, service string, version string, method string, path string, extraParam map[string]any) (
+			*json.RawMessage, error) {
+			return echo(getPathId(path))
+		})
+	patches.ApplyFunc(connector.CallOpenStack,
+		func(authProvider auth.IAuthProvider, service string, path string, extraParam map[string]any) (*json.RawMessage, error) {
+			return echo(getPathId(path))
+		})
+	patches.ApplyFunc(connector.CallHttpApi,
+		func(authProvider auth.IAuthProvider, cmd *def.ConfHttpCmd, nextLink string, param map[string]any) (
+			*json.RawMessage, string, error) {
+			res, err := echo(getPathId(cmd.URL))
+			return res, "", err
+		})
+	patches.ApplyFunc(connector.CallK8sGet,
+		func(authProvider auth.IAuthProvider, kubeContext string, namespace string, group string, version string, resource string,
+			name string, subresource string, ignoreNotFound bool) (*json.RawMessage, error) {
+			return echo(name)
+		})
+
+	return patches.Reset
+}
+
+func Test_getPropWithCmd_cmdChain(t *testing.T) {
+	deferFn := setupCmdChain()
+	defer deferFn()
 	mockAuthProvider := auth.NewAuthFileProvider(def.ConfProfile{})
+	rm, _ := internal.JsonMarshal(map[string]string{"id": "root", "name": "mock_name"})
+	rmChild, _ := internal.JsonMarshal(map[string]string{"id": "root/child/child"})
+	rmExtracted, _ := internal.JsonMarshal("root/child")
+	rmJsonPath, _ := internal.ParseJsonPath(rm, "$")
+
+	// Two steps of cloud commands, the second one derives its id from the response of the first one
+	chainOf := func(step def.ConfExtractCmd) []def.ConfExtractCmd {
+		nextStep := step
+		nextStep.IdJsonPath = "$.id"
+		return []def.ConfExtractCmd{step, nextStep}
+	}
+	paramStep := def.ConfExtractCmd{IdParamName: "mock_name", IdParamType: def.PARAM_STRING}
 
 	type args struct {
-		authProvider auth.IAuthProvider
-		cloudType    def.CloudType
-		id           string
+		previousData CheckerProp
 		conf         *def.ConfExtractCmd
+		cloudType    def.CloudType
 	}
 	tests := []struct {
 		name    string
 		args    args
-		want    *json.RawMessage
+		want    *CheckerProp
 		wantErr bool
 	}{
 		{
 			"Valid result of TencentCloud",
-			args{
-				mockAuthProvider, def.TENCENT_CLOUD, "mock",
-				&def.ConfExtractCmd{IdParamName: "mock_name", IdParamType: def.PARAM_STRING},
-			},
-			rm,
+			args{CheckerProp{Prop: rm}, &def.ConfExtractCmd{IdJsonPath: "$.id", CmdChain: chainOf(paramStep)}, def.TENCENT_CLOUD},
+			&CheckerProp{Id: "root", Prop: rmChild},
 			false,
 		},
 		{
 			"Valid result of TencentCOS",
-			args{
-				mockAuthProvider, def.TENCENT_COS, "mock", &def.ConfExtractCmd{},
-			},
-			rm,
+			args{CheckerProp{Prop: rm}, &def.ConfExtractCmd{IdJsonPath: "$.id", CmdChain: chainOf(def.ConfExtractCmd{})}, def.TENCENT_COS},
+			&CheckerProp{Id: "root", Prop: rmChild},
 			false,
 		},
 		{
-			"Valid result of AliyunCloud",
-			args{
-				mockAuthProvider, def.ALIYUN_CLOUD, "mock",
-				&def.ConfExtractCmd{IdParamName: "mock_name", IdParamType: def.PARAM_STRING},
-			},
-			rm,
+			"Valid result of Aliyun",
+			args{CheckerProp{Prop: rm}, &def.ConfExtractCmd{IdJsonPath: "$.id", CmdChain: chainOf(paramStep)}, def.ALIYUN_CLOUD},
+			&CheckerProp{Id: "root", Prop: rmChild},
 			false,
 		},
 		{
 			"Valid result of AliyunOSS",
-			args{
-				mockAuthProvider, def.ALIYUN_OSS, "mock", &def.ConfExtractCmd{},
-			},
-			rm,
+			args{CheckerProp{Prop: rm}, &def.ConfExtractCmd{IdJsonPath: "$.id", CmdChain: chainOf(def.ConfExtractCmd{})}, def.ALIYUN_OSS},
+			&CheckerProp{Id: "root", Prop: rmChild},
 			false,
 		},
 		{
 			"Valid result of Azure",
-			args{
-				mockAuthProvider, def.AZURE, "mock", &def.ConfExtractCmd{},
-			},
-			rm,
+			args{CheckerProp{Prop: rm}, &def.ConfExtractCmd{IdJsonPath: "$.id", CmdChain: chainOf(def.ConfExtractCmd{})}, def.AZURE},
+			&CheckerProp{Id: "root", Prop: rmChild},
 			false,
 		},
 		{
-			"missing IdParamName for getting prop from tencent cloud",
-			args{
-				mockAuthProvider, def.TENCENT_CLOUD, "mock", &def.ConfExtractCmd{},
-			},
+			"Valid result of AWS",
+			args{CheckerProp{Prop: rm}, &def.ConfExtractCmd{IdJsonPath: "$.id", CmdChain: chainOf(paramStep)}, def.AWS},
+			&CheckerProp{Id: "root", Prop: rmChild},
+			false,
+		},
+		{
+			"Valid result of GCP",
+			args{CheckerProp{Prop: rm}, &def.ConfExtractCmd{IdJsonPath: "$.id", CmdChain: chainOf(
+				def.ConfExtractCmd{GCP: def.ConfGCPCmd{Path: "mock/" + ID_PLACEHOLDER}})}, def.GCP},
+			&CheckerProp{Id: "root", Prop: rmChild},
+			false,
+		},
+		{
+			"Valid result of OpenStack",
+			args{CheckerProp{Prop: rm}, &def.ConfExtractCmd{IdJsonPath: "$.id", CmdChain: chainOf(
+				def.ConfExtractCmd{OpenStack: def.ConfOpenStackCmd{Path: "mock/" + ID_PLACEHOLDER}})}, def.OPENSTACK},
+			&CheckerProp{Id: "root", Prop: rmChild},
+			false,
+		},
+		{
+			"Valid result of HTTP API",
+			args{CheckerProp{Prop: rm}, &def.ConfExtractCmd{IdJsonPath: "$.id", CmdChain: chainOf(
+				def.ConfExtractCmd{HttpApi: def.ConfHttpCmd{URL: "mock/" + ID_PLACEHOLDER}})}, def.HTTP_API},
+			&CheckerProp{Id: "root", Prop: rmChild},
+			false,
+		},
+		{
+			"Valid result of k8s",
+			args{CheckerProp{Prop: rm}, &def.ConfExtractCmd{IdJsonPath: "$.id", CmdChain: chainOf(
+				def.ConfExtractCmd{K8sGet: def.ConfK8sGetCmd{Resource: "mock"}})}, def.K8S},
+			&CheckerProp{Id: "root", Prop: rmChild},
+			false,
+		},
+		{
+			"Valid result with jsonpath step, name and NormalizeId",
+			args{CheckerProp{Prop: rm}, &def.ConfExtractCmd{
+				IdJsonPath: "$.id", NameJsonPath: "$.name", NormalizeId: true,
+				CmdChain: []def.ConfExtractCmd{{}, {ExtractJsonPath: def.ConfJsonPathCmd{Path: "$.id"}}},
+			}, def.AZURE},
+			&CheckerProp{Id: "root", Name: "mock_name", Prop: rmExtracted},
+			false,
+		},
+		{
+			"Valid result of nested chain",
+			args{CheckerProp{Prop: rm}, &def.ConfExtractCmd{IdJsonPath: "$.id", CmdChain: []def.ConfExtractCmd{
+				{CmdChain: chainOf(def.ConfExtractCmd{})},
+			}}, def.AZURE},
+			&CheckerProp{Id: "root", Prop: rmChild},
+			false,
+		},
+		{
+			"Failed in step of cloud",
+			args{CheckerProp{Id: "invalid", Prop: rm}, &def.ConfExtractCmd{CmdChain: chainOf(def.ConfExtractCmd{})}, def.AZURE},
 			nil,
 			true,
 		},
 		{
-			"missing IdParamName for getting prop from aliyun",
-			args{
-				mockAuthProvider, def.ALIYUN_CLOUD, "mock", &def.ConfExtractCmd{},
-			},
+			"Failed to derive id in step",
+			args{CheckerProp{Prop: rm}, &def.ConfExtractCmd{IdJsonPath: "$.id", CmdChain: []def.ConfExtractCmd{
+				{}, {IdJsonPath: "$.not_exists"},
+			}}, def.AZURE},
 			nil,
 			true,
 		},
 		{
-			"invalid cloud type",
-			args{
-				mockAuthProvider, "invalid", "mock", &def.ConfExtractCmd{},
-			},
+			"Cycle detected",
+			args{CheckerProp{Prop: rm}, &def.ConfExtractCmd{IdJsonPath: "$.id", CmdChain: []def.ConfExtractCmd{
+				{}, {IdConst: "root"},
+			}}, def.AZURE},
+			nil,
+			true,
+		},
+		{
+			"Valid result of same jsonpath steps with inherited id",
+			args{CheckerProp{Prop: rm}, &def.ConfExtractCmd{IdJsonPath: "$.id", CmdChain: []def.ConfExtractCmd{
+				{ExtractJsonPath: def.ConfJsonPathCmd{Path: "$"}}, {ExtractJsonPath: def.ConfJsonPathCmd{Path: "$"}},
+			}}, def.AZURE},
+			&CheckerProp{Id: "root", Prop: rmJsonPath},
+			false,
+		},
+		{
+			"Depth exceeds the limit",
+			args{CheckerProp{Prop: rm}, &def.ConfExtractCmd{IdJsonPath: "$.id", CmdChain: []def.ConfExtractCmd{
+				{CmdChain: []def.ConfExtractCmd{{CmdChain: []def.ConfExtractCmd{{CmdChain: []def.ConfExtractCmd{
+					{CmdChain: []def.ConfExtractCmd{{CmdChain: []def.ConfExtractCmd{{}}}}},
+				}}}}}},
+			}}, def.AZURE},
 			nil,
 			true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := getPropWithCloud(tt.args.authProvider, tt.args.cloudType, tt.args.id, tt.args.conf)
+			got, err := getPropWithCmd(mockAuthProvider, tt.args.previousData, tt.args.conf, tt.args.cloudType)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("getPropWithCloud() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("getPropWithCmd() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("getPropWithCloud() = %v, want %v", got, tt.want)
+				t.Errorf("getPropWithCmd() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
-func TestChecker_Validate(t *testing.T) {
+func Test_getPropWithK8s(t *testing.T) {
+	patches := gomonkey.ApplyFunc(connector.CallK8sRaw,
+		func(authProvider auth.IAuthProvider, kubeContext string, path string, extraParam map[string]any) (*json.RawMessage, error) {
+			return internal.JsonMarshal(map[string]any{"context": kubeContext, "path": path, "param": extraParam})
+		})
+	defer patches.Reset()
+	patches.ApplyFunc(connector.CallK8sGet,
+		func(authProvider auth.IAuthProvider, kubeContext string, namespace string, group string, version string, resource string,
+			name string, subresource string, ignoreNotFound bool) (*json.RawMessage, error) {
+			return internal.JsonMarshal(map[string]string{"name": name})
+		})
+	mockAuthProvider := auth.NewAuthFileProvider(def.ConfProfile{})
 	rm, _ := internal.JsonMarshal("mock")
+	rmRaw, _ := internal.JsonMarshal(map[string]any{
+		"context": "a.yaml:dev", "path": "/api/v1/nodes/node-1/proxy/configz", "param": map[string]any{"mock": "value"},
+	})
+	rmObject, _ := internal.JsonMarshal(map[string]string{"name": "node-1"})
 
 	type args struct {
-		data CheckerPropList
+		authProvider auth.IAuthProvider
+		checkerProp  *CheckerProp
+		conf         *def.ConfExtractCmd
 	}
 	tests := []struct {
 		name    string
-		c       *Checker
-		v       def.ConfValidator
 		args    args
-		want    []*ValidateResult
+		want    *json.RawMessage
 		wantErr bool
 	}{
 		{
-			"Valid result",
-			NewChecker(&def.ConfChecker{}, nil, nil),
-			def.ConfValidator{
-				ValidateSchema: `{"type": "string"}`,
-			},
-			args{CheckerPropList{
-				{Id: "mock_id", Prop: rm}},
-			},
-			[]*ValidateResult{
-				{Id: "mock_id", InRisk: true},
-			},
+			"Valid result of raw path",
+			args{mockAuthProvider, &CheckerProp{Id: "node-1", K8sContext: "a.yaml:dev", Prop: rm}, &def.ConfExtractCmd{
+				K8sRaw: def.ConfK8sRawCmd{Path: "/api/v1/nodes/" + ID_PLACEHOLDER + "/proxy/configz", ExtraParam: map[string]any{"mock": "value"}},
+			}},
+			rmRaw,
 			false,
 		},
 		{
-			"Valid result with Value",
-			NewChecker(&def.ConfChecker{}, nil, nil),
-			def.ConfValidator{
-				ValidateSchema: `{"type": "string"}`,
-				ValueJsonPath:  "$",
-			},
-			args{CheckerPropList{
-				{Id: "mock_id", Prop: rm}},
-			},
-			[]*ValidateResult{
-				{Id: "mock_id", InRisk: true, Value: "mock"},
-			},
+			"Valid result of object",
+			args{mockAuthProvider, &CheckerProp{Id: "node-1", Prop: rm}, &def.ConfExtractCmd{
+				K8sGet: def.ConfK8sGetCmd{Resource: "nodes"},
+			}},
+			rmObject,
 			false,
 		},
 		{
-			"Failed to validate prop",
+			"missing id in raw path",
+			args{mockAuthProvider, &CheckerProp{Id: "node-1", Prop: rm}, &def.ConfExtractCmd{
+				K8sRaw: def.ConfK8sRawCmd{Path: "/api/v1/nodes"},
+			}},
+			nil,
+			true,
+		},
+		{
+			"nil pointor of IAuthProvider",
+			args{nil, &CheckerProp{Id: "node-1", Prop: rm}, &def.ConfExtractCmd{
+				K8sGet: def.ConfK8sGetCmd{Resource: "nodes"},
+			}},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getPropWithK8s(tt.args.authProvider, tt.args.checkerProp, tt.args.conf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getPropWithK8s() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getPropWithK8s() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getK8sObject(t *testing.T) {
+	patches := gomonkey.ApplyFunc(connector.CallK8sGet,
+		func(authProvider auth.IAuthProvider, kubeContext string, namespace string, group string, version string, resource string,
+			name string, subresource string, ignoreNotFound bool) (*json.RawMessage, error) {
+			switch name {
+			case "invalid":
+				return nil, errors.New("mock error")
+			case "not_found":
+				// null replayed from cassette
+				return nil, nil
+			}
+			return internal.JsonMarshal(map[string]string{
+				"context": kubeContext, "namespace": namespace, "resource": resource, "name": name, "subresource": subresource,
+			})
+		})
+	defer patches.Reset()
+	mockAuthProvider := auth.NewAuthFileProvider(def.ConfProfile{})
+	rm, _ := internal.JsonMarshal(map[string]any{"metadata": map[string]string{"namespace": "mock_ns"}})
+	rmNull, _ := internal.JsonMarshal(nil)
+	fnWant := func(kubeContext string, namespace string, subresource string) *json.RawMessage {
+		res, _ := internal.JsonMarshal(map[string]string{
+			"context": kubeContext, "namespace": namespace, "resource": "roles", "name": "mock_role", "subresource": subresource,
+		})
+		return res
+	}
+
+	type args struct {
+		authProvider auth.IAuthProvider
+		checkerProp  *CheckerProp
+		conf         *def.ConfK8sGetCmd
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *json.RawMessage
+		wantErr bool
+	}{
+		{
+			"Valid result",
+			args{mockAuthProvider, &CheckerProp{Id: "mock_role", Prop: rm}, &def.ConfK8sGetCmd{Namespace: "default", Resource: "roles"}},
+			fnWant("", "default", ""),
+			false,
+		},
+		{
+			"Valid result with NamespaceJsonPath",
+			args{mockAuthProvider, &CheckerProp{Id: "mock_role", Prop: rm},
+				&def.ConfK8sGetCmd{Namespace: "default", NamespaceJsonPath: "$.metadata.namespace", Resource: "roles"}},
+			fnWant("", "mock_ns", ""),
+			false,
+		},
+		{
+			"Valid result with context and subresource",
+			args{mockAuthProvider, &CheckerProp{Id: "mock_role", K8sContext: "a.yaml:dev", Prop: rm},
+				&def.ConfK8sGetCmd{Resource: "roles", Subresource: "status"}},
+			fnWant("a.yaml:dev", "", "status"),
+			false,
+		},
+		{
+			"Valid result of object not found",
+			args{mockAuthProvider, &CheckerProp{Id: "not_found", Prop: rm}, &def.ConfK8sGetCmd{Resource: "roles", IgnoreNotFound: true}},
+			rmNull,
+			false,
+		},
+		{
+			"failed to get namespace",
+			args{mockAuthProvider, &CheckerProp{Id: "mock_role", Prop: rm},
+				&def.ConfK8sGetCmd{NamespaceJsonPath: "$.undefined", Resource: "roles"}},
+			nil,
+			true,
+		},
+		{
+			"failed to get object",
+			args{mockAuthProvider, &CheckerProp{Id: "invalid", Prop: rm}, &def.ConfK8sGetCmd{Resource: "roles"}},
+			nil,
+			true,
+		},
+		{
+			"missing resource",
+			args{mockAuthProvider, &CheckerProp{Id: "mock_role", Prop: rm}, &def.ConfK8sGetCmd{}},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getK8sObject(tt.args.authProvider, tt.args.checkerProp, tt.args.conf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getK8sObject() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getK8sObject() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getPropWithCloud(t *testing.T) {
+	rm, _ := internal.JsonMarshal("mock")
+	patchCallTencentCloud := gomonkey.ApplyFunc(connector.CallTencentCloud,
+		func(authProvider auth.IAuthProvider, service string, version string, action string, extraParam map[string]any) (*json.RawMessage, error) {
+			return rm, nil
+		})
+	defer patchCallTencentCloud.Reset()
+	patchCallTencentCOS := gomonkey.ApplyFunc(connector.CallTencentCOS,
+		func(authProvider auth.IAuthProvider, bucketName string, service string, action string) (*json.RawMessage, error) {
+			return rm, nil
+		})
+	defer patchCallTencentCOS.Reset()
+	patchCallAliyunCloud := gomonkey.ApplyFunc(connector.CallAliyunCloud,
+		func(authProvider auth.IAuthProvider, endpoint string, bEpWithRegion bool, version string, action string, extraParam map[string]any) (*json.RawMessage, error) {
+			return rm, nil
+		})
+	defer patchCallAliyunCloud.Reset()
+	patchCallAliyunOSS := gomonkey.ApplyFunc(connector.CallAliyunOSS,
+		func(authProvider auth.IAuthProvider, bucketName string, action string, extraParam map[string]any) (*json.RawMessage, error) {
+			return rm, nil
+		})
+	defer patchCallAliyunOSS.Reset()
+	patchCallAzure := gomonkey.ApplyFunc(connector.CallAzureWithEndpoint,
+		func(authProvider auth.IAuthProvider, version string, endpoint string, action string) (*json.RawMessage, error) {
+			return rm, nil
+		})
+	defer patchCallAzure.Reset()
+	patchCallAzureGraph := gomonkey.ApplyFunc(connector.CallAzureGraph,
+		func(authProvider auth.IAuthProvider, version string, endpoint string, action string, extraParam map[string]any) (
+			*json.RawMessage, error) {
+			if endpoint != "users/mock" && endpoint != "users/mock%2Fid%3Fx=1" {
+				return nil, errors.New("mock error")
+			}
+			return rm, nil
+		})
+	defer patchCallAzureGraph.Reset()
+	patchCallAWS := gomonkey.ApplyFunc(connector.CallAWS,
+		func(authProvider auth.IAuthProvider, service string, version string, action string, extraParam map[string]any) (*json.RawMessage, error) {
+			return rm, nil
+		})
+	defer patchCallAWS.Reset()
+	patchCallGCP := gomonkey.ApplyFunc(connector.CallGCP,
+		func(authProvider auth.IAuthProvider, service string, version string, method string, path string, extraParam map[string]any) (
+			*json.RawMessage, error) {
+			if strings.Contains(path, "mock/escaped") {
+				return nil, errors.New("id not escaped")
+			}
+			return rm, nil
+		})
+	defer patchCallGCP.Reset()
+	patchCallOpenStack := gomonkey.ApplyFunc(connector.CallOpenStack,
+		func(authProvider auth.IAuthProvider, service string, path string, extraParam map[string]any) (*json.RawMessage, error) {
+			if strings.Contains(path, "mock/escaped") {
+				return nil, errors.New("id not escaped")
+			}
+			return rm, nil
+		})
+	defer patchCallOpenStack.Reset()
+	patchCallHttpApi := gomonkey.ApplyFunc(connector.CallHttpApi,
+		func(authProvider auth.IAuthProvider, cmd *def.ConfHttpCmd, nextLink string, param map[string]any) (
+			*json.RawMessage, string, error) {
+			if strings.Contains(cmd.URL, "mock/escaped") {
+				return nil, "", errors.New("id not escaped")
+			}
+			return rm, "", nil
+		})
+	defer patchCallHttpApi.Reset()
+	mockAuthProvider := auth.NewAuthFileProvider(def.ConfProfile{})
+
+	type args struct {
+		authProvider auth.IAuthProvider
+		cloudType    def.CloudType
+		id           string
+		region       string
+		conf         *def.ConfExtractCmd
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *json.RawMessage
+		wantErr bool
+	}{
+		{
+			"Valid result of TencentCloud",
+			args{
+				mockAuthProvider, def.TENCENT_CLOUD, "mock", "",
+				&def.ConfExtractCmd{IdParamName: "mock_name", IdParamType: def.PARAM_STRING},
+			},
+			rm,
+			false,
+		},
+		{
+			"Valid result of TencentCOS",
+			args{
+				mockAuthProvider, def.TENCENT_COS, "mock", "", &def.ConfExtractCmd{},
+			},
+			rm,
+			false,
+		},
+		{
+			"Valid result of AliyunCloud",
+			args{
+				mockAuthProvider, def.ALIYUN_CLOUD, "mock", "",
+				&def.ConfExtractCmd{IdParamName: "mock_name", IdParamType: def.PARAM_STRING},
+			},
+			rm,
+			false,
+		},
+		{
+			"Valid result of AliyunOSS",
+			args{
+				mockAuthProvider, def.ALIYUN_OSS, "mock", "", &def.ConfExtractCmd{},
+			},
+			rm,
+			false,
+		},
+		{
+			"Valid result of Azure",
+			args{
+				mockAuthProvider, def.AZURE, "mock", "", &def.ConfExtractCmd{},
+			},
+			rm,
+			false,
+		},
+		{
+			"Valid result of Microsoft Graph of Azure",
+			args{
+				mockAuthProvider, def.AZURE, "mock", "",
+				&def.ConfExtractCmd{Azure: def.ConfAzureCmd{Api: def.AZURE_API_GRAPH, RsType: "users"}},
+			},
+			rm,
+			false,
+		},
+		{
+			"Valid result of Microsoft Graph of Azure with id escaped",
+			args{
+				mockAuthProvider, def.AZURE, "mock/id?x=1", "",
+				&def.ConfExtractCmd{Azure: def.ConfAzureCmd{Api: def.AZURE_API_GRAPH, RsType: "users"}},
+			},
+			rm,
+			false,
+		},
+		{
+			"unknown api of Azure",
+			args{
+				mockAuthProvider, def.AZURE, "mock", "", &def.ConfExtractCmd{Azure: def.ConfAzureCmd{Api: "invalid"}},
+			},
+			nil,
+			true,
+		},
+		{
+			"missing IdParamName for getting prop from tencent cloud",
+			args{
+				mockAuthProvider, def.TENCENT_CLOUD, "mock", "", &def.ConfExtractCmd{},
+			},
+			nil,
+			true,
+		},
+		{
+			"missing IdParamName for getting prop from aliyun",
+			args{
+				mockAuthProvider, def.ALIYUN_CLOUD, "mock", "", &def.ConfExtractCmd{},
+			},
+			nil,
+			true,
+		},
+		{
+			"Valid result of AWS",
+			args{
+				mockAuthProvider, def.AWS, "mock", "",
+				&def.ConfExtractCmd{IdParamName: "mock_name", IdParamType: def.PARAM_STRING},
+			},
+			rm,
+			false,
+		},
+		{
+			"missing IdParamName for getting prop from AWS",
+			args{
+				mockAuthProvider, def.AWS, "mock", "", &def.ConfExtractCmd{},
+			},
+			nil,
+			true,
+		},
+		{
+			"Valid result of GCP with id in path",
+			args{
+				mockAuthProvider, def.GCP, "mock", "",
+				&def.ConfExtractCmd{GCP: def.ConfGCPCmd{Path: "serviceAccounts/{id}/keys"}},
+			},
+			rm,
+			false,
+		},
+		{
+			"Valid result of GCP with id escaped in path",
+			args{
+				mockAuthProvider, def.GCP, "mock/escaped", "",
+				&def.ConfExtractCmd{GCP: def.ConfGCPCmd{Path: "serviceAccounts/{id}/keys"}},
+			},
+			rm,
+			false,
+		},
+		{
+			"Valid result of GCP with IdParamName",
+			args{
+				mockAuthProvider, def.GCP, "mock", "",
+				&def.ConfExtractCmd{IdParamName: "mock_name", IdParamType: def.PARAM_STRING},
+			},
+			rm,
+			false,
+		},
+		{
+			"missing IdParamName or {id} in path for getting prop from GCP",
+			args{
+				mockAuthProvider, def.GCP, "mock", "", &def.ConfExtractCmd{},
+			},
+			nil,
+			true,
+		},
+		{
+			"Valid result of OpenStack with id in path",
+			args{
+				mockAuthProvider, def.OPENSTACK, "mock", "",
+				&def.ConfExtractCmd{OpenStack: def.ConfOpenStackCmd{Path: "servers/{id}"}},
+			},
+			rm,
+			false,
+		},
+		{
+			"Valid result of OpenStack with id escaped in path",
+			args{
+				mockAuthProvider, def.OPENSTACK, "mock/escaped", "",
+				&def.ConfExtractCmd{OpenStack: def.ConfOpenStackCmd{Path: "servers/{id}"}},
+			},
+			rm,
+			false,
+		},
+		{
+			"Valid result of OpenStack with IdParamName",
+			args{
+				mockAuthProvider, def.OPENSTACK, "mock", "",
+				&def.ConfExtractCmd{IdParamName: "mock_name", IdParamType: def.PARAM_STRING},
+			},
+			rm,
+			false,
+		},
+		{
+			"missing IdParamName or {id} in path for getting prop from OpenStack",
+			args{
+				mockAuthProvider, def.OPENSTACK, "mock", "", &def.ConfExtractCmd{},
+			},
+			nil,
+			true,
+		},
+		{
+			"Valid result of HTTP API with id in url",
+			args{
+				mockAuthProvider, def.HTTP_API, "mock", "",
+				&def.ConfExtractCmd{HttpApi: def.ConfHttpCmd{URL: "repos/{id}"}},
+			},
+			rm,
+			false,
+		},
+		{
+			"Valid result of HTTP API with id escaped in url",
+			args{
+				mockAuthProvider, def.HTTP_API, "mock/escaped", "",
+				&def.ConfExtractCmd{HttpApi: def.ConfHttpCmd{URL: "repos/{id}"}},
+			},
+			rm,
+			false,
+		},
+		{
+			"Valid result of HTTP API with IdParamName",
+			args{
+				mockAuthProvider, def.HTTP_API, "mock", "",
+				&def.ConfExtractCmd{IdParamName: "mock_name", IdParamType: def.PARAM_STRING},
+			},
+			rm,
+			false,
+		},
+		{
+			"missing IdParamName or {id} in url for getting prop from HTTP API",
+			args{
+				mockAuthProvider, def.HTTP_API, "mock", "", &def.ConfExtractCmd{},
+			},
+			nil,
+			true,
+		},
+		{
+			"invalid cloud type",
+			args{
+				mockAuthProvider, "invalid", "mock", "", &def.ConfExtractCmd{},
+			},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getPropWithCloud(tt.args.authProvider, tt.args.cloudType, tt.args.id, tt.args.region, tt.args.conf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getPropWithCloud() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getPropWithCloud() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChecker_Validate(t *testing.T) {
+	rm, _ := internal.JsonMarshal("mock")
+
+	type args struct {
+		data CheckerPropList
+	}
+	tests := []struct {
+		name    string
+		c       *Checker
+		v       def.ConfValidator
+		args    args
+		want    []*ValidateResult
+		wantErr bool
+	}{
+		{
+			"Valid result",
+			NewChecker(&def.ConfChecker{}, nil, nil),
+			def.ConfValidator{
+				ValidateSchema: `{"type": "string"}`,
+			},
+			args{CheckerPropList{
+				{Id: "mock_id", Prop: rm}},
+			},
+			[]*ValidateResult{
+				{Id: "mock_id", InRisk: true, Outcome: OUTCOME_FAIL},
+			},
+			false,
+		},
+		{
+			"Valid result with Value",
+			NewChecker(&def.ConfChecker{}, nil, nil),
+			def.ConfValidator{
+				ValidateSchema: `{"type": "string"}`,
+				ValueJsonPath:  "$",
+			},
+			args{CheckerPropList{
+				{Id: "mock_id", Prop: rm}},
+			},
+			[]*ValidateResult{
+				{Id: "mock_id", InRisk: true, Outcome: OUTCOME_FAIL, Value: "mock"},
+			},
+			false,
+		},
+		{
+			"Valid result with Region",
+			NewChecker(&def.ConfChecker{}, nil, nil),
+			def.ConfValidator{
+				ValidateSchema: `{"type": "string"}`,
+			},
+			args{CheckerPropList{
+				{Id: "mock_id", Region: "region-1", Prop: rm}},
+			},
+			[]*ValidateResult{
+				{Id: "mock_id", Region: "region-1", InRisk: true, Outcome: OUTCOME_FAIL},
+			},
+			false,
+		},
+		{
+			"Valid result with Reason",
+			NewChecker(&def.ConfChecker{}, nil, nil),
+			def.ConfValidator{
+				ValidateSchema: `{"anyOf": [{"type": "number"}, {"type": "string", "title": "mock title"}]}`,
+				Reason:         true,
+			},
+			args{CheckerPropList{
+				{Id: "mock_id", Prop: rm}},
+			},
+			[]*ValidateResult{
+				{Id: "mock_id", InRisk: true, Outcome: OUTCOME_FAIL, Reason: "$ matched #/anyOf/1: mock title"},
+			},
+			false,
+		},
+		{
+			"Valid result with cel",
+			NewChecker(&def.ConfChecker{}, nil, nil),
+			def.ConfValidator{
+				ValidateCel: `prop == "mock"`,
+			},
+			args{CheckerPropList{
+				{Id: "mock_id", Prop: rm}},
+			},
+			[]*ValidateResult{
+				{Id: "mock_id", InRisk: true, Outcome: OUTCOME_FAIL},
+			},
+			false,
+		},
+		{
+			"Valid result with rego",
+			NewChecker(&def.ConfChecker{}, nil, nil),
+			def.ConfValidator{
+				ValidateRego: "package mock\ndeny[msg] { input == \"mock\"; msg := \"mock message\" }",
+				Reason:       true,
+			},
+			args{CheckerPropList{
+				{Id: "mock_id", Prop: rm}},
+			},
+			[]*ValidateResult{
+				{Id: "mock_id", InRisk: true, Outcome: OUTCOME_FAIL, Reason: "mock message"},
+			},
+			false,
+		},
+		{
+			"Valid result of not applicable",
+			NewChecker(&def.ConfChecker{}, nil, nil),
+			def.ConfValidator{
+				ValidateSchema: `{"type": "string"}`,
+				ValueJsonPath:  "$",
+			},
+			args{CheckerPropList{
+				{Region: "region-1", NotApplicable: "constraint not satisfied", Prop: rm}},
+			},
+			[]*ValidateResult{
+				{Region: "region-1", Outcome: OUTCOME_NOT_APPLICABLE, Value: "constraint not satisfied"},
+			},
+			false,
+		},
+		{
+			"Valid result with pass",
+			NewChecker(&def.ConfChecker{}, nil, nil),
+			def.ConfValidator{
+				ValidateSchema: `{"type": "number"}`,
+			},
+			args{CheckerPropList{
+				{Id: "mock_id", Prop: rm}},
+			},
+			[]*ValidateResult{
+				{Id: "mock_id", Outcome: OUTCOME_PASS},
+			},
+			false,
+		},
+		{
+			"Valid result of error of getting prop",
+			NewChecker(&def.ConfChecker{}, nil, nil),
+			def.ConfValidator{
+				ValidateSchema: `{"type": "string"}`,
+			},
+			args{CheckerPropList{
+				{Error: "mock error"}},
+			},
+			[]*ValidateResult{
+				{Outcome: OUTCOME_ERROR, Error: "mock error"},
+			},
+			false,
+		},
+		{
+			"Valid result of error of listing in region",
+			NewChecker(&def.ConfChecker{}, nil, nil),
+			def.ConfValidator{
+				ValidateSchema: `{"type": "string"}`,
+			},
+			args{CheckerPropList{
+				{Region: "region-1", Error: "failed to list data in region region-1", Prop: rm}},
+			},
+			[]*ValidateResult{
+				{Region: "region-1", Outcome: OUTCOME_ERROR, Error: "failed to list data in region region-1"},
+			},
+			false,
+		},
+		{
+			"Failed to create jsonschema",
+			NewChecker(&def.ConfChecker{}, nil, nil),
+			def.ConfValidator{
+				ValidateSchema: "",
+			},
+			args{CheckerPropList{
+				{Id: "mock_id", Prop: rm}},
+			},
+			[]*ValidateResult{
+				{Id: "mock_id", Outcome: OUTCOME_ERROR, Error: "failed to create jsonschema: EOF"},
+			},
+			false,
+		},
+		{
+			"Failed to validate prop",
 			NewChecker(&def.ConfChecker{}, nil, nil),
 			def.ConfValidator{
 				ValidateSchema: `{"type": "string"}`,
@@ -553,7 +1529,9 @@ func TestChecker_Validate(t *testing.T) {
 			args{CheckerPropList{
 				{Id: "mock_id", Prop: &json.RawMessage{}}},
 			},
-			[]*ValidateResult{},
+			[]*ValidateResult{
+				{Id: "mock_id", Outcome: OUTCOME_ERROR, Error: "failed to validate prop: EOF"},
+			},
 			false,
 		},
 		{
@@ -566,7 +1544,9 @@ func TestChecker_Validate(t *testing.T) {
 			args{CheckerPropList{
 				{Id: "mock_id", Prop: rm}},
 			},
-			[]*ValidateResult{},
+			[]*ValidateResult{
+				{Id: "mock_id", Outcome: OUTCOME_ERROR, Error: "failed to get actual value with jsonPath: path: $ expected"},
+			},
 			false,
 		},
 	}
@@ -613,9 +1593,144 @@ func TestChecker_createValidator(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.c.conf.Validator = tt.v
-			if err := tt.c.createValidator(); (err != nil) != tt.wantErr {
+			if _, err := tt.c.createValidator(tt.v.DynValidateValue); (err != nil) != tt.wantErr {
 				t.Errorf("Checker.createValidator() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
+
+func TestChecker_createValidator_cache(t *testing.T) {
+	c := NewChecker(&def.ConfChecker{Validator: def.ConfValidator{
+		ValidateSchema:   `{"enum": ["%v%"]}`,
+		DynValidateValue: map[string]string{"v": "value"},
+	}}, nil, nil)
+
+	cv1, err := c.createValidator(map[string]string{"v": "value"})
+	if err != nil {
+		t.Fatalf("Checker.createValidator() error = %v", err)
+	}
+	cv2, _ := c.createValidator(map[string]string{"v": "value"})
+	if cv1 != cv2 {
+		t.Errorf("Checker.createValidator() created again with the same params")
+	}
+	cv3, _ := c.createValidator(map[string]string{"v": "other"})
+	if cv1 == cv3 {
+		t.Errorf("Checker.createValidator() not created with different params")
+	}
+
+	// Params requested are arbitrary, but the cache is bounded
+	for i := 0; i < MAX_CACHED_VALIDATORS; i++ {
+		c.createValidator(map[string]string{"v": fmt.Sprintf("requested-%d", i)})
+	}
+	if count := c.validators.Len(); count != MAX_CACHED_VALIDATORS {
+		t.Errorf("Checker.createValidator() cached %d validators, want %d", count, MAX_CACHED_VALIDATORS)
+	}
+	if cv4, _ := c.createValidator(map[string]string{"v": "value"}); cv1 == cv4 {
+		t.Errorf("Checker.createValidator() not evicted the least recently used one")
+	}
+}
+
+func TestChecker_Validate_params(t *testing.T) {
+	rm, _ := internal.JsonMarshal(map[string]any{"length": 14})
+	authProvider := auth.NewAuthFileProvider(def.ConfProfile{})
+	patches := gomonkey.ApplyFunc(getProfileParams, func(p auth.IAuthProvider, _ def.CloudType) (map[string]string, error) {
+		if p != authProvider {
+			return nil, nil
+		}
+		return map[string]string{"min_length": "16", "unused": "1"}, nil
+	})
+	defer patches.Reset()
+
+	type args struct {
+		opts []ValidateOption
+	}
+	tests := []struct {
+		name         string
+		authProvider auth.IAuthProvider
+		args         args
+		want         bool
+	}{
+		{
+			"Valid result of params file of profile of Checker",
+			authProvider,
+			args{nil},
+			true,
+		},
+		{
+			"Valid result of params file of profile of options",
+			nil,
+			args{[]ValidateOption{SetValidateAuthProviderOpt(authProvider)}},
+			true,
+		},
+		{
+			"Valid result without params file",
+			nil,
+			args{nil},
+			false,
+		},
+		{
+			"Valid result of params of options",
+			authProvider,
+			args{[]ValidateOption{SetParamsOpt(map[string]string{"min_length": "12"})}},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewChecker(&def.ConfChecker{Validator: def.ConfValidator{
+				ValidateCel:      `prop.length < %min_length%`,
+				DynValidateValue: map[string]string{"min_length": "14"},
+			}}, tt.authProvider, nil)
+			got, err := c.Validate(CheckerPropList{{Id: "mock_id", Prop: rm}}, tt.args.opts...)
+			if err != nil {
+				t.Errorf("Checker.Validate() error = %v", err)
+				return
+			}
+			if len(got) != 1 || got[0].InRisk != tt.want {
+				t.Errorf("Checker.Validate() = %v, want InRisk %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChecker_Validate_time(t *testing.T) {
+	now := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	setupValidatorClock(t, now)
+	rm, _ := internal.JsonMarshal(map[string]any{"created": "2024-01-01T00:00:00Z"})
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want bool
+	}{
+		{
+			"Valid result before the clock moves",
+			now,
+			false,
+		},
+		{
+			"Valid result after the clock moves",
+			now.AddDate(0, 4, 0),
+			true,
+		},
+	}
+	// The same Checker is used, so that validators cached before the clock moves are not reused
+	c := NewChecker(&def.ConfChecker{Validator: def.ConfValidator{
+		ValidateCel:      `timestamp(prop.created) < timestamp("%deadline%")`,
+		DynValidateValue: map[string]string{"deadline": "%NOW-90d%"},
+	}}, nil, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetValidatorClock(func() time.Time { return tt.now })
+			got, err := c.Validate(CheckerPropList{{Id: "mock_id", Prop: rm}})
+			if err != nil {
+				t.Errorf("Checker.Validate() error = %v", err)
+				return
+			}
+			if len(got) != 1 || got[0].InRisk != tt.want {
+				t.Errorf("Checker.Validate() = %v, want InRisk %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,8 +3,12 @@
 package framework
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
 
+	"github.com/s3studio/cloud-bench-checker/internal"
 	"github.com/s3studio/cloud-bench-checker/pkg/auth"
 	"github.com/s3studio/cloud-bench-checker/pkg/connector"
 	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
@@ -12,6 +16,10 @@ import (
 	"github.com/Masterminds/semver/v3"
 )
 
+// RAW_DATA_NOT_APPLICABLE_KEY: Key of the item of raw data standing for a Listor or a scope not satisfying the constraint,
+// with the description of constraint not satisfied as value
+const RAW_DATA_NOT_APPLICABLE_KEY = "_not_applicable"
+
 // ConstraintChecker: Used to check the constraint of a cloud connector
 type ConstraintChecker struct {
 	conf *def.ConfConstraint
@@ -25,40 +33,165 @@ func NewConstraintChecker(conf *def.ConfConstraint) *ConstraintChecker {
 }
 
 // Check: Check the constraint
+//
+// Constraints common to all clouds are checked first, then those of the cloudType.
+// The scope to check against, such as region, subscription or context of k8s,
+// is set by the same options used in GetOnePage, and the scope of profile is used if not set.
 // @param: authProvider: IAuthProvider to provide profile of auth
 // @param: cloudType: Type of cloud that the constraint is associated with
+// @param: opts: Options of scope
 // @return: Empty string if the constraint is satisfied, or description if not satisfied
 // @return: Error
-func (c *ConstraintChecker) Check(authProvider auth.IAuthProvider, cloudType string) (string, error) {
+func (c *ConstraintChecker) Check(authProvider auth.IAuthProvider, cloudType string, opts ...GetPageOption) (string, error) {
+	var optAll getPageOpt
+	for _, opt := range opts {
+		if err := opt(&optAll); err != nil {
+			return "", err
+		}
+	}
+
+	for _, fnCheck := range []func() (string, error){
+		func() (string, error) { return c.checkProfileKeys(authProvider, def.CloudType(cloudType)) },
+		func() (string, error) { return c.checkRegions(authProvider, def.CloudType(cloudType), optAll.region) },
+	} {
+		if checkRes, err := fnCheck(); err != nil || checkRes != "" {
+			return checkRes, err
+		}
+	}
+
 	switch cloudType {
 	case string(def.K8S):
-		if c.conf.ConstraintK8s.Version == "" {
-			// constraint not set
-			return "", nil
+		return c.checkK8s(authProvider, optAll.k8sContext)
+	case string(def.AZURE):
+		return c.checkAzure(authProvider, optAll.subscription)
+	default:
+		// Treated as satisfied if the cloudType has no constraint implement
+		return "", nil
+	}
+}
+
+// checkProfileKeys: Check that all required keys are set in the profile
+func (c *ConstraintChecker) checkProfileKeys(authProvider auth.IAuthProvider, cloudType def.CloudType) (string, error) {
+	if len(c.conf.ProfileKeys) == 0 {
+		// constraint not set
+		return "", nil
+	}
+	if authProvider == nil {
+		return "", errors.New("nil pointor of IAuthProvider")
+	}
+
+	v, err := authProvider.GetProfile(cloudType)
+	if err != nil {
+		return "", err
+	}
+
+	for _, key := range c.conf.ProfileKeys {
+		if !v.IsSet(key) {
+			return fmt.Sprintf("constraint not satisfied, need key %s in profile", key), nil
 		}
+	}
 
-		serverVersion, err := connector.GetK8sVersion(authProvider)
-		if err != nil {
+	return "", nil
+}
+
+// checkRegions: Check that the region is in the allowlist
+// @param: region: Region to check, the region of profile is used if empty
+func (c *ConstraintChecker) checkRegions(authProvider auth.IAuthProvider, cloudType def.CloudType, region string) (
+	string, error) {
+	if len(c.conf.Regions) == 0 {
+		// constraint not set
+		return "", nil
+	}
+
+	if len(region) == 0 {
+		var err error
+		if region, err = connector.GetProfileRegion(authProvider, cloudType); err != nil {
 			return "", err
 		}
+	}
 
-		target, err := semver.NewVersion(serverVersion)
-		if err != nil {
-			return "", fmt.Errorf("failed to parse version: %w", err)
-		}
+	if slices.Contains(c.conf.Regions, region) {
+		return "", nil
+	} else {
+		return fmt.Sprintf("constraint not satisfied, need region in %v, got \"%s\"", c.conf.Regions, region), nil
+	}
+}
+
+// checkK8s: Implementation of checking the constraint of k8s
+// @param: kubeContext: Id of k8s context, the current context of kubeconfig is used if empty
+func (c *ConstraintChecker) checkK8s(authProvider auth.IAuthProvider, kubeContext string) (string, error) {
+	if c.conf.ConstraintK8s.Version == "" {
+		// constraint not set
+		return "", nil
+	}
+
+	serverVersion, err := connector.GetK8sVersionWithContext(authProvider, kubeContext)
+	if err != nil {
+		return "", err
+	}
 
-		constraint, err := semver.NewConstraint(c.conf.ConstraintK8s.Version)
+	target, err := semver.NewVersion(serverVersion)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse version: %w", err)
+	}
+
+	constraint, err := semver.NewConstraint(c.conf.ConstraintK8s.Version)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse version constraint: %w", err)
+	}
+
+	if constraint.Check(target) {
+		return "", nil
+	} else {
+		return fmt.Sprintf("constraint not satisfied, need %s, got %s", c.conf.ConstraintK8s.Version, serverVersion), nil
+	}
+}
+
+// checkAzure: Implementation of checking the constraint of Azure
+//
+// Each resource provider must be registered, and the version of api must be available for its resource type if set.
+// @param: subscription: Id of subscription, the subscription of profile is used if empty
+func (c *ConstraintChecker) checkAzure(authProvider auth.IAuthProvider, subscription string) (string, error) {
+	for _, required := range c.conf.ConstraintAzure.Providers {
+		provider, err := connector.GetAzureResourceProvider(authProvider, subscription, required.Namespace)
 		if err != nil {
-			return "", fmt.Errorf("failed to parse version constraint: %w", err)
+			return "", err
+		}
+		if provider.RegistrationState != "Registered" {
+			return fmt.Sprintf("constraint not satisfied, need provider %s registered, got %s",
+				required.Namespace, provider.RegistrationState), nil
 		}
 
-		if constraint.Check(target) {
-			return "", nil
-		} else {
-			return fmt.Sprintf("constraint not satisfied, need %s, got %s", c.conf.ConstraintK8s.Version, serverVersion), nil
+		if required.RsType == "" {
+			continue
+		}
+		idx := slices.IndexFunc(provider.ResourceTypes, func(rsType connector.AzureProviderResourceType) bool {
+			return rsType.ResourceType == required.RsType
+		})
+		if idx < 0 {
+			return fmt.Sprintf("constraint not satisfied, need resource type %s/%s",
+				required.Namespace, required.RsType), nil
+		}
+		if required.ApiVersion != "" && !slices.Contains(provider.ResourceTypes[idx].ApiVersions, required.ApiVersion) {
+			return fmt.Sprintf("constraint not satisfied, need version %s of %s/%s",
+				required.ApiVersion, required.Namespace, required.RsType), nil
 		}
-	default:
-		// Treated as satisfied if the cloudType has no constraint implement
-		return "", nil
 	}
+
+	return "", nil
+}
+
+// newNotApplicableData: Create the item of raw data standing for a Listor or a scope not satisfying the constraint
+// @param: reason: Description of constraint not satisfied
+// @return: Item of raw data
+// @return: Error
+func newNotApplicableData(reason string) (*json.RawMessage, error) {
+	return internal.JsonMarshal(map[string]string{RAW_DATA_NOT_APPLICABLE_KEY: reason})
+}
+
+// getNotApplicableOfRawData: Get description of constraint not satisfied in the item of raw data
+// @param: rawData: Item of raw data
+// @return: Description of constraint not satisfied, or empty string if the item is applicable
+func getNotApplicableOfRawData(rawData *json.RawMessage) string {
+	return getTagOfRawData(rawData, RAW_DATA_NOT_APPLICABLE_KEY)
 }
@@ -3,12 +3,17 @@
 package framework
 
 import (
+	"encoding/json"
+	"errors"
+	"os"
 	"testing"
 
 	"github.com/agiledragon/gomonkey/v2"
+	"github.com/s3studio/cloud-bench-checker/internal"
 	"github.com/s3studio/cloud-bench-checker/pkg/auth"
 	"github.com/s3studio/cloud-bench-checker/pkg/connector"
 	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
+	"github.com/s3studio/cloud-bench-checker/test"
 )
 
 func TestConstraintChecker_Check(t *testing.T) {
@@ -124,3 +129,274 @@ func TestConstraintChecker_Check(t *testing.T) {
 		})
 	}
 }
+
+func TestConstraintChecker_Check_k8sContext(t *testing.T) {
+	patches := gomonkey.ApplyFunc(connector.GetK8sVersionWithContext,
+		func(authProvider auth.IAuthProvider, kubeContext string) (string, error) {
+			switch kubeContext {
+			case "a.yaml:old":
+				return "1.27", nil
+			case "a.yaml:invalid":
+				return "", errors.New("mock error")
+			default:
+				return "1.29", nil
+			}
+		})
+	defer patches.Reset()
+	c := &ConstraintChecker{
+		&def.ConfConstraint{ConstraintK8s: def.ConfConstraintK8s{Version: ">=1.28"}},
+	}
+
+	type args struct {
+		authProvider auth.IAuthProvider
+		kubeContext  string
+	}
+	tests := []struct {
+		name            string
+		c               *ConstraintChecker
+		args            args
+		wantEmptyString bool
+		wantErr         bool
+	}{
+		{
+			"Valid result",
+			c,
+			args{nil, "a.yaml:new"},
+			true,
+			false,
+		},
+		{
+			"Valid result with different version",
+			c,
+			args{nil, "a.yaml:old"},
+			false,
+			false,
+		},
+		{
+			"Valid result with no constraint",
+			&ConstraintChecker{
+				&def.ConfConstraint{},
+			},
+			args{nil, "a.yaml:invalid"},
+			true,
+			false,
+		},
+		{
+			"failed to get version",
+			c,
+			args{nil, "a.yaml:invalid"},
+			true,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.c.Check(tt.args.authProvider, string(def.K8S), SetListorK8sContext(tt.args.kubeContext))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ConstraintChecker.Check() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if (got == "") != tt.wantEmptyString {
+				t.Errorf("ConstraintChecker.Check() = %v, wantEmptyString %v", got, tt.wantEmptyString)
+			}
+		})
+	}
+}
+
+func TestConstraintChecker_Check_common(t *testing.T) {
+	os.Setenv("TENCENTCLOUD_REGION", "ap-guangzhou")
+	os.Setenv("MOCK_CONSTRAINT_KEY", "mock")
+
+	type args struct {
+		authProvider auth.IAuthProvider
+		cloudType    string
+		opts         []GetPageOption
+	}
+	tests := []struct {
+		name            string
+		c               *ConstraintChecker
+		args            args
+		wantEmptyString bool
+		wantErr         bool
+	}{
+		{
+			"Valid result with key set in profile",
+			&ConstraintChecker{&def.ConfConstraint{ProfileKeys: []string{"MOCK_CONSTRAINT_KEY"}}},
+			args{auth.NewAuthFileProvider(test.Test_conf_env), string(def.TENCENT_CLOUD), nil},
+			true,
+			false,
+		},
+		{
+			"Valid result with key not set in profile",
+			&ConstraintChecker{&def.ConfConstraint{ProfileKeys: []string{"MOCK_CONSTRAINT_KEY", "MOCK_CONSTRAINT_UNSET"}}},
+			args{auth.NewAuthFileProvider(test.Test_conf_env), string(def.TENCENT_CLOUD), nil},
+			false,
+			false,
+		},
+		{
+			"Valid result with region of profile in allowlist",
+			&ConstraintChecker{&def.ConfConstraint{Regions: []string{"ap-guangzhou", "ap-shanghai"}}},
+			args{auth.NewAuthFileProvider(test.Test_conf_env), string(def.TENCENT_CLOUD), nil},
+			true,
+			false,
+		},
+		{
+			"Valid result with region of scope not in allowlist",
+			&ConstraintChecker{&def.ConfConstraint{Regions: []string{"ap-guangzhou", "ap-shanghai"}}},
+			args{auth.NewAuthFileProvider(test.Test_conf_env), string(def.TENCENT_CLOUD),
+				[]GetPageOption{SetListorRegion("ap-beijing")}},
+			false,
+			false,
+		},
+		{
+			"Valid result with cloud without region",
+			&ConstraintChecker{&def.ConfConstraint{Regions: []string{"ap-guangzhou"}}},
+			args{nil, string(def.HTTP_API), nil},
+			false,
+			false,
+		},
+		{
+			"failed to get profile",
+			&ConstraintChecker{&def.ConfConstraint{ProfileKeys: []string{"MOCK_CONSTRAINT_KEY"}}},
+			args{auth.NewAuthFileProvider(test.Test_conf_invalid), string(def.TENCENT_CLOUD), nil},
+			true,
+			true,
+		},
+		{
+			"nil pointor of IAuthProvider",
+			&ConstraintChecker{&def.ConfConstraint{Regions: []string{"ap-guangzhou"}}},
+			args{nil, string(def.TENCENT_CLOUD), nil},
+			true,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.c.Check(tt.args.authProvider, tt.args.cloudType, tt.args.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ConstraintChecker.Check() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if (got == "") != tt.wantEmptyString {
+				t.Errorf("ConstraintChecker.Check() = %v, wantEmptyString %v", got, tt.wantEmptyString)
+			}
+		})
+	}
+}
+
+func TestConstraintChecker_Check_azure(t *testing.T) {
+	patches := gomonkey.ApplyFunc(connector.GetAzureResourceProvider,
+		func(authProvider auth.IAuthProvider, subscription string, namespace string) (*connector.AzureResourceProvider, error) {
+			if subscription == "invalid" {
+				return nil, errors.New("mock error")
+			}
+			state := "Registered"
+			if subscription == "sub-unregistered" {
+				state = "NotRegistered"
+			}
+			return &connector.AzureResourceProvider{
+				Namespace:         namespace,
+				RegistrationState: state,
+				ResourceTypes: []connector.AzureProviderResourceType{
+					{ResourceType: "storageAccounts", ApiVersions: []string{"2023-05-01", "2023-01-01"}},
+				},
+			}, nil
+		})
+	defer patches.Reset()
+	fnNewChecker := func(rsType string, apiVersion string) *ConstraintChecker {
+		return &ConstraintChecker{&def.ConfConstraint{ConstraintAzure: def.ConfConstraintAzure{
+			Providers: []def.ConfConstraintAzureProvider{{Namespace: "Microsoft.Storage", RsType: rsType, ApiVersion: apiVersion}},
+		}}}
+	}
+
+	type args struct {
+		subscription string
+	}
+	tests := []struct {
+		name            string
+		c               *ConstraintChecker
+		args            args
+		wantEmptyString bool
+		wantErr         bool
+	}{
+		{
+			"Valid result",
+			fnNewChecker("storageAccounts", "2023-05-01"),
+			args{"sub-1"},
+			true,
+			false,
+		},
+		{
+			"Valid result with provider not registered",
+			fnNewChecker("", ""),
+			args{"sub-unregistered"},
+			false,
+			false,
+		},
+		{
+			"Valid result with resource type not available",
+			fnNewChecker("fileServices", ""),
+			args{"sub-1"},
+			false,
+			false,
+		},
+		{
+			"Valid result with version not available",
+			fnNewChecker("storageAccounts", "2020-01-01"),
+			args{"sub-1"},
+			false,
+			false,
+		},
+		{
+			"failed to get resource provider",
+			fnNewChecker("", ""),
+			args{"invalid"},
+			true,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.c.Check(nil, string(def.AZURE), SetListorSubscription(tt.args.subscription))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ConstraintChecker.Check() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if (got == "") != tt.wantEmptyString {
+				t.Errorf("ConstraintChecker.Check() = %v, wantEmptyString %v", got, tt.wantEmptyString)
+			}
+		})
+	}
+}
+
+func Test_getNotApplicableOfRawData(t *testing.T) {
+	rm, _ := internal.JsonMarshal(map[string]any{"name": "mock"})
+	rmNotApplicable, _ := newNotApplicableData("constraint not satisfied")
+
+	type args struct {
+		rawData *json.RawMessage
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			"Valid result",
+			args{rmNotApplicable},
+			"constraint not satisfied",
+		},
+		{
+			"Valid result of raw data applicable",
+			args{rm},
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getNotApplicableOfRawData(tt.args.rawData); got != tt.want {
+				t.Errorf("getNotApplicableOfRawData() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -36,6 +36,9 @@ type SyncMapDataProvider struct {
 	DataMap sync.Map
 	// sync.Map of cloud_type
 	CtMap sync.Map
+	// sync.Map of error of listing, returned by GetRawDataByListorId
+	// while the Listor has no cloud_type stored and is treated as having no data by Checkers
+	ErrMap sync.Map
 }
 
 // GetRawDataByListorId: Implementation of IDataProvider.GetRawDataByListorId
@@ -43,6 +46,13 @@ type SyncMapDataProvider struct {
 // @return: Raw data of listor
 // @return: Error
 func (p *SyncMapDataProvider) GetRawDataByListorId(listorId int) ([]*json.RawMessage, error) {
+	if value, ok := p.ErrMap.Load(listorId); ok {
+		if err, ok := value.(error); ok {
+			return nil, err
+		}
+		return nil, errors.New("data in the sync.Map is not a type of error")
+	}
+
 	value, ok := p.DataMap.Load(listorId)
 	if !ok {
 		// No data of Listor in the cloud
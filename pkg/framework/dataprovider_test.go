@@ -4,6 +4,7 @@ package framework
 
 import (
 	"encoding/json"
+	"errors"
 	"reflect"
 	"testing"
 
@@ -15,6 +16,8 @@ func TestSyncMapDataProvider_GetRawDataByListorId(t *testing.T) {
 	p := SyncMapDataProvider{}
 	p.DataMap.Store(1, []*json.RawMessage{rm})
 	p.DataMap.Store(2, "invalid")
+	p.ErrMap.Store(3, errors.New("mock error"))
+	p.ErrMap.Store(4, "invalid")
 
 	type args struct {
 		listorId int
@@ -47,6 +50,20 @@ func TestSyncMapDataProvider_GetRawDataByListorId(t *testing.T) {
 			nil,
 			true,
 		},
+		{
+			"Failed to list data",
+			&p,
+			args{3},
+			nil,
+			true,
+		},
+		{
+			"data of map is not type of error",
+			&p,
+			args{4},
+			nil,
+			true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
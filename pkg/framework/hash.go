@@ -27,3 +27,42 @@ func CalcHash(hashType crypto.Hash, obj any) ([]byte, error) {
 
 	return hashInstance.Sum(nil), nil
 }
+
+// deleteEmptyKeys: Remove keys with empty value from the unmarshaled json object before calculating hash
+//
+// Keys added to conf later should be removed if not used, so that the hash of an existing conf remains unchanged.
+// @param: obj: Unmarshaled json object
+// @param: keys: Keys to remove if the value is empty
+func deleteEmptyKeys(obj map[string]any, keys ...string) {
+	for _, key := range keys {
+		if value, ok := obj[key]; ok && isEmptyJsonValue(value) {
+			delete(obj, key)
+		}
+	}
+}
+
+// isEmptyJsonValue: Check whether the unmarshaled json value is empty,
+// including an object with all values empty, such as a struct of conf not set
+func isEmptyJsonValue(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	case float64:
+		return v == 0
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		for _, item := range v {
+			if !isEmptyJsonValue(item) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
@@ -5,6 +5,7 @@ package framework
 import (
 	"crypto"
 	"fmt"
+	"reflect"
 	"testing"
 )
 
@@ -39,3 +40,48 @@ func TestCalcHash(t *testing.T) {
 		})
 	}
 }
+
+func Test_deleteEmptyKeys(t *testing.T) {
+	type args struct {
+		obj  map[string]any
+		keys []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want map[string]any
+	}{
+		{
+			"Remove empty values",
+			args{
+				map[string]any{
+					"Nil": nil, "Str": "", "Bool": false, "Num": 0.0, "List": []any{},
+					"Struct": map[string]any{"Key": "", "Map": nil},
+				},
+				[]string{"Nil", "Str", "Bool", "Num", "List", "Struct"},
+			},
+			map[string]any{},
+		},
+		{
+			"Keep values not empty",
+			args{
+				map[string]any{"Struct": map[string]any{"Key": "mock"}, "List": []any{nil}},
+				[]string{"Struct", "List"},
+			},
+			map[string]any{"Struct": map[string]any{"Key": "mock"}, "List": []any{nil}},
+		},
+		{
+			"Keep keys not specified",
+			args{map[string]any{"Str": ""}, []string{"Other"}},
+			map[string]any{"Str": ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deleteEmptyKeys(tt.args.obj, tt.args.keys...)
+			if !reflect.DeepEqual(tt.args.obj, tt.want) {
+				t.Errorf("deleteEmptyKeys() = %v, want %v", tt.args.obj, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+// Join of items from secondary Listors to items of primary Listors in a Checker
+
+package framework
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/s3studio/cloud-bench-checker/internal"
+	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
+)
+
+// GetCheckerListorId: Get the ids of all Listors used in a Checker,
+// including the primary Listors followed by the secondary Listors of join
+// @param: conf: Definition of Checker
+// @return: Ids of Listors
+func GetCheckerListorId(conf *def.ConfChecker) []int {
+	listorIds := make([]int, 0, len(conf.Listor)+len(conf.Join))
+	listorIds = append(listorIds, conf.Listor...)
+	for _, join := range conf.Join {
+		listorIds = append(listorIds, join.Listor)
+	}
+
+	return listorIds
+}
+
+// joinKey: Key of join within the scope of the item,
+// so that items of different regions, subscriptions or k8s contexts sharing the same key are not matched
+type joinKey struct {
+	region       string
+	subscription string
+	k8sContext   string
+	key          string
+}
+
+// newJoinKey: Create joinKey with the scope tagged in the item of raw data
+func newJoinKey(rawData *json.RawMessage, key string) joinKey {
+	return joinKey{
+		region:       getRegionOfRawData(rawData),
+		subscription: getSubscriptionOfRawData(rawData),
+		k8sContext:   getK8sContextOfRawData(rawData),
+		key:          key,
+	}
+}
+
+// joinIndex: Items of a secondary Listor indexed by key
+type joinIndex struct {
+	conf  *def.ConfJoinListor
+	items map[joinKey][]*json.RawMessage
+}
+
+// buildJoinIndex: Index items of secondary Listors by key within the scope of each item
+// @param: conf: Definition of join
+// @param: fnGetData: Function to get raw data of Listor, returns nil if there is no data in the cloud
+// @return: Index of each secondary Listor
+// @return: Error
+func buildJoinIndex(conf []def.ConfJoinListor, fnGetData func(listorId int) ([]*json.RawMessage, error)) (
+	[]joinIndex, error) {
+	index := make([]joinIndex, len(conf))
+	for i := range conf {
+		join := &conf[i]
+		if len(join.As) == 0 {
+			return nil, fmt.Errorf("missing \"as\" of join with listor %d", join.Listor)
+		}
+		if len(join.KeyJsonPath) == 0 || len(join.PrimaryKeyJsonPath) == 0 {
+			return nil, fmt.Errorf("missing jsonpath of key of join with listor %d", join.Listor)
+		}
+
+		rawData, err := fnGetData(join.Listor)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get data of listor %d to join: %w", join.Listor, err)
+		}
+
+		index[i] = joinIndex{conf: join, items: make(map[joinKey][]*json.RawMessage)}
+		for _, item := range rawData {
+			if getNotApplicableOfRawData(item) != "" || getErrorOfRawData(item) != "" {
+				// Item of scope not applicable or failed has nothing to join
+				continue
+			}
+
+			key, err := internal.ParseJsonPathStr(item, join.KeyJsonPath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get key of item of listor %d to join: %w", join.Listor, err)
+			}
+			if len(key) == 0 {
+				// Item without key never matches
+				continue
+			}
+
+			indexKey := newJoinKey(item, key)
+			index[i].items[indexKey] = append(index[i].items[indexKey], item)
+		}
+	}
+
+	return index, nil
+}
+
+// joinItem: Add matched items of secondary Listors to the item of primary Listor
+//
+// The list of matched items of each secondary Listor is added to the primary item
+// with key defined in "as", and an empty list is added if nothing matched.
+// Only items in the same scope match, except that items without scope, such as global resources, match in any scope.
+// @param: rawData: Item of primary Listor, must be a json object
+// @param: index: Index of secondary Listors
+// @return: Item enriched with matched items
+// @return: Error
+func joinItem(rawData *json.RawMessage, index []joinIndex) (*json.RawMessage, error) {
+	if len(index) == 0 {
+		return rawData, nil
+	}
+	if rawData == nil {
+		return nil, errors.New("nil pointor of item of primary listor to join")
+	}
+
+	var obj map[string]json.RawMessage
+	if err := internal.JsonUnmarshal(*rawData, &obj); err != nil || obj == nil {
+		return nil, errors.New("item of primary listor to join must be a json object")
+	}
+
+	for _, eachIndex := range index {
+		key, err := internal.ParseJsonPathStr(rawData, eachIndex.conf.PrimaryKeyJsonPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get key of item of primary listor to join: %w", err)
+		}
+
+		matched := []*json.RawMessage{}
+		if len(key) > 0 {
+			indexKey := newJoinKey(rawData, key)
+			matched = append(matched, eachIndex.items[indexKey]...)
+			if unscopedKey := (joinKey{key: key}); indexKey != unscopedKey {
+				matched = append(matched, eachIndex.items[unscopedKey]...)
+			}
+		}
+
+		byMatched, err := json.Marshal(matched)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal matched items of listor %d: %w", eachIndex.conf.Listor, err)
+		}
+		obj[eachIndex.conf.As] = byMatched
+	}
+
+	return internal.JsonMarshal(obj)
+}
@@ -0,0 +1,237 @@
+// Join of items from secondary Listors to items of primary Listors in a Checker
+
+package framework
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/s3studio/cloud-bench-checker/internal"
+	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
+)
+
+func TestGetCheckerListorId(t *testing.T) {
+	type args struct {
+		conf *def.ConfChecker
+	}
+	tests := []struct {
+		name string
+		args args
+		want []int
+	}{
+		{
+			"Valid result",
+			args{&def.ConfChecker{Listor: []int{1, 2}}},
+			[]int{1, 2},
+		},
+		{
+			"Valid result with join",
+			args{&def.ConfChecker{Listor: []int{1}, Join: []def.ConfJoinListor{{Listor: 3}, {Listor: 2}}}},
+			[]int{1, 3, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetCheckerListorId(tt.args.conf); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetCheckerListorId() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_buildJoinIndex(t *testing.T) {
+	rmEast, _ := internal.JsonMarshal(map[string]any{"location": "east"})
+	rmEast2, _ := internal.JsonMarshal(map[string]any{"location": "east", "name": "2"})
+	rmNoKey, _ := internal.JsonMarshal(map[string]any{})
+	rmBeijing, _ := internal.JsonMarshal(map[string]any{"vpc": "default", RAW_DATA_REGION_KEY: "ap-beijing"})
+	rmShanghai, _ := internal.JsonMarshal(map[string]any{"vpc": "default", RAW_DATA_REGION_KEY: "ap-shanghai"})
+	rmGuangzhouError, _ := internal.JsonMarshal(map[string]any{
+		RAW_DATA_ERROR_KEY: "failed to list data in region ap-guangzhou", RAW_DATA_REGION_KEY: "ap-guangzhou"})
+	fnGetData := func(listorId int) ([]*json.RawMessage, error) {
+		switch listorId {
+		case 1:
+			return []*json.RawMessage{rmEast, rmEast2, rmNoKey}, nil
+		case 2:
+			return nil, nil
+		case 4:
+			return []*json.RawMessage{rmBeijing, rmShanghai, rmGuangzhouError}, nil
+		default:
+			return nil, errors.New("mock error")
+		}
+	}
+	validJoin := def.ConfJoinListor{Listor: 1, KeyJsonPath: "$.location", PrimaryKeyJsonPath: "$.location", As: "mock"}
+	noDataJoin := def.ConfJoinListor{Listor: 2, KeyJsonPath: "$.location", PrimaryKeyJsonPath: "$.location", As: "mock"}
+	scopedJoin := def.ConfJoinListor{Listor: 4, KeyJsonPath: "$.vpc", PrimaryKeyJsonPath: "$.vpc", As: "mock"}
+
+	type args struct {
+		conf []def.ConfJoinListor
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []joinIndex
+		wantErr bool
+	}{
+		{
+			"Valid result",
+			args{[]def.ConfJoinListor{validJoin}},
+			[]joinIndex{{conf: &validJoin, items: map[joinKey][]*json.RawMessage{{key: "east"}: {rmEast, rmEast2}}}},
+			false,
+		},
+		{
+			"Valid result of same key in different scopes",
+			args{[]def.ConfJoinListor{scopedJoin}},
+			[]joinIndex{{conf: &scopedJoin, items: map[joinKey][]*json.RawMessage{
+				{region: "ap-beijing", key: "default"}:  {rmBeijing},
+				{region: "ap-shanghai", key: "default"}: {rmShanghai},
+			}}},
+			false,
+		},
+		{
+			"Valid result of listor without data",
+			args{[]def.ConfJoinListor{noDataJoin}},
+			[]joinIndex{{conf: &noDataJoin, items: map[joinKey][]*json.RawMessage{}}},
+			false,
+		},
+		{
+			"Missing as",
+			args{[]def.ConfJoinListor{{Listor: 1, KeyJsonPath: "$.location", PrimaryKeyJsonPath: "$.location"}}},
+			nil,
+			true,
+		},
+		{
+			"Missing jsonpath of key",
+			args{[]def.ConfJoinListor{{Listor: 1, PrimaryKeyJsonPath: "$.location", As: "mock"}}},
+			nil,
+			true,
+		},
+		{
+			"failed to get data of listor",
+			args{[]def.ConfJoinListor{{Listor: 3, KeyJsonPath: "$.location", PrimaryKeyJsonPath: "$.location", As: "mock"}}},
+			nil,
+			true,
+		},
+		{
+			"failed to get key of item",
+			args{[]def.ConfJoinListor{{Listor: 1, KeyJsonPath: "invalid", PrimaryKeyJsonPath: "$.location", As: "mock"}}},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildJoinIndex(tt.args.conf, fnGetData)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("buildJoinIndex() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("buildJoinIndex() = %v, want %v", got, tt.want)
+				return
+			}
+			for i := range got {
+				if !reflect.DeepEqual(*got[i].conf, *tt.want[i].conf) || !reflect.DeepEqual(got[i].items, tt.want[i].items) {
+					t.Errorf("buildJoinIndex() = %v, want %v", got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func Test_joinItem(t *testing.T) {
+	rmPrimary, _ := internal.JsonMarshal(map[string]any{"location": "east"})
+	rmPrimaryOther, _ := internal.JsonMarshal(map[string]any{"location": "west"})
+	rmSecondary, _ := internal.JsonMarshal(map[string]any{"name": "mock"})
+	rmNotObject, _ := internal.JsonMarshal("mock")
+	rmJoined, _ := internal.JsonMarshal(map[string]any{"location": "east", "mock": []any{map[string]any{"name": "mock"}}})
+	rmJoinedEmpty, _ := internal.JsonMarshal(map[string]any{"location": "west", "mock": []any{}})
+	index := []joinIndex{{
+		conf:  &def.ConfJoinListor{PrimaryKeyJsonPath: "$.location", As: "mock"},
+		items: map[joinKey][]*json.RawMessage{{key: "east"}: {rmSecondary}},
+	}}
+	rmSecondaryBeijing, _ := internal.JsonMarshal(map[string]any{"name": "ap-beijing", RAW_DATA_REGION_KEY: "ap-beijing"})
+	rmSecondaryShanghai, _ := internal.JsonMarshal(map[string]any{"name": "ap-shanghai", RAW_DATA_REGION_KEY: "ap-shanghai"})
+	rmPrimaryBeijing, _ := internal.JsonMarshal(map[string]any{"vpc": "default", RAW_DATA_REGION_KEY: "ap-beijing"})
+	rmJoinedBeijing, _ := internal.JsonMarshal(map[string]any{"vpc": "default", RAW_DATA_REGION_KEY: "ap-beijing",
+		"mock": []any{map[string]any{"name": "ap-beijing", RAW_DATA_REGION_KEY: "ap-beijing"}, map[string]any{"name": "global"}}})
+	rmSecondaryGlobal, _ := internal.JsonMarshal(map[string]any{"name": "global"})
+	scopedIndex := []joinIndex{{
+		conf: &def.ConfJoinListor{PrimaryKeyJsonPath: "$.vpc", As: "mock"},
+		items: map[joinKey][]*json.RawMessage{
+			{region: "ap-beijing", key: "default"}:  {rmSecondaryBeijing},
+			{region: "ap-shanghai", key: "default"}: {rmSecondaryShanghai},
+			{key: "default"}:                        {rmSecondaryGlobal},
+		},
+	}}
+
+	type args struct {
+		rawData *json.RawMessage
+		index   []joinIndex
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *json.RawMessage
+		wantErr bool
+	}{
+		{
+			"Valid result",
+			args{rmPrimary, index},
+			rmJoined,
+			false,
+		},
+		{
+			"Valid result of nothing matched",
+			args{rmPrimaryOther, index},
+			rmJoinedEmpty,
+			false,
+		},
+		{
+			"Valid result of same key in different scopes",
+			args{rmPrimaryBeijing, scopedIndex},
+			rmJoinedBeijing,
+			false,
+		},
+		{
+			"Valid result without join",
+			args{rmNotObject, nil},
+			rmNotObject,
+			false,
+		},
+		{
+			"Item is not an object",
+			args{rmNotObject, index},
+			nil,
+			true,
+		},
+		{
+			"failed to get key of primary item",
+			args{rmPrimary, []joinIndex{{conf: &def.ConfJoinListor{PrimaryKeyJsonPath: "invalid", As: "mock"}}}},
+			nil,
+			true,
+		},
+		{
+			"nil pointor of item",
+			args{nil, index},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := joinItem(tt.args.rawData, tt.args.index)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("joinItem() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("joinItem() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+// Listing of raw data in multiple contexts of k8s
+
+package framework
+
+import (
+	"encoding/json"
+
+	"github.com/s3studio/cloud-bench-checker/pkg/auth"
+)
+
+// RAW_DATA_K8S_CONTEXT_KEY: Key added to each item of raw data listed in multiple contexts of k8s
+const RAW_DATA_K8S_CONTEXT_KEY = "_k8s_context"
+
+// listDataInK8sContexts: Get list of all raw data in each context of k8s concurrently
+//
+// Each item of raw data is tagged with its context with key of RAW_DATA_K8S_CONTEXT_KEY,
+// and items are merged in the order of contexts.
+// @param: authProvider: IAuthProvider to provide profile of auth
+// @param: kubeContexts: Ids of k8s context returned by connector.GetK8sContexts
+// @param: opts: Options to pass to GetEntireList
+// @return: List of raw data
+// @return: Error
+func (l *Listor) listDataInK8sContexts(authProvider auth.IAuthProvider, kubeContexts []string, opts ...GetPageOption) (
+	[]*json.RawMessage, error) {
+	return l.listDataInScopes(authProvider, listScope{"context", RAW_DATA_K8S_CONTEXT_KEY, SetListorK8sContext},
+		kubeContexts, opts...)
+}
+
+// getK8sContextOfRawData: Get context of k8s tagged in the item of raw data
+// @param: rawData: Item of raw data
+// @return: Id of k8s context of the item, or empty string if not tagged
+func getK8sContextOfRawData(rawData *json.RawMessage) string {
+	return getTagOfRawData(rawData, RAW_DATA_K8S_CONTEXT_KEY)
+}
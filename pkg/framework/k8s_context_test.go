@@ -0,0 +1,182 @@
+// Listing of raw data in multiple contexts of k8s
+
+package framework
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/s3studio/cloud-bench-checker/internal"
+	"github.com/s3studio/cloud-bench-checker/pkg/auth"
+	"github.com/s3studio/cloud-bench-checker/pkg/connector"
+	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
+	"github.com/s3studio/cloud-bench-checker/test"
+
+	"github.com/agiledragon/gomonkey/v2"
+)
+
+func setupK8sContexts() *gomonkey.Patches {
+	patches := gomonkey.ApplyFunc(connector.GetK8sVersionWithContext,
+		func(authProvider auth.IAuthProvider, kubeContext string) (string, error) {
+			if kubeContext == "a.yaml:old" {
+				return "1.27", nil
+			}
+			return "1.29", nil
+		})
+	patches.ApplyFunc(connector.CallK8sListWithContext,
+		func(authProvider auth.IAuthProvider, kubeContext string, namespace string, group string, version string,
+			resource string, listOpts map[string]any) (*json.RawMessage, error) {
+			if kubeContext == "a.yaml:invalid" {
+				return nil, errors.New("mock error")
+			}
+			return internal.JsonMarshal(map[string]any{"items": []any{map[string]any{"name": kubeContext}}})
+		})
+
+	return patches
+}
+
+func TestListor_listDataInK8sContexts(t *testing.T) {
+	patches := setupK8sContexts()
+	defer patches.Reset()
+	authProvider := auth.NewAuthFileProvider(test.Test_conf_k8s)
+	fnNewListor := func(version string) *Listor {
+		return NewListor(&def.ConfListor{
+			CloudType:  def.K8S,
+			Constraint: def.ConfConstraint{ConstraintK8s: def.ConfConstraintK8s{Version: version}},
+		}, nil)
+	}
+	rmDev, _ := internal.JsonMarshal(map[string]any{"name": "a.yaml:dev", RAW_DATA_K8S_CONTEXT_KEY: "a.yaml:dev"})
+	rmOld, _ := internal.JsonMarshal(map[string]any{"name": "a.yaml:old", RAW_DATA_K8S_CONTEXT_KEY: "a.yaml:old"})
+	rmProd, _ := internal.JsonMarshal(map[string]any{"name": "b.yaml:prod", RAW_DATA_K8S_CONTEXT_KEY: "b.yaml:prod"})
+	rmOldNotApplicable, _ := internal.JsonMarshal(map[string]any{
+		RAW_DATA_NOT_APPLICABLE_KEY: "constraint not satisfied, need >=1.28, got 1.27",
+		RAW_DATA_K8S_CONTEXT_KEY:    "a.yaml:old",
+	})
+	rmDevError, _ := internal.JsonMarshal(map[string]any{
+		RAW_DATA_ERROR_KEY: "failed to list data in context a.yaml:dev: failed to check constraint: " +
+			"failed to parse version constraint: improper constraint: invalid",
+		RAW_DATA_K8S_CONTEXT_KEY: "a.yaml:dev",
+	})
+	rmInvalidError, _ := internal.JsonMarshal(map[string]any{
+		RAW_DATA_ERROR_KEY:       "failed to list data in context a.yaml:invalid: mock error",
+		RAW_DATA_K8S_CONTEXT_KEY: "a.yaml:invalid",
+	})
+
+	type args struct {
+		kubeContexts []string
+	}
+	tests := []struct {
+		name    string
+		l       *Listor
+		args    args
+		want    []*json.RawMessage
+		wantErr bool
+	}{
+		{
+			"Valid result",
+			fnNewListor(""),
+			args{[]string{"a.yaml:dev", "a.yaml:old", "b.yaml:prod"}},
+			[]*json.RawMessage{rmDev, rmOld, rmProd},
+			false,
+		},
+		{
+			"Valid result with context not satisfying constraint not applicable",
+			fnNewListor(">=1.28"),
+			args{[]string{"a.yaml:dev", "a.yaml:old", "b.yaml:prod"}},
+			[]*json.RawMessage{rmDev, rmOldNotApplicable, rmProd},
+			false,
+		},
+		{
+			"Valid result with constraint not satisfied in all contexts",
+			fnNewListor(">=1.28"),
+			args{[]string{"a.yaml:old"}},
+			[]*json.RawMessage{rmOldNotApplicable},
+			false,
+		},
+		{
+			"Valid result with error of checking constraint",
+			fnNewListor("invalid"),
+			args{[]string{"a.yaml:dev"}},
+			[]*json.RawMessage{rmDevError},
+			false,
+		},
+		{
+			"Valid result with error of one of contexts",
+			fnNewListor(""),
+			args{[]string{"a.yaml:dev", "a.yaml:invalid"}},
+			[]*json.RawMessage{rmDev, rmInvalidError},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.l.listDataInK8sContexts(authProvider, tt.args.kubeContexts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Listor.listDataInK8sContexts() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Listor.listDataInK8sContexts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListor_ListData_k8sContexts(t *testing.T) {
+	patches := setupK8sContexts()
+	defer patches.Reset()
+	patches.ApplyFunc(connector.GetK8sContexts,
+		func(authProvider auth.IAuthProvider) ([]string, error) {
+			return []string{"a.yaml:dev"}, nil
+		})
+	l := NewListor(&def.ConfListor{CloudType: def.K8S}, auth.NewAuthFileProvider(test.Test_conf_k8s))
+	rmDev, _ := internal.JsonMarshal(map[string]any{"name": "a.yaml:dev", RAW_DATA_K8S_CONTEXT_KEY: "a.yaml:dev"})
+
+	got, err := l.ListData()
+	if err != nil {
+		t.Errorf("Listor.ListData() error = %v", err)
+		return
+	}
+	if !reflect.DeepEqual(got, []*json.RawMessage{rmDev}) {
+		t.Errorf("Listor.ListData() = %v, want %v", got, []*json.RawMessage{rmDev})
+	}
+}
+
+func Test_getK8sContextOfRawData(t *testing.T) {
+	rm, _ := internal.JsonMarshal(map[string]any{"name": "mock"})
+	rmTagged, _ := internal.JsonMarshal(map[string]any{"name": "mock", RAW_DATA_K8S_CONTEXT_KEY: "a.yaml:dev"})
+
+	type args struct {
+		rawData *json.RawMessage
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			"Valid result",
+			args{rmTagged},
+			"a.yaml:dev",
+		},
+		{
+			"Valid result of raw data not tagged",
+			args{rm},
+			"",
+		},
+		{
+			"Valid result of nil raw data",
+			args{nil},
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getK8sContextOfRawData(tt.args.rawData); got != tt.want {
+				t.Errorf("getK8sContextOfRawData() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
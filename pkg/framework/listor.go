@@ -21,7 +21,20 @@ type Listor struct {
 	authProvider auth.IAuthProvider
 }
 
-const AZURE_NEXT_MARKER = "nextLink"
+const (
+	AZURE_NEXT_MARKER = "nextLink"
+	AWS_NEXT_MARKER   = "NextToken"
+	AWS_MARKER        = "Marker"
+	AWS_TRUNCATED     = "IsTruncated"
+	GCP_MARKER        = "pageToken"
+	GCP_NEXT_MARKER   = "nextPageToken"
+	OPENSTACK_MARKER  = "marker"
+	OPENSTACK_LIMIT   = "limit"
+	// Key in paginationParam to pass url of the next page for PAGE_LINK_HEADER
+	LINK_HEADER_MARKER = "link_header_next"
+	// Key of url of the next page in response of Microsoft Graph
+	AZURE_GRAPH_NEXT_MARKER = "@odata.nextLink"
+)
 
 // _defaultPaginatorConf: Default paginator definition of different cloud connector
 var _defaultPaginatorConf = map[def.CloudType]def.ConfPaginator{
@@ -50,9 +63,40 @@ var _defaultPaginatorConf = map[def.CloudType]def.ConfPaginator{
 		MarkerName:     AZURE_NEXT_MARKER,
 		NextMarkerName: AZURE_NEXT_MARKER,
 	},
+	def.AWS: {
+		PaginationType: def.PAGE_MARKER,
+		MarkerName:     AWS_NEXT_MARKER,
+		NextMarkerName: AWS_NEXT_MARKER,
+	},
+	def.GCP: {
+		PaginationType: def.PAGE_MARKER,
+		MarkerName:     GCP_MARKER,
+		NextMarkerName: GCP_NEXT_MARKER,
+	},
+	def.OPENSTACK: {
+		PaginationType: def.PAGE_MARKER,
+		LimitType:      def.PARAM_INT,
+		LimitName:      OPENSTACK_LIMIT,
+		MarkerName:     OPENSTACK_MARKER,
+		NextMarkerName: connector.OPENSTACK_NEXT_MARKER,
+	},
+	def.HTTP_API: {
+		PaginationType: def.PAGE_LINK_HEADER,
+		MarkerName:     LINK_HEADER_MARKER,
+	},
 	// No default definition for def.ALIYUN_CLOUD as it varies from API to API
 }
 
+// _awsServicePaginatorConf: Default paginator definition of AWS services using "Marker" instead of "NextToken"
+var _awsServicePaginatorConf = map[string]def.ConfPaginator{
+	"iam": {
+		PaginationType: def.PAGE_MARKER,
+		MarkerName:     AWS_MARKER,
+		NextMarkerName: AWS_MARKER,
+		TruncatedName:  AWS_TRUNCATED,
+	},
+}
+
 // NewListor: Constructor of Listor
 // @param: conf: Definition of Listor
 // @param: authProvider: IAuthProvider to provide profile of auth
@@ -60,6 +104,17 @@ func NewListor(conf *def.ConfListor, authProvider auth.IAuthProvider) *Listor {
 	listor := Listor{conf: conf, authProvider: authProvider}
 	if listor.conf.Paginator.PaginationType == def.PAGEINATION_DEFAULT {
 		listor.conf.Paginator = _defaultPaginatorConf[listor.conf.CloudType]
+		if listor.conf.CloudType == def.AZURE && listor.conf.ListCmd.Azure.Api == def.AZURE_API_GRAPH {
+			listor.conf.Paginator.NextMarkerName = AZURE_GRAPH_NEXT_MARKER
+		}
+		if listor.conf.CloudType == def.AWS {
+			if paginator, ok := _awsServicePaginatorConf[listor.conf.ListCmd.AWS.Service]; ok {
+				listor.conf.Paginator = paginator
+			}
+		}
+	}
+	if listor.conf.Paginator.PaginationType == def.PAGE_LINK_HEADER && len(listor.conf.Paginator.MarkerName) == 0 {
+		listor.conf.Paginator.MarkerName = LINK_HEADER_MARKER
 	}
 	return &listor
 }
@@ -91,11 +146,38 @@ func (l *Listor) ListData(opts ...GetPageOption) ([]*json.RawMessage, error) {
 		authProvider = l.authProvider
 	}
 
+	if l.conf.CloudType == def.K8S && authProvider != nil {
+		kubeContexts, err := connector.GetK8sContexts(authProvider)
+		if err != nil {
+			pndError := auth.ProfileNotDefinedError{}
+			if !errors.As(err, &pndError) {
+				return nil, fmt.Errorf("failed to get k8s contexts: %w", err)
+			}
+			// It's ok to fall through here, the same as listing in single context
+		} else if len(kubeContexts) > 0 {
+			return l.listDataInK8sContexts(authProvider, kubeContexts, opts...)
+		}
+	}
+
+	// The constraint is checked against each scope when listing in multiple scopes
+	if len(l.conf.Regions) > 0 {
+		return l.listDataInRegions(authProvider, opts...)
+	}
+	if len(l.conf.Subscriptions) > 0 || len(l.conf.ManagementGroup) > 0 {
+		return l.listDataInSubscriptions(authProvider, opts...)
+	}
+
 	constraintChecker := NewConstraintChecker(&l.conf.Constraint)
-	if checkRes, err := constraintChecker.Check(authProvider, string(l.conf.CloudType)); err != nil {
+	if checkRes, err := constraintChecker.Check(authProvider, string(l.conf.CloudType), opts...); err != nil {
 		return nil, fmt.Errorf("failed to check constraint: %w", err)
 	} else if checkRes != "" {
-		return nil, errors.New(checkRes)
+		// Keep a record of the Listor not applicable instead of dropping it
+		glog().Printf("Listor %d not applicable: %s\n", l.conf.Id, checkRes)
+		notApplicableData, err := newNotApplicableData(checkRes)
+		if err != nil {
+			return nil, err
+		}
+		return []*json.RawMessage{notApplicableData}, nil
 	}
 
 	return GetEntireList(l, l.conf.Paginator, opts...)
@@ -130,8 +212,9 @@ func (l *Listor) GetOnePage(paginationParam map[string]any, opts ...GetPageOptio
 	case def.TENCENT_CLOUD:
 		mergeMaps(&paginationParam, l.conf.ListCmd.TencentCloud.ExtraParam)
 
-		pageRes, err := connector.CallTencentCloud(
+		pageRes, err := connector.CallTencentCloudWithRegion(
 			authProvider,
+			optAll.region,
 			l.conf.ListCmd.TencentCloud.Service,
 			l.conf.ListCmd.TencentCloud.Version,
 			l.conf.ListCmd.TencentCloud.Action,
@@ -166,8 +249,9 @@ func (l *Listor) GetOnePage(paginationParam map[string]any, opts ...GetPageOptio
 	case def.ALIYUN_CLOUD:
 		mergeMaps(&paginationParam, l.conf.ListCmd.Aliyun.ExtraParam)
 
-		pageRes, err := connector.CallAliyunCloud(
+		pageRes, err := connector.CallAliyunCloudWithRegion(
 			authProvider,
+			optAll.region,
 			l.conf.ListCmd.Aliyun.Endpoint,
 			l.conf.ListCmd.Aliyun.EndpointWithRegion,
 			l.conf.ListCmd.Aliyun.Version,
@@ -184,8 +268,9 @@ func (l *Listor) GetOnePage(paginationParam map[string]any, opts ...GetPageOptio
 	case def.ALIYUN_OSS:
 		// action is ignored, and bucketName is set to empty,
 		// so that CallAliyunOSS returns a list of all buckets
-		pageRes, err := connector.CallAliyunOSS(
+		pageRes, err := connector.CallAliyunOSSWithRegion(
 			authProvider,
+			optAll.region,
 			"", "",
 			paginationParam,
 		)
@@ -203,8 +288,9 @@ func (l *Listor) GetOnePage(paginationParam map[string]any, opts ...GetPageOptio
 		)
 	case def.K8S:
 		mergeMaps(&paginationParam, l.conf.ListCmd.K8sList.ListOptions)
-		res, err := connector.CallK8sList(
+		res, err := connector.CallK8sListWithContext(
 			authProvider,
+			optAll.k8sContext,
 			l.conf.ListCmd.K8sList.Namespace,
 			l.conf.ListCmd.K8sList.Group,
 			l.conf.ListCmd.K8sList.Version,
@@ -227,12 +313,138 @@ func (l *Listor) GetOnePage(paginationParam map[string]any, opts ...GetPageOptio
 		// nextLink is empty on the first call of listing
 		nextLink, _ := paginationParam[AZURE_NEXT_MARKER].(string)
 
-		res, err := connector.CallAzureList(
+		var res *json.RawMessage
+		var err error
+		switch l.conf.ListCmd.Azure.Api {
+		case "", def.AZURE_API_ARM:
+			res, err = connector.CallAzureListInScope(
+				authProvider,
+				optAll.subscription,
+				l.conf.ListCmd.Azure.ResourceGroup,
+				l.conf.ListCmd.Azure.Provider,
+				l.conf.ListCmd.Azure.Version,
+				l.conf.ListCmd.Azure.RsType,
+				l.conf.ListCmd.Azure.ExtraParam,
+				nextLink,
+			)
+		case def.AZURE_API_GRAPH:
+			// rs_type is used as the path of resources, and provider is ignored
+			endpoint := l.conf.ListCmd.Azure.RsType
+			extraParam := l.conf.ListCmd.Azure.ExtraParam
+			if len(nextLink) > 0 {
+				// nextLink already contains the query parameters
+				endpoint = nextLink
+				extraParam = nil
+			}
+			res, err = connector.CallAzureGraph(
+				authProvider,
+				l.conf.ListCmd.Azure.Version,
+				endpoint,
+				"",
+				extraParam,
+			)
+		default:
+			return nil, NextCondition{}, fmt.Errorf("unknown api of Azure: %s", l.conf.ListCmd.Azure.Api)
+		}
+		if err != nil {
+			return nil, NextCondition{}, err
+		}
+
+		dataListJsonPath := l.conf.ListCmd.DataListJsonPath
+		if len(dataListJsonPath) == 0 {
+			dataListJsonPath = "$.value" // Default value
+		}
+
+		dataList, nextCondition, err := ResultDataParse(res, l.conf.Paginator, dataListJsonPath,
+			SetConvertObjectToList(l.conf.ListCmd.ConvertObjectToList),
+		)
+		if err != nil || len(l.conf.ListCmd.Azure.ChildPath) == 0 {
+			return dataList, nextCondition, err
+		}
+
+		// Child resources of resources on this page are returned instead
+		childList, err := listAzureChildren(authProvider, &l.conf.ListCmd.Azure, dataList)
+		if err != nil {
+			return nil, NextCondition{}, err
+		}
+		return childList, nextCondition, nil
+	case def.AWS:
+		// AWS rejects an empty marker on the first call of listing
+		deleteEmptyMarker(paginationParam, l.conf.Paginator)
+		mergeMaps(&paginationParam, l.conf.ListCmd.AWS.ExtraParam)
+
+		pageRes, err := connector.CallAWS(
+			authProvider,
+			l.conf.ListCmd.AWS.Service,
+			l.conf.ListCmd.AWS.Version,
+			l.conf.ListCmd.AWS.Action,
+			paginationParam,
+		)
+		if err != nil {
+			return nil, NextCondition{}, err
+		}
+
+		return ResultDataParse(pageRes, l.conf.Paginator, l.conf.ListCmd.DataListJsonPath,
+			SetConvertObjectToList(l.conf.ListCmd.ConvertObjectToList),
+		)
+	case def.GCP:
+		// pageToken is not expected on the first call of listing
+		deleteEmptyMarker(paginationParam, l.conf.Paginator)
+		mergeMaps(&paginationParam, l.conf.ListCmd.GCP.ExtraParam)
+
+		pageRes, err := connector.CallGCP(
 			authProvider,
-			l.conf.ListCmd.Azure.Provider,
-			l.conf.ListCmd.Azure.Version,
-			l.conf.ListCmd.Azure.RsType,
+			l.conf.ListCmd.GCP.Service,
+			l.conf.ListCmd.GCP.Version,
+			l.conf.ListCmd.GCP.Method,
+			l.conf.ListCmd.GCP.Path,
+			paginationParam,
+		)
+		if err != nil {
+			return nil, NextCondition{}, err
+		}
+
+		return ResultDataParse(pageRes, l.conf.Paginator, l.conf.ListCmd.DataListJsonPath,
+			SetConvertObjectToList(l.conf.ListCmd.ConvertObjectToList),
+		)
+	case def.OPENSTACK:
+		// An empty marker is rejected by some services on the first call of listing
+		deleteEmptyMarker(paginationParam, l.conf.Paginator)
+		mergeMaps(&paginationParam, l.conf.ListCmd.OpenStack.ExtraParam)
+
+		pageRes, err := connector.CallOpenStack(
+			authProvider,
+			l.conf.ListCmd.OpenStack.Service,
+			l.conf.ListCmd.OpenStack.Path,
+			paginationParam,
+		)
+		if err != nil {
+			return nil, NextCondition{}, err
+		}
+
+		dataListJsonPath := l.conf.ListCmd.DataListJsonPath
+		if len(dataListJsonPath) == 0 {
+			dataListJsonPath = "$." + connector.OPENSTACK_LIST_KEY // Default value for response of json array
+		}
+
+		return ResultDataParse(pageRes, l.conf.Paginator, dataListJsonPath,
+			SetConvertObjectToList(l.conf.ListCmd.ConvertObjectToList),
+		)
+	case def.HTTP_API:
+		nextLink := ""
+		if l.conf.Paginator.PaginationType == def.PAGE_LINK_HEADER {
+			// Url of the next page is used instead of marker, and is empty on the first call of listing
+			nextLink, _ = paginationParam[l.conf.Paginator.MarkerName].(string)
+			delete(paginationParam, l.conf.Paginator.MarkerName)
+		} else {
+			deleteEmptyMarker(paginationParam, l.conf.Paginator)
+		}
+
+		pageRes, linkNext, err := connector.CallHttpApi(
+			authProvider,
+			&l.conf.ListCmd.HttpApi,
 			nextLink,
+			paginationParam,
 		)
 		if err != nil {
 			return nil, NextCondition{}, err
@@ -240,12 +452,17 @@ func (l *Listor) GetOnePage(paginationParam map[string]any, opts ...GetPageOptio
 
 		dataListJsonPath := l.conf.ListCmd.DataListJsonPath
 		if len(dataListJsonPath) == 0 {
-			dataListJsonPath = "$.value" // Default value
+			dataListJsonPath = "$" // Default value
 		}
 
-		return ResultDataParse(res, l.conf.Paginator, dataListJsonPath,
+		dataList, nextCondition, err := ResultDataParse(pageRes, l.conf.Paginator, dataListJsonPath,
 			SetConvertObjectToList(l.conf.ListCmd.ConvertObjectToList),
 		)
+		if err == nil && l.conf.Paginator.PaginationType == def.PAGE_LINK_HEADER {
+			nextCondition.NextMarker = linkNext
+		}
+
+		return dataList, nextCondition, err
 	default:
 		return nil, NextCondition{}, fmt.Errorf("invalid cloud type of %s", l.conf.CloudType)
 	}
@@ -276,11 +493,51 @@ func (l *Listor) GetHash(hashType crypto.Hash) ([]byte, error) {
 
 	// Remove current id
 	delete(objListor, "Id")
+	if len(l.conf.Regions) == 0 {
+		// Keep hash of Listor in single region unchanged
+		delete(objListor, "Regions")
+	}
+	if len(l.conf.Subscriptions) == 0 && len(l.conf.ManagementGroup) == 0 {
+		// Keep hash of Listor in single subscription unchanged
+		delete(objListor, "Subscriptions")
+		delete(objListor, "ManagementGroup")
+	}
+	// Keep hash of Listor unchanged if the optional keys of Azure are not used
+	if objListCmd, ok := objListor["ListCmd"].(map[string]any); ok {
+		// Also the commands of clouds added later
+		deleteEmptyKeys(objListCmd, "AWS", "GCP", "OpenStack", "HttpApi")
+		if objAzure, ok := objListCmd["Azure"].(map[string]any); ok {
+			for _, key := range []string{"Api", "ResourceGroup", "ExtraParam", "ChildPath", "ChildVersion"} {
+				if value := objAzure[key]; value == nil || value == "" {
+					delete(objAzure, key)
+				}
+			}
+		}
+	}
+	// Keep hash of Listor unchanged if the constraints other than k8s are not used
+	if objConstraint, ok := objListor["Constraint"].(map[string]any); ok {
+		if len(l.conf.Constraint.ConstraintAzure.Providers) == 0 {
+			delete(objConstraint, "ConstraintAzure")
+		}
+		if len(l.conf.Constraint.ProfileKeys) == 0 {
+			delete(objConstraint, "ProfileKeys")
+		}
+		if len(l.conf.Constraint.Regions) == 0 {
+			delete(objConstraint, "Regions")
+		}
+	}
 
 	// Calculate hash
 	return CalcHash(hashType, objListor)
 }
 
+// deleteEmptyMarker: Remove marker of empty string on the first call of listing for PAGE_MARKER
+func deleteEmptyMarker(paginationParam map[string]any, conf def.ConfPaginator) {
+	if marker, ok := paginationParam[conf.MarkerName].(string); ok && len(marker) == 0 {
+		delete(paginationParam, conf.MarkerName)
+	}
+}
+
 func mergeMaps(target *map[string]any, from ...map[string]any) {
 	for _, m := range from {
 		for k, v := range m {
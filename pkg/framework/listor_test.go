@@ -33,6 +33,53 @@ func TestNewListor(t *testing.T) {
 			args{&validConf, nil},
 			&Listor{conf: &validConf},
 		},
+		{
+			"Default marker of PAGE_LINK_HEADER",
+			args{&def.ConfListor{Paginator: def.ConfPaginator{PaginationType: def.PAGE_LINK_HEADER}}, nil},
+			&Listor{conf: &def.ConfListor{
+				Paginator: def.ConfPaginator{PaginationType: def.PAGE_LINK_HEADER, MarkerName: LINK_HEADER_MARKER},
+			}},
+		},
+		{
+			"Default next marker of Microsoft Graph of Azure",
+			args{&def.ConfListor{CloudType: def.AZURE, ListCmd: def.ConfListCmd{Azure: def.ConfAzureCmd{Api: def.AZURE_API_GRAPH}}}, nil},
+			&Listor{conf: &def.ConfListor{
+				CloudType: def.AZURE,
+				ListCmd:   def.ConfListCmd{Azure: def.ConfAzureCmd{Api: def.AZURE_API_GRAPH}},
+				Paginator: def.ConfPaginator{
+					PaginationType: def.PAGE_MARKER,
+					MarkerName:     AZURE_NEXT_MARKER,
+					NextMarkerName: AZURE_GRAPH_NEXT_MARKER,
+				},
+			}},
+		},
+		{
+			"Default paginator of AWS",
+			args{&def.ConfListor{CloudType: def.AWS, ListCmd: def.ConfListCmd{AWS: def.ConfAWSCmd{Service: "ec2"}}}, nil},
+			&Listor{conf: &def.ConfListor{
+				CloudType: def.AWS,
+				ListCmd:   def.ConfListCmd{AWS: def.ConfAWSCmd{Service: "ec2"}},
+				Paginator: def.ConfPaginator{
+					PaginationType: def.PAGE_MARKER,
+					MarkerName:     AWS_NEXT_MARKER,
+					NextMarkerName: AWS_NEXT_MARKER,
+				},
+			}},
+		},
+		{
+			"Default paginator of AWS service using Marker",
+			args{&def.ConfListor{CloudType: def.AWS, ListCmd: def.ConfListCmd{AWS: def.ConfAWSCmd{Service: "iam"}}}, nil},
+			&Listor{conf: &def.ConfListor{
+				CloudType: def.AWS,
+				ListCmd:   def.ConfListCmd{AWS: def.ConfAWSCmd{Service: "iam"}},
+				Paginator: def.ConfPaginator{
+					PaginationType: def.PAGE_MARKER,
+					MarkerName:     AWS_MARKER,
+					NextMarkerName: AWS_MARKER,
+					TruncatedName:  AWS_TRUNCATED,
+				},
+			}},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -100,6 +147,40 @@ func TestListor_GetOnePage(t *testing.T) {
 			return rm, nil
 		})
 	defer patchCallAzureList.Reset()
+	patchCallAzureGraph := gomonkey.ApplyFunc(connector.CallAzureGraph,
+		func(authProvider auth.IAuthProvider, version string, endpoint string, action string, extraParam map[string]any) (
+			*json.RawMessage, error) {
+			return rm, nil
+		})
+	defer patchCallAzureGraph.Reset()
+	rmChild, _ := internal.JsonMarshal("mock_child")
+	patchListAzureChildren := gomonkey.ApplyFunc(listAzureChildren,
+		func(authProvider auth.IAuthProvider, conf *def.ConfAzureCmd, parentList []*json.RawMessage) ([]*json.RawMessage, error) {
+			return []*json.RawMessage{rmChild}, nil
+		})
+	defer patchListAzureChildren.Reset()
+	patchCallAWS := gomonkey.ApplyFunc(connector.CallAWS,
+		func(authProvider auth.IAuthProvider, service string, version string, action string, extraParam map[string]any) (*json.RawMessage, error) {
+			return rm, nil
+		})
+	defer patchCallAWS.Reset()
+	patchCallGCP := gomonkey.ApplyFunc(connector.CallGCP,
+		func(authProvider auth.IAuthProvider, service string, version string, method string, path string, extraParam map[string]any) (
+			*json.RawMessage, error) {
+			return rm, nil
+		})
+	defer patchCallGCP.Reset()
+	patchCallOpenStack := gomonkey.ApplyFunc(connector.CallOpenStack,
+		func(authProvider auth.IAuthProvider, service string, path string, extraParam map[string]any) (*json.RawMessage, error) {
+			return rm, nil
+		})
+	defer patchCallOpenStack.Reset()
+	patchCallHttpApi := gomonkey.ApplyFunc(connector.CallHttpApi,
+		func(authProvider auth.IAuthProvider, cmd *def.ConfHttpCmd, nextLink string, param map[string]any) (
+			*json.RawMessage, string, error) {
+			return rm, "mock_link", nil
+		})
+	defer patchCallHttpApi.Reset()
 	patchRDP := gomonkey.ApplyFunc(ResultDataParse,
 		func(resultData *json.RawMessage, conf def.ConfPaginator, dataListJsonPath string, opts ...RDPOption) (
 			[]*json.RawMessage, NextCondition, error) {
@@ -175,6 +256,81 @@ func TestListor_GetOnePage(t *testing.T) {
 			NextCondition{},
 			false,
 		},
+		{
+			"Valid result of Microsoft Graph of Azure",
+			NewListor(&def.ConfListor{CloudType: def.AZURE}, mockAuthProvider),
+			def.ConfListCmd{Azure: def.ConfAzureCmd{Api: def.AZURE_API_GRAPH, RsType: "users"}},
+			args{map[string]any{AZURE_NEXT_MARKER: "https://mock.domain/next"}, nil},
+			rmList,
+			NextCondition{},
+			false,
+		},
+		{
+			"Valid result of child resources of Azure",
+			NewListor(&def.ConfListor{CloudType: def.AZURE}, mockAuthProvider),
+			def.ConfListCmd{Azure: def.ConfAzureCmd{ChildPath: "blobServices/default/containers"}},
+			args{map[string]any{}, nil},
+			[]*json.RawMessage{rmChild},
+			NextCondition{},
+			false,
+		},
+		{
+			"unknown api of Azure",
+			NewListor(&def.ConfListor{CloudType: def.AZURE}, mockAuthProvider),
+			def.ConfListCmd{Azure: def.ConfAzureCmd{Api: "invalid"}},
+			args{map[string]any{}, nil},
+			nil,
+			NextCondition{},
+			true,
+		},
+		{
+			"Valid result of AWS",
+			NewListor(&def.ConfListor{CloudType: def.AWS}, mockAuthProvider),
+			def.ConfListCmd{},
+			args{map[string]any{AWS_NEXT_MARKER: ""}, nil},
+			rmList,
+			NextCondition{},
+			false,
+		},
+		{
+			"Valid result of GCP",
+			NewListor(&def.ConfListor{CloudType: def.GCP}, mockAuthProvider),
+			def.ConfListCmd{},
+			args{map[string]any{GCP_MARKER: ""}, nil},
+			rmList,
+			NextCondition{},
+			false,
+		},
+		{
+			"Valid result of OpenStack",
+			NewListor(&def.ConfListor{CloudType: def.OPENSTACK}, mockAuthProvider),
+			def.ConfListCmd{},
+			args{map[string]any{OPENSTACK_MARKER: ""}, nil},
+			rmList,
+			NextCondition{},
+			false,
+		},
+		{
+			"Valid result of HTTP API with Link header",
+			NewListor(&def.ConfListor{CloudType: def.HTTP_API}, mockAuthProvider),
+			def.ConfListCmd{},
+			args{map[string]any{LINK_HEADER_MARKER: ""}, nil},
+			rmList,
+			NextCondition{NextMarker: "mock_link"},
+			false,
+		},
+		{
+			"Valid result of HTTP API with other pagination",
+			NewListor(&def.ConfListor{
+				CloudType: def.HTTP_API,
+				Paginator: def.ConfPaginator{PaginationType: def.PAGE_MARKER, MarkerName: "cursor"},
+			}, mockAuthProvider),
+			def.ConfListCmd{},
+			args{map[string]any{"cursor": ""}, nil},
+			rmList,
+			NextCondition{},
+			false,
+		},
 		{
 			"Valid result with mergeMaps",
 			NewListor(&def.ConfListor{CloudType: def.TENCENT_CLOUD}, mockAuthProvider),
@@ -225,6 +381,23 @@ func TestListor_GetOnePage(t *testing.T) {
 	}
 }
 
+func TestListor_ListData_notApplicable(t *testing.T) {
+	l := NewListor(&def.ConfListor{
+		CloudType:  def.HTTP_API,
+		Constraint: def.ConfConstraint{Regions: []string{"region-1"}},
+	}, nil)
+	rmNotApplicable, _ := newNotApplicableData("constraint not satisfied, need region in [region-1], got \"\"")
+
+	got, err := l.ListData()
+	if err != nil {
+		t.Errorf("Listor.ListData() error = %v", err)
+		return
+	}
+	if !reflect.DeepEqual(got, []*json.RawMessage{rmNotApplicable}) {
+		t.Errorf("Listor.ListData() = %v, want %v", got, []*json.RawMessage{rmNotApplicable})
+	}
+}
+
 func TestListor_GetHash(t *testing.T) {
 	type args struct {
 		hashType crypto.Hash
@@ -243,6 +416,13 @@ func TestListor_GetHash(t *testing.T) {
 			"e1ee77ffb1d36d8db254caeebf056cdce15a887790f875e22179e496104a03ff", // hardcode value
 			false,
 		},
+		{
+			"Valid result with child resources of Azure",
+			NewListor(&def.ConfListor{ListCmd: def.ConfListCmd{Azure: def.ConfAzureCmd{ChildPath: "mock"}}}, nil),
+			args{crypto.SHA256},
+			"02d915005f805ab75b273068b80e0849a6ff3379294801c851251a2d21173896", // hardcode value
+			false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
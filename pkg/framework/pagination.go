@@ -15,6 +15,12 @@ import (
 type getPageOpt struct {
 	// IAuthProvider used in call of GetOnePage instead of default value
 	ap auth.IAuthProvider
+	// Region used in call of GetOnePage instead of the region of profile
+	region string
+	// Subscription of Azure used in call of GetOnePage instead of the subscription of profile
+	subscription string
+	// Context of k8s used in call of GetOnePage instead of the current context of kubeconfig
+	k8sContext string
 }
 
 // GetPageOption: Functional options used in GetOnePage in case more options are added
@@ -31,6 +37,39 @@ func SetListorAuthProvider(val auth.IAuthProvider) GetPageOption {
 	}
 }
 
+// SetListorRegion: Set getPageOpt.region
+//
+// Region used in call of GetOnePage instead of the region of profile
+// @param: val: Value for region
+func SetListorRegion(val string) GetPageOption {
+	return func(options *getPageOpt) error {
+		options.region = val
+		return nil
+	}
+}
+
+// SetListorSubscription: Set getPageOpt.subscription
+//
+// Subscription of Azure used in call of GetOnePage instead of the subscription of profile
+// @param: val: Value for subscription
+func SetListorSubscription(val string) GetPageOption {
+	return func(options *getPageOpt) error {
+		options.subscription = val
+		return nil
+	}
+}
+
+// SetListorK8sContext: Set getPageOpt.k8sContext
+//
+// Context of k8s used in call of GetOnePage instead of the current context of kubeconfig
+// @param: val: Value for id of k8s context
+func SetListorK8sContext(val string) GetPageOption {
+	return func(options *getPageOpt) error {
+		options.k8sContext = val
+		return nil
+	}
+}
+
 // IPaginator: Interface to get single page of data
 type IPaginator interface {
 	// See function of GetEntireList for details of paginationParam
@@ -75,6 +114,11 @@ type NextCondition struct {
 // We defines marker and pagesize in paginationParam of GetOnePage.
 // NextCondition from IPaginator.GetOnePage: Value of next marker
 //
+// - PaginationType == PAGE_LINK_HEADER:
+// Same as PAGE_MARKER, except that the next marker is the url of rel="next" in Link header of response,
+// which is set by IPaginator.GetOnePage other than ResultDataParse.
+// NextCondition from IPaginator.GetOnePage: Url of the next page
+//
 // @param: p: Implementation of interface IPaginator to get data of one page
 // @param: conf: Definition of ConfPaginator
 // @param: opts: Options to pass to IPaginator.GetOnePage
@@ -171,7 +215,7 @@ GetPageLoop:
 				// so it is ok if the number of items differs from the returned totalCount
 				break GetPageLoop
 			}
-		case def.PAGE_MARKER:
+		case def.PAGE_MARKER, def.PAGE_LINK_HEADER:
 			if len(nextCondition.NextMarker) == 0 {
 				break GetPageLoop
 			} else {
@@ -256,6 +300,14 @@ func ResultDataParse(resultData *json.RawMessage, conf def.ConfPaginator, dataLi
 
 		// totalCount will be truncated from 64-bit to 32-bit, hope there are not too many resources
 		return dataList, NextCondition{TotalCount: int(totalCount)}, nil
+	case def.PAGE_LINK_HEADER:
+		// Next marker is not in the response body, and should be set by the caller
+		dataList, err := internal.ParseJsonPathList(resultData, dataListJsonPath, *optAll.convertObjectToList)
+		if err != nil {
+			return nil, NextCondition{}, fmt.Errorf("failed to convert to list: %w", err)
+		}
+
+		return dataList, NextCondition{}, nil
 	case def.PAGE_NOPAGEINATION:
 		dataList, err := internal.ParseJsonPathList(resultData, dataListJsonPath, *optAll.convertObjectToList)
 		if err != nil {
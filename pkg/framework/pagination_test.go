@@ -178,6 +178,24 @@ func (p *mockPaginator) GetOnePage(paginationParam map[string]any, opts ...GetPa
 
 		rm, _ := internal.JsonMarshal(r)
 		return ResultDataParse(rm, p.conf, "$.Items")
+	case def.PAGE_LINK_HEADER:
+		link, ok := paginationParam[p.conf.MarkerName].(string)
+		if !ok {
+			return nil, NextCondition{}, errors.New("invalid test suite")
+		}
+
+		iItems := 5
+		if len(link) > 0 && !p.fullLastPage {
+			iItems -= 1
+		}
+
+		rm, _ := internal.JsonMarshal(make([]int, iItems))
+		dataList, nextCondition, err := ResultDataParse(rm, p.conf, "$")
+		if len(link) == 0 {
+			// Set by caller as ResultDataParse does not know the Link header
+			nextCondition.NextMarker = "http://mock/?page=2"
+		}
+		return dataList, nextCondition, err
 	default:
 		return nil, NextCondition{}, errors.New("invalid PaginationType")
 	}
@@ -521,6 +539,33 @@ func TestGetEntireList(t *testing.T) {
 			9,
 			false,
 		},
+		{
+			"Valid PAGE_LINK_HEADER with fullLastPage==true",
+			args{
+				(&mockPaginator{
+					conf: def.ConfPaginator{
+						PaginationType: def.PAGE_LINK_HEADER,
+						MarkerName:     "link",
+					},
+				}).
+					setFullLastPage(true),
+			},
+			10,
+			false,
+		},
+		{
+			"Valid PAGE_LINK_HEADER with fullLastPage==false",
+			args{
+				(&mockPaginator{
+					conf: def.ConfPaginator{
+						PaginationType: def.PAGE_LINK_HEADER,
+						MarkerName:     "link",
+					},
+				}),
+			},
+			9,
+			false,
+		},
 		{
 			"pagination type not set",
 			args{
@@ -732,6 +777,17 @@ func TestResultDataParse(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"failed to convert to list in PAGE_LINK_HEADER",
+			args{
+				map[string]any{
+					"total": 1,
+				},
+				def.ConfPaginator{PaginationType: def.PAGE_LINK_HEADER},
+				"$.total",
+			},
+			true,
+		},
 		{
 			"failed to unmarshal as map in PAGE_MARKER",
 			args{
@@ -0,0 +1,86 @@
+// Params overriding DynValidateValue of validators
+
+package framework
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/s3studio/cloud-bench-checker/internal"
+	"github.com/s3studio/cloud-bench-checker/pkg/auth"
+	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
+
+	"github.com/magiconair/properties"
+)
+
+// PARAMS_FILE_SUFFIX: Suffix of the params file of profile, which is placed beside the file of profile
+const PARAMS_FILE_SUFFIX = ".params"
+
+// _profileParams: Cache of params files of profiles, with key of the pathname of params file
+var _profileParams internal.SyncMap[map[string]string]
+
+// ParseParams: Parse params in the format of "key=value"
+// @param: list: List of params
+// @return: Mapping of params
+// @return: Error
+func ParseParams(list []string) (map[string]string, error) {
+	params := make(map[string]string, len(list))
+	for _, param := range list {
+		k, v, ok := strings.Cut(param, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid param, should be in the format of key=value: %s", param)
+		}
+		params[k] = v
+	}
+
+	return params, nil
+}
+
+// getProfileParams: Get params from the params file of profile
+//
+// The params file is the file of profile with PARAMS_FILE_SUFFIX, in the format of properties,
+// e.g. ".auth/prod.params" for the profile of "prod".
+// No params is returned if the profile is not defined, is $ENV, or has no params file.
+// Each params file is read once and cached, no matter how many providers or Checkers use it.
+// @param: authProvider: IAuthProvider to provide profile of auth
+// @param: cloudType: Type of the cloud
+// @return: Mapping of params
+// @return: Error
+func getProfileParams(authProvider auth.IAuthProvider, cloudType def.CloudType) (map[string]string, error) {
+	if authProvider == nil {
+		return nil, nil
+	}
+
+	pndError := auth.ProfileNotDefinedError{}
+	if nameProvider, ok := authProvider.(auth.IProfileNameProvider); ok {
+		profileName, err := nameProvider.GetProfileName(cloudType)
+		if errors.As(err, &pndError) || profileName == def.PROFILE_ENV {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	pathname, err := authProvider.GetProfilePathname(cloudType)
+	if errors.As(err, &pndError) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	pathname += PARAMS_FILE_SUFFIX
+	return _profileParams.LoadOrCreate(pathname, func() (any, error) {
+		if _, err := os.Stat(pathname); errors.Is(err, os.ErrNotExist) {
+			return map[string]string(nil), nil
+		}
+
+		p, err := properties.LoadFile(pathname, properties.UTF8)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load params file of profile: %w", err)
+		}
+
+		return p.Map(), nil
+	}, nil)
+}
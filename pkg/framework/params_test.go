@@ -0,0 +1,158 @@
+// Params overriding DynValidateValue of validators
+
+package framework
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/s3studio/cloud-bench-checker/pkg/auth"
+	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
+
+	"github.com/agiledragon/gomonkey/v2"
+)
+
+func TestParseParams(t *testing.T) {
+	type args struct {
+		list []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			"Valid result",
+			args{[]string{"min_length=16", "pattern=a=b", "empty="}},
+			map[string]string{"min_length": "16", "pattern": "a=b", "empty": ""},
+			false,
+		},
+		{
+			"Valid result of empty list",
+			args{nil},
+			map[string]string{},
+			false,
+		},
+		{
+			"invalid param without value",
+			args{[]string{"min_length"}},
+			nil,
+			true,
+		},
+		{
+			"invalid param without key",
+			args{[]string{"=16"}},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseParams(tt.args.list)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseParams() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseParams() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getProfileParams(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "mock_profile"+PARAMS_FILE_SUFFIX),
+		[]byte("# mock\nmin_length=16\nretention_days = 365\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "mock_invalid"+PARAMS_FILE_SUFFIX), []byte("key=${"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	patches := gomonkey.ApplyMethodFunc(&auth.AuthFileProvider{}, "GetProfilePathname",
+		func(cloudType def.CloudType) (string, error) {
+			return filepath.Join(dir, "mock_"+string(cloudType)), nil
+		})
+	patches.ApplyMethodFunc(&auth.AuthFileProvider{}, "GetProfileName",
+		func(cloudType def.CloudType) (string, error) {
+			switch cloudType {
+			case def.AWS:
+				return def.PROFILE_ENV, nil
+			case def.GCP:
+				return "", auth.ProfileNotDefinedError{}
+			default:
+				return "mock_" + string(cloudType), nil
+			}
+		})
+	defer patches.Reset()
+
+	type args struct {
+		authProvider auth.IAuthProvider
+		cloudType    def.CloudType
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			"Valid result",
+			args{auth.NewAuthFileProvider(def.ConfProfile{}), "profile"},
+			map[string]string{"min_length": "16", "retention_days": "365"},
+			false,
+		},
+		{
+			"Params file not exist",
+			args{auth.NewAuthFileProvider(def.ConfProfile{}), def.AZURE},
+			nil,
+			false,
+		},
+		{
+			"Profile of $ENV",
+			args{auth.NewAuthFileProvider(def.ConfProfile{}), def.AWS},
+			nil,
+			false,
+		},
+		{
+			"Profile not defined",
+			args{auth.NewAuthFileProvider(def.ConfProfile{}), def.GCP},
+			nil,
+			false,
+		},
+		{
+			"nil pointor of IAuthProvider",
+			args{nil, def.AZURE},
+			nil,
+			false,
+		},
+		{
+			"failed to load params file of profile",
+			args{auth.NewAuthFileProvider(def.ConfProfile{}), "invalid"},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getProfileParams(tt.args.authProvider, tt.args.cloudType)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getProfileParams() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getProfileParams() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	// The params file is read once
+	if err := os.WriteFile(filepath.Join(dir, "mock_profile"+PARAMS_FILE_SUFFIX), []byte("min_length=8\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := getProfileParams(auth.NewAuthFileProvider(def.ConfProfile{}), "profile"); got["min_length"] != "16" {
+		t.Errorf("getProfileParams() = %v, want params cached", got)
+	}
+}
@@ -0,0 +1,227 @@
+// Reason of validation explaining which branches of JsonSchema are matched by the property
+
+package framework
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// MAX_REASON_DEPTH: Max depth of schema to search for reasons, also used to stop at recursive $ref
+const MAX_REASON_DEPTH = 32
+
+// reasonFinder: Used to find reasons why the property matches JsonSchema of validator
+//
+// As JsonSchema of validator describes the state in risk, no error is reported by the validation
+// when the resource is in risk. Instead, the schema is walked along with the property,
+// and the deepest branches matched with "title" or "description" are reported.
+type reasonFinder struct {
+	// Root of JsonSchema, where definitions and local $ref are resolved
+	root map[string]any
+}
+
+// newReasonFinder: Constructor of reasonFinder
+// @param: schema: JsonSchema of validator, after dynamic values are replaced
+// @return: Instance of reasonFinder
+// @return: Error
+func newReasonFinder(schema string) (*reasonFinder, error) {
+	var root map[string]any
+	if err := json.Unmarshal([]byte(schema), &root); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal jsonschema to find reason: %w", err)
+	}
+
+	return &reasonFinder{root: root}, nil
+}
+
+// find: Get reasons why the property matches the schema
+//
+// Each reason is in the format of "<path of property> matched <path of schema>: <title>: <description>".
+// The property must have matched the schema.
+// @param: prop: Property matched
+// @return: List of reasons, or the root of schema if no branch is described
+// @return: Error
+func (f *reasonFinder) find(prop *json.RawMessage) ([]string, error) {
+	if prop == nil {
+		return nil, errors.New("nil pointor of prop to find reason")
+	}
+
+	var value any
+	if err := json.Unmarshal(*prop, &value); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal prop to find reason: %w", err)
+	}
+
+	var reasons []string
+	if err := f.collect(f.root, value, "$", "#", 0, &reasons); err != nil {
+		return nil, err
+	}
+	if len(reasons) == 0 {
+		reasons = append(reasons, "$ matched #")
+	}
+
+	// Remove duplicated reasons of items in the same array
+	var uniqueReasons []string
+	for _, reason := range reasons {
+		if !slices.Contains(uniqueReasons, reason) {
+			uniqueReasons = append(uniqueReasons, reason)
+		}
+	}
+
+	return uniqueReasons, nil
+}
+
+// collect: Collect reasons of the node of schema matched by the value
+// @param: node: Node of schema matched by the value
+// @param: value: Value of property
+// @param: path: JsonPath of the value
+// @param: schemaPath: Json pointer of the node in schema
+// @param: depth: Depth of the node
+// @param: reasons: List to append reasons to
+// @return: Error
+func (f *reasonFinder) collect(node map[string]any, value any, path string, schemaPath string, depth int,
+	reasons *[]string) error {
+	if depth > MAX_REASON_DEPTH {
+		return nil
+	}
+	count := len(*reasons)
+
+	// fnCollect: Collect reasons of the sub node, checking whether it is matched if required
+	fnCollect := func(sub any, subValue any, subPath string, subSchemaPath string, checkMatch bool) error {
+		subNode, ok := sub.(map[string]any)
+		if !ok {
+			return nil
+		}
+		if checkMatch {
+			if matched, err := f.match(subNode, subValue); err != nil || !matched {
+				return err
+			}
+		}
+		return f.collect(subNode, subValue, subPath, subSchemaPath, depth+1, reasons)
+	}
+
+	if ref, ok := node["$ref"].(string); ok {
+		if err := fnCollect(f.resolveRef(ref), value, path, ref, false); err != nil {
+			return err
+		}
+	}
+
+	for _, keyword := range []string{"allOf", "anyOf", "oneOf"} {
+		branches, _ := node[keyword].([]any)
+		for i, branch := range branches {
+			// Every branch of allOf is matched, while only some of anyOf and oneOf are
+			err := fnCollect(branch, value, path, fmt.Sprintf("%s/%s/%d", schemaPath, keyword, i), keyword != "allOf")
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		properties, _ := node["properties"].(map[string]any)
+		keys := make([]string, 0, len(properties))
+		for key := range properties {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			if subValue, ok := v[key]; ok {
+				err := fnCollect(properties[key], subValue, fmt.Sprintf("%s.%s", path, key),
+					fmt.Sprintf("%s/properties/%s", schemaPath, escapeJsonPointer(key)), false)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	case []any:
+		for i, item := range v {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			if err := fnCollect(node["items"], item, itemPath, schemaPath+"/items", false); err != nil {
+				return err
+			}
+			if err := fnCollect(node["contains"], item, itemPath, schemaPath+"/contains", true); err != nil {
+				return err
+			}
+		}
+	}
+
+	// The node itself is the reason if none of its sub nodes is described
+	if len(*reasons) == count {
+		if description := describeSchema(node); description != "" {
+			*reasons = append(*reasons, fmt.Sprintf("%s matched %s: %s", path, schemaPath, description))
+		}
+	}
+
+	return nil
+}
+
+// match: Check whether the value matches the node of schema
+//
+// Definitions of the root are copied to the node, so that local $ref in the node is resolved.
+func (f *reasonFinder) match(node map[string]any, value any) (bool, error) {
+	schema := maps.Clone(node)
+	for _, key := range []string{"definitions", "$defs"} {
+		if _, ok := schema[key]; !ok && f.root[key] != nil {
+			schema[key] = f.root[key]
+		}
+	}
+
+	res, err := gojsonschema.Validate(gojsonschema.NewGoLoader(schema), gojsonschema.NewGoLoader(value))
+	if err != nil {
+		return false, fmt.Errorf("failed to validate branch of jsonschema to find reason: %w", err)
+	}
+
+	return res.Valid(), nil
+}
+
+// resolveRef: Get the node of local $ref such as "#/definitions/name" from the root
+// @return: Node of schema, or nil if not found
+func (f *reasonFinder) resolveRef(ref string) any {
+	pointer, ok := strings.CutPrefix(ref, "#")
+	if !ok {
+		// Remote $ref is not supported
+		return nil
+	}
+
+	var node any = f.root
+	for _, token := range strings.Split(pointer, "/")[1:] {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		switch v := node.(type) {
+		case map[string]any:
+			node = v[token]
+		case []any:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil
+			}
+			node = v[i]
+		default:
+			return nil
+		}
+	}
+
+	return node
+}
+
+// describeSchema: Get "title" and "description" of the node of schema joined by ": "
+func describeSchema(node map[string]any) string {
+	var parts []string
+	for _, key := range []string{"title", "description"} {
+		if value, ok := node[key].(string); ok && value != "" {
+			parts = append(parts, value)
+		}
+	}
+
+	return strings.Join(parts, ": ")
+}
+
+// escapeJsonPointer: Escape token of Json pointer
+func escapeJsonPointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,190 @@
+// Reason of validation explaining which branches of JsonSchema are matched by the property
+
+package framework
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/s3studio/cloud-bench-checker/internal"
+)
+
+func Test_newReasonFinder(t *testing.T) {
+	type args struct {
+		schema string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *reasonFinder
+		wantErr bool
+	}{
+		{
+			"Valid result",
+			args{`{"title": "mock"}`},
+			&reasonFinder{root: map[string]any{"title": "mock"}},
+			false,
+		},
+		{
+			"failed to unmarshal jsonschema",
+			args{`invalid`},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newReasonFinder(tt.args.schema)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newReasonFinder() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newReasonFinder() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_reasonFinder_find(t *testing.T) {
+	schemaAnyOf := `{
+		"type": "object",
+		"anyOf": [
+			{"title": "Public access", "properties": {"public": {"const": true}}, "required": ["public"]},
+			{"properties": {"tls": {"description": "TLS version is too low", "enum": ["1.0", "1.1"]}}, "required": ["tls"]}
+		]
+	}`
+	schemaRef := `{
+		"definitions": {"open": {"description": "Port open to the internet", "properties": {"cidr": {"const": "0.0.0.0/0"}}}},
+		"type": "array",
+		"contains": {"$ref": "#/definitions/open"}
+	}`
+	rmPublic, _ := internal.JsonMarshal(map[string]any{"public": true, "tls": "1.2"})
+	rmBoth, _ := internal.JsonMarshal(map[string]any{"public": true, "tls": "1.0"})
+	rmRules, _ := internal.JsonMarshal([]any{
+		map[string]any{"cidr": "10.0.0.0/8"},
+		map[string]any{"cidr": "0.0.0.0/0"},
+		map[string]any{"cidr": "0.0.0.0/0"},
+	})
+	rmInvalid := json.RawMessage(`invalid`)
+
+	type args struct {
+		schema string
+		prop   *json.RawMessage
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []string
+		wantErr bool
+	}{
+		{
+			"Valid result of branch of anyOf",
+			args{schemaAnyOf, rmPublic},
+			[]string{"$ matched #/anyOf/0: Public access"},
+			false,
+		},
+		{
+			"Valid result of multiple branches of anyOf",
+			args{schemaAnyOf, rmBoth},
+			[]string{
+				"$ matched #/anyOf/0: Public access",
+				"$.tls matched #/anyOf/1/properties/tls: TLS version is too low",
+			},
+			false,
+		},
+		{
+			"Valid result of $ref in contains",
+			args{schemaRef, rmRules},
+			[]string{
+				"$[1] matched #/definitions/open: Port open to the internet",
+				"$[2] matched #/definitions/open: Port open to the internet",
+			},
+			false,
+		},
+		{
+			"Valid result of schema not described",
+			args{`{"type": "object"}`, rmPublic},
+			[]string{"$ matched #"},
+			false,
+		},
+		{
+			"failed to unmarshal prop",
+			args{schemaAnyOf, &rmInvalid},
+			nil,
+			true,
+		},
+		{
+			"nil pointor of prop",
+			args{schemaAnyOf, nil},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := newReasonFinder(tt.args.schema)
+			if err != nil {
+				t.Fatalf("newReasonFinder() error = %v", err)
+			}
+			got, err := f.find(tt.args.prop)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("reasonFinder.find() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("reasonFinder.find() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_reasonFinder_resolveRef(t *testing.T) {
+	f := &reasonFinder{root: map[string]any{
+		"definitions": map[string]any{"a/b": map[string]any{"title": "slash"}},
+		"anyOf":       []any{map[string]any{"title": "first"}},
+	}}
+
+	type args struct {
+		ref string
+	}
+	tests := []struct {
+		name string
+		args args
+		want any
+	}{
+		{
+			"Valid result with escaped token",
+			args{"#/definitions/a~1b"},
+			map[string]any{"title": "slash"},
+		},
+		{
+			"Valid result with index of array",
+			args{"#/anyOf/0"},
+			map[string]any{"title": "first"},
+		},
+		{
+			"Valid result of root",
+			args{"#"},
+			f.root,
+		},
+		{
+			"index out of range",
+			args{"#/anyOf/1"},
+			nil,
+		},
+		{
+			"remote $ref not supported",
+			args{"http://mock.domain/schema.json"},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := f.resolveRef(tt.args.ref); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("reasonFinder.resolveRef() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// Listing of raw data in multiple regions
+
+package framework
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/s3studio/cloud-bench-checker/pkg/auth"
+	"github.com/s3studio/cloud-bench-checker/pkg/connector"
+	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
+)
+
+// RAW_DATA_REGION_KEY: Key added to each item of raw data listed in multiple regions
+const RAW_DATA_REGION_KEY = "_region"
+
+// getListorRegions: Get regions to list resources in according to definition of Listor
+//
+// All regions available to the account are discovered if the only region is REGION_ALL
+// @param: authProvider: IAuthProvider to provide profile of auth
+// @param: conf: Definition of Listor
+// @return: List of regions
+// @return: Error
+func getListorRegions(authProvider auth.IAuthProvider, conf *def.ConfListor) ([]string, error) {
+	if len(conf.Regions) != 1 || conf.Regions[0] != def.REGION_ALL {
+		if slices.Contains(conf.Regions, def.REGION_ALL) {
+			return nil, fmt.Errorf("\"%s\" can not be used together with other regions", def.REGION_ALL)
+		}
+		return conf.Regions, nil
+	}
+
+	switch conf.CloudType {
+	case def.TENCENT_CLOUD:
+		return connector.GetTencentCloudRegions(authProvider)
+	case def.ALIYUN_CLOUD, def.ALIYUN_OSS:
+		return connector.GetAliyunRegions(authProvider)
+	default:
+		return nil, fmt.Errorf("regions not supported for cloud type of %s", conf.CloudType)
+	}
+}
+
+// listDataInRegions: Get list of all raw data in each region concurrently
+//
+// Each item of raw data is tagged with its region with key of RAW_DATA_REGION_KEY,
+// and items are merged in the order of regions.
+// @param: authProvider: IAuthProvider to provide profile of auth
+// @param: opts: Options to pass to GetEntireList
+// @return: List of raw data
+// @return: Error
+func (l *Listor) listDataInRegions(authProvider auth.IAuthProvider, opts ...GetPageOption) ([]*json.RawMessage, error) {
+	switch l.conf.CloudType {
+	case def.TENCENT_CLOUD, def.ALIYUN_CLOUD, def.ALIYUN_OSS:
+	default:
+		return nil, fmt.Errorf("regions not supported for cloud type of %s", l.conf.CloudType)
+	}
+	if authProvider == nil {
+		return nil, errors.New("nil pointor of IAuthProvider of Listor")
+	}
+
+	regions, err := getListorRegions(authProvider, l.conf)
+	if err != nil {
+		pndError := auth.ProfileNotDefinedError{}
+		if errors.As(err, &pndError) {
+			// It's ok to bypass here, the same as listing in single region
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get regions: %w", err)
+	}
+
+	return l.listDataInScopes(authProvider, listScope{"region", RAW_DATA_REGION_KEY, SetListorRegion}, regions, opts...)
+}
+
+// tagRegion: Add region to the item of raw data with key of RAW_DATA_REGION_KEY
+// @param: rawData: Item of raw data, must be a json object
+// @param: region: Region of the item
+// @return: Item tagged with region
+// @return: Error
+func tagRegion(rawData *json.RawMessage, region string) (*json.RawMessage, error) {
+	return tagRawData(rawData, RAW_DATA_REGION_KEY, region)
+}
+
+// getRegionOfRawData: Get region tagged in the item of raw data
+// @param: rawData: Item of raw data
+// @return: Region of the item, or empty string if not tagged
+func getRegionOfRawData(rawData *json.RawMessage) string {
+	return getTagOfRawData(rawData, RAW_DATA_REGION_KEY)
+}
@@ -0,0 +1,288 @@
+// Listing of raw data in multiple regions
+
+package framework
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/s3studio/cloud-bench-checker/internal"
+	"github.com/s3studio/cloud-bench-checker/pkg/auth"
+	"github.com/s3studio/cloud-bench-checker/pkg/connector"
+	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
+	"github.com/s3studio/cloud-bench-checker/test"
+
+	"github.com/agiledragon/gomonkey/v2"
+)
+
+func setupRegions() *gomonkey.Patches {
+	patches := gomonkey.ApplyFunc(connector.GetTencentCloudRegions,
+		func(authProvider auth.IAuthProvider) ([]string, error) {
+			return []string{"region-1", "region-2"}, nil
+		})
+	patches.ApplyFunc(connector.GetAliyunRegions,
+		func(authProvider auth.IAuthProvider) ([]string, error) {
+			return nil, auth.ProfileNotDefinedError{}
+		})
+	patches.ApplyFunc(connector.CallTencentCloudWithRegion,
+		func(authProvider auth.IAuthProvider, region string, service string, version string, action string, extraParam map[string]any) (
+			*json.RawMessage, error) {
+			if region == "invalid" {
+				return nil, errors.New("mock error")
+			}
+			return internal.JsonMarshal(map[string]any{"TotalCount": 1, "List": []any{map[string]any{"id": region}}})
+		})
+
+	return patches
+}
+
+func Test_getListorRegions(t *testing.T) {
+	patches := setupRegions()
+	defer patches.Reset()
+
+	type args struct {
+		authProvider auth.IAuthProvider
+		conf         *def.ConfListor
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []string
+		wantErr bool
+	}{
+		{
+			"Valid result of given regions",
+			args{nil, &def.ConfListor{CloudType: def.TENCENT_CLOUD, Regions: []string{"region-1"}}},
+			[]string{"region-1"},
+			false,
+		},
+		{
+			"Valid result of all regions",
+			args{nil, &def.ConfListor{CloudType: def.TENCENT_CLOUD, Regions: []string{def.REGION_ALL}}},
+			[]string{"region-1", "region-2"},
+			false,
+		},
+		{
+			"all can not be used together with other regions",
+			args{nil, &def.ConfListor{CloudType: def.TENCENT_CLOUD, Regions: []string{def.REGION_ALL, "region-1"}}},
+			nil,
+			true,
+		},
+		{
+			"regions not supported for cloud type",
+			args{nil, &def.ConfListor{CloudType: def.AZURE, Regions: []string{def.REGION_ALL}}},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getListorRegions(tt.args.authProvider, tt.args.conf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getListorRegions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getListorRegions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListor_listDataInRegions(t *testing.T) {
+	patches := setupRegions()
+	defer patches.Reset()
+	authProvider := auth.NewAuthFileProvider(test.Test_conf_env)
+	fnNewListor := func(cloudType def.CloudType, regions []string) *Listor {
+		return NewListor(&def.ConfListor{
+			CloudType: cloudType,
+			ListCmd:   def.ConfListCmd{DataListJsonPath: "$.List"},
+			Regions:   regions,
+		}, nil)
+	}
+	rm1, _ := internal.JsonMarshal(map[string]any{"id": "region-1", RAW_DATA_REGION_KEY: "region-1"})
+	rm2, _ := internal.JsonMarshal(map[string]any{"id": "region-2", RAW_DATA_REGION_KEY: "region-2"})
+	rm2NotApplicable, _ := internal.JsonMarshal(map[string]any{
+		RAW_DATA_NOT_APPLICABLE_KEY: "constraint not satisfied, need region in [region-1], got \"region-2\"",
+		RAW_DATA_REGION_KEY:         "region-2",
+	})
+	rmInvalidError, _ := internal.JsonMarshal(map[string]any{
+		RAW_DATA_ERROR_KEY:  "failed to list data in region invalid: failed to get data of page (offset 0/limit 5): mock error",
+		RAW_DATA_REGION_KEY: "invalid",
+	})
+	listorAllowlist := fnNewListor(def.TENCENT_CLOUD, []string{def.REGION_ALL})
+	listorAllowlist.conf.Constraint.Regions = []string{"region-1"}
+
+	type args struct {
+		authProvider auth.IAuthProvider
+	}
+	tests := []struct {
+		name    string
+		l       *Listor
+		args    args
+		want    []*json.RawMessage
+		wantErr bool
+	}{
+		{
+			"Valid result of given regions",
+			fnNewListor(def.TENCENT_CLOUD, []string{"region-2"}),
+			args{authProvider},
+			[]*json.RawMessage{rm2},
+			false,
+		},
+		{
+			"Valid result of all regions",
+			fnNewListor(def.TENCENT_CLOUD, []string{def.REGION_ALL}),
+			args{authProvider},
+			[]*json.RawMessage{rm1, rm2},
+			false,
+		},
+		{
+			"Valid result of region not in allowlist of constraint",
+			listorAllowlist,
+			args{authProvider},
+			[]*json.RawMessage{rm1, rm2NotApplicable},
+			false,
+		},
+		{
+			"Valid result of profile not defined",
+			fnNewListor(def.ALIYUN_CLOUD, []string{def.REGION_ALL}),
+			args{authProvider},
+			nil,
+			false,
+		},
+		{
+			"Valid result with error of one of regions",
+			fnNewListor(def.TENCENT_CLOUD, []string{"region-1", "invalid"}),
+			args{authProvider},
+			[]*json.RawMessage{rm1, rmInvalidError},
+			false,
+		},
+		{
+			"failed to get regions",
+			fnNewListor(def.TENCENT_CLOUD, []string{def.REGION_ALL, "region-1"}),
+			args{authProvider},
+			nil,
+			true,
+		},
+		{
+			"regions not supported for cloud type",
+			fnNewListor(def.AZURE, []string{"region-1"}),
+			args{authProvider},
+			nil,
+			true,
+		},
+		{
+			"nil pointor of IAuthProvider",
+			fnNewListor(def.TENCENT_CLOUD, []string{"region-1"}),
+			args{nil},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.l.listDataInRegions(tt.args.authProvider)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Listor.listDataInRegions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Listor.listDataInRegions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_tagRegion(t *testing.T) {
+	rm, _ := internal.JsonMarshal(map[string]any{"id": "mock"})
+	rmTagged, _ := internal.JsonMarshal(map[string]any{"id": "mock", RAW_DATA_REGION_KEY: "region-1"})
+	rmNotObject, _ := internal.JsonMarshal("mock")
+
+	type args struct {
+		rawData *json.RawMessage
+		region  string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *json.RawMessage
+		wantErr bool
+	}{
+		{
+			"Valid result",
+			args{rm, "region-1"},
+			rmTagged,
+			false,
+		},
+		{
+			"raw data must be a json object",
+			args{rmNotObject, "region-1"},
+			nil,
+			true,
+		},
+		{
+			"nil pointor of raw data",
+			args{nil, "region-1"},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tagRegion(tt.args.rawData, tt.args.region)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("tagRegion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tagRegion() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getRegionOfRawData(t *testing.T) {
+	rm, _ := internal.JsonMarshal(map[string]any{"id": "mock"})
+	rmTagged, _ := internal.JsonMarshal(map[string]any{"id": "mock", RAW_DATA_REGION_KEY: "region-1"})
+	rmNotObject, _ := internal.JsonMarshal("mock")
+
+	type args struct {
+		rawData *json.RawMessage
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			"Valid result",
+			args{rmTagged},
+			"region-1",
+		},
+		{
+			"Raw data not tagged",
+			args{rm},
+			"",
+		},
+		{
+			"Raw data not an object",
+			args{rmNotObject},
+			"",
+		},
+		{
+			"nil pointor of raw data",
+			args{nil},
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getRegionOfRawData(tt.args.rawData); got != tt.want {
+				t.Errorf("getRegionOfRawData() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
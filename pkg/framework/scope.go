@@ -0,0 +1,157 @@
+// Listing of raw data in multiple scopes, such as regions or subscriptions
+
+package framework
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/s3studio/cloud-bench-checker/internal"
+	"github.com/s3studio/cloud-bench-checker/pkg/auth"
+)
+
+// RAW_DATA_ERROR_KEY: Key of the item of raw data standing for a scope failed to list data in,
+// with the description of error as value
+const RAW_DATA_ERROR_KEY = "_error"
+
+// listScope: Kind of scope to list raw data in
+type listScope struct {
+	// Human readable name of scope, such as "region"
+	name string
+	// Key added to each item of raw data to tag its scope
+	tagKey string
+	// Option to pass to GetOnePage for each scope
+	fnOpt func(scope string) GetPageOption
+}
+
+// listDataInScopes: Get list of all raw data in each scope concurrently
+//
+// The constraint of Listor is checked against each scope, and a scope not satisfying it
+// has a single item created by newNotApplicableData instead of its raw data.
+// Likewise, a scope failed to list data in has a single item created by newErrorData,
+// so that the data of other scopes is kept.
+// Each item of raw data is tagged with its scope with key of listScope.tagKey,
+// and items are merged in the order of scopes.
+// @param: authProvider: IAuthProvider to provide profile of auth
+// @param: kind: Kind of scope
+// @param: scopes: Scopes to list raw data in
+// @param: opts: Options to pass to GetEntireList
+// @return: List of raw data
+// @return: Error
+func (l *Listor) listDataInScopes(authProvider auth.IAuthProvider, kind listScope, scopes []string, opts ...GetPageOption) (
+	[]*json.RawMessage, error) {
+	scopeData := make([][]*json.RawMessage, len(scopes))
+	scopeErr := make([]error, len(scopes))
+	var waitGroup sync.WaitGroup
+	for i, scope := range scopes {
+		waitGroup.Add(1)
+		go func(i int, scope string) {
+			defer waitGroup.Done()
+
+			checkRes, err := NewConstraintChecker(&l.conf.Constraint).Check(authProvider, string(l.conf.CloudType),
+				kind.fnOpt(scope))
+			if err != nil {
+				scopeErr[i] = fmt.Errorf("failed to check constraint: %w", err)
+				return
+			}
+			if checkRes != "" {
+				glog().Printf("Listor %d not applicable in %s %s: %s\n", l.conf.Id, kind.name, scope, checkRes)
+				notApplicableData, err := newNotApplicableData(checkRes)
+				scopeData[i], scopeErr[i] = []*json.RawMessage{notApplicableData}, err
+				return
+			}
+
+			// Copy opts to avoid sharing the underlying array between goroutines
+			scopeOpts := append(slices.Clone(opts), SetListorAuthProvider(authProvider), kind.fnOpt(scope))
+			scopeData[i], scopeErr[i] = GetEntireList(l, l.conf.Paginator, scopeOpts...)
+		}(i, scope)
+	}
+	waitGroup.Wait()
+
+	var fullList []*json.RawMessage
+	for i, scope := range scopes {
+		if scopeErr[i] != nil {
+			glog().Printf("Listor %d failed to list data in %s %s: %v\n", l.conf.Id, kind.name, scope, scopeErr[i])
+			errorData, err := newErrorData(fmt.Sprintf("failed to list data in %s %s: %v", kind.name, scope, scopeErr[i]))
+			if err != nil {
+				return nil, err
+			}
+			scopeData[i] = []*json.RawMessage{errorData}
+		}
+
+		for _, rawData := range scopeData[i] {
+			taggedData, err := tagRawData(rawData, kind.tagKey, scope)
+			if err != nil {
+				return nil, err
+			}
+			fullList = append(fullList, taggedData)
+		}
+	}
+
+	return fullList, nil
+}
+
+// newErrorData: Create the item of raw data standing for a scope failed to list data in
+// @param: desc: Description of error
+// @return: Item of raw data
+// @return: Error
+func newErrorData(desc string) (*json.RawMessage, error) {
+	return internal.JsonMarshal(map[string]string{RAW_DATA_ERROR_KEY: desc})
+}
+
+// getErrorOfRawData: Get description of error in the item of raw data
+// @param: rawData: Item of raw data
+// @return: Description of error, or empty string if the item is listed successfully
+func getErrorOfRawData(rawData *json.RawMessage) string {
+	return getTagOfRawData(rawData, RAW_DATA_ERROR_KEY)
+}
+
+// tagRawData: Add a tag to the item of raw data
+// @param: rawData: Item of raw data, must be a json object
+// @param: key: Key of the tag
+// @param: value: Value of the tag
+// @return: Item tagged
+// @return: Error
+func tagRawData(rawData *json.RawMessage, key string, value string) (*json.RawMessage, error) {
+	if rawData == nil {
+		return nil, errors.New("nil pointor of raw data to tag")
+	}
+
+	var obj map[string]json.RawMessage
+	if err := internal.JsonUnmarshal(*rawData, &obj); err != nil || obj == nil {
+		return nil, fmt.Errorf("raw data to tag with %s must be a json object", key)
+	}
+
+	byValue, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal value of %s: %w", key, err)
+	}
+	obj[key] = byValue
+
+	return internal.JsonMarshal(obj)
+}
+
+// getTagOfRawData: Get the tag in the item of raw data
+// @param: rawData: Item of raw data
+// @param: key: Key of the tag
+// @return: Value of the tag, or empty string if not tagged
+func getTagOfRawData(rawData *json.RawMessage, key string) string {
+	if rawData == nil {
+		return ""
+	}
+
+	var obj map[string]json.RawMessage
+	if err := internal.JsonUnmarshal(*rawData, &obj); err != nil {
+		return ""
+	}
+
+	var value string
+	if byValue, ok := obj[key]; ok {
+		json.Unmarshal(byValue, &value)
+	}
+
+	return value
+}
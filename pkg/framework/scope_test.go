@@ -0,0 +1,135 @@
+// Listing of raw data in multiple scopes, such as regions or subscriptions
+
+package framework
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/s3studio/cloud-bench-checker/internal"
+)
+
+func Test_tagRawData(t *testing.T) {
+	rm, _ := internal.JsonMarshal(map[string]any{"id": "mock"})
+	rmTagged, _ := internal.JsonMarshal(map[string]any{"id": "mock", "_tag": "value"})
+	rmNotObject, _ := internal.JsonMarshal([]string{"mock"})
+
+	type args struct {
+		rawData *json.RawMessage
+		key     string
+		value   string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *json.RawMessage
+		wantErr bool
+	}{
+		{
+			"Valid result",
+			args{rm, "_tag", "value"},
+			rmTagged,
+			false,
+		},
+		{
+			"raw data must be a json object",
+			args{rmNotObject, "_tag", "value"},
+			nil,
+			true,
+		},
+		{
+			"nil pointor of raw data",
+			args{nil, "_tag", "value"},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tagRawData(tt.args.rawData, tt.args.key, tt.args.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("tagRawData() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tagRawData() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getTagOfRawData(t *testing.T) {
+	rmTagged, _ := internal.JsonMarshal(map[string]any{"id": "mock", "_tag": "value"})
+	rmNotObject, _ := internal.JsonMarshal("mock")
+
+	type args struct {
+		rawData *json.RawMessage
+		key     string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			"Valid result",
+			args{rmTagged, "_tag"},
+			"value",
+		},
+		{
+			"Key not found",
+			args{rmTagged, "_other"},
+			"",
+		},
+		{
+			"Raw data not an object",
+			args{rmNotObject, "_tag"},
+			"",
+		},
+		{
+			"nil pointor of raw data",
+			args{nil, "_tag"},
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getTagOfRawData(tt.args.rawData, tt.args.key); got != tt.want {
+				t.Errorf("getTagOfRawData() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getErrorOfRawData(t *testing.T) {
+	rm, _ := internal.JsonMarshal(map[string]any{"name": "mock"})
+	rmError, _ := newErrorData("failed to list data in region mock")
+
+	type args struct {
+		rawData *json.RawMessage
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			"Valid result",
+			args{rmError},
+			"failed to list data in region mock",
+		},
+		{
+			"Valid result of raw data listed successfully",
+			args{rm},
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getErrorOfRawData(tt.args.rawData); got != tt.want {
+				t.Errorf("getErrorOfRawData() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
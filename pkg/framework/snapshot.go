@@ -0,0 +1,348 @@
+// Snapshot of raw data of Listors saved on disk to be replayed offline
+
+package framework
+
+import (
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/s3studio/cloud-bench-checker/internal"
+	"github.com/s3studio/cloud-bench-checker/pkg/auth"
+	"github.com/s3studio/cloud-bench-checker/pkg/connector"
+	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
+)
+
+const (
+	SNAPSHOT_VERSION       = 3
+	SNAPSHOT_MANIFEST_FILE = "manifest.json"
+	SNAPSHOT_CASSETTE_FILE = "cassette.json" // Cassette of calls of extract_cmd recorded by Recorder of connector
+)
+
+// SnapshotListorEntry: Entry of a Listor in the manifest of snapshot
+type SnapshotListorEntry struct {
+	// Id of Listor when the snapshot is collected
+	Id int `json:"id"`
+	// Cloud type of Listor
+	CloudType string `json:"cloud_type"`
+	// Hash of Listor in hex calculated by Listor.GetHash with SHA256
+	Hash string `json:"hash"`
+	// Name of json file containing raw data under the directory of snapshot, empty if there is no data
+	File string `json:"file,omitempty"`
+	// Hash of the json file of raw data in hex with SHA256
+	DataHash string `json:"data_hash,omitempty"`
+	// Number of items in the raw data
+	Count int `json:"count"`
+	// Error of listing when the snapshot is collected, empty if succeeded
+	Error string `json:"error,omitempty"`
+}
+
+// SnapshotFileEntry: Entry of a file other than raw data of Listors in the manifest of snapshot
+type SnapshotFileEntry struct {
+	// Name of file under the directory of snapshot
+	File string `json:"file"`
+	// Hash of the file in hex with SHA256
+	Hash string `json:"hash"`
+}
+
+// SnapshotManifest: Manifest of snapshot
+type SnapshotManifest struct {
+	Version   int                   `json:"version"`
+	CreatedAt time.Time             `json:"created_at"`
+	Listor    []SnapshotListorEntry `json:"listor"`
+	// Name of profile by cloud type that Listors are collected with, used as id of aggregate when replayed
+	Profile map[string]string `json:"profile,omitempty"`
+	// Cassette of calls to the cloud made by extract_cmd of Checkers, nil if not recorded
+	Cassette *SnapshotFileEntry `json:"cassette,omitempty"`
+}
+
+// NewSnapshotRecorder: Create a Recorder to record calls of extract_cmd into the cassette of snapshot
+//
+// The Recorder should be set by connector.SetRecorder before getting props from the cloud,
+// and be passed to SaveSnapshot afterwards.
+// @param: dir: Directory of snapshot, created if not exists
+// @return: Pointer of Recorder
+// @return: Error
+func NewSnapshotRecorder(dir string) (*connector.Recorder, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create directory of snapshot: %w", err)
+	}
+
+	return connector.NewRecorder(connector.RECORDER_RECORD, filepath.Join(dir, SNAPSHOT_CASSETTE_FILE))
+}
+
+// SaveSnapshot: Save raw data of Listors provided by IDataProvider to a directory
+//
+// Raw data of each Listor is saved as a json file named "listor_{id}.json",
+// the cassette of the Recorder is saved as "cassette.json" if provided,
+// and "manifest.json" is written at last with the hashes of Listors and files.
+// Every Listor is recorded in the manifest, including those without data in the cloud with count of 0,
+// and those failed to get raw data with the error.
+// The names of profiles of Listors are recorded as well if provided by their IAuthProvider.
+// @param: dir: Directory of snapshot, created if not exists
+// @param: listors: Listors whose raw data is to be saved
+// @param: dataProvider: IDataProvider to provide raw data
+// @param: recorder: Recorder created by NewSnapshotRecorder with the same dir, nil if calls of extract_cmd are not recorded
+// @return: Error
+func SaveSnapshot(dir string, listors []*Listor, dataProvider IDataProvider, recorder *connector.Recorder) error {
+	if dataProvider == nil {
+		return errors.New("nil pointor of IDataProvider")
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create directory of snapshot: %w", err)
+	}
+
+	manifest := SnapshotManifest{
+		Version:   SNAPSHOT_VERSION,
+		CreatedAt: time.Now().UTC(),
+		Listor:    make([]SnapshotListorEntry, 0, len(listors)),
+		Profile:   make(map[string]string),
+	}
+	for _, l := range listors {
+		if l == nil {
+			continue
+		}
+
+		hash, err := l.GetHash(crypto.SHA256)
+		if err != nil {
+			return fmt.Errorf("failed to get hash of listor %d: %w", l.conf.Id, err)
+		}
+
+		entry := SnapshotListorEntry{
+			Id:        l.conf.Id,
+			CloudType: string(l.conf.CloudType),
+			Hash:      fmt.Sprintf("%x", hash),
+		}
+
+		rawData, err := dataProvider.GetRawDataByListorId(l.conf.Id)
+		if err != nil {
+			entry.Error = err.Error()
+		} else if rawData != nil {
+			entry.File = fmt.Sprintf("listor_%d.json", l.conf.Id)
+			entry.Count = len(rawData)
+			if entry.DataHash, err = writeSnapshotJson(filepath.Join(dir, entry.File), rawData, false); err != nil {
+				return err
+			}
+		}
+
+		manifest.Listor = append(manifest.Listor, entry)
+
+		if nameProvider, ok := l.authProvider.(auth.IProfileNameProvider); ok {
+			if profileName, err := nameProvider.GetProfileName(l.conf.CloudType); err == nil {
+				manifest.Profile[entry.CloudType] = profileName
+			}
+		}
+	}
+
+	if recorder != nil {
+		if err := recorder.Save(); err != nil {
+			return fmt.Errorf("failed to save cassette of snapshot: %w", err)
+		}
+
+		byCassette, err := os.ReadFile(filepath.Join(dir, SNAPSHOT_CASSETTE_FILE))
+		if err != nil {
+			return fmt.Errorf("failed to read cassette of snapshot: %w", err)
+		}
+		manifest.Cassette = &SnapshotFileEntry{File: SNAPSHOT_CASSETTE_FILE, Hash: hashSnapshotFile(byCassette)}
+	}
+
+	_, err := writeSnapshotJson(filepath.Join(dir, SNAPSHOT_MANIFEST_FILE), manifest, true)
+	return err
+}
+
+// hashSnapshotFile: Calculate hash of content of file in snapshot
+func hashSnapshotFile(by []byte) string {
+	hashInstance := crypto.SHA256.New()
+	hashInstance.Write(by)
+	return fmt.Sprintf("%x", hashInstance.Sum(nil))
+}
+
+// readSnapshotFile: Read file in the directory of snapshot and check its hash in manifest
+func readSnapshotFile(dir string, file string, hash string) ([]byte, error) {
+	if fileDir, _ := filepath.Split(file); fileDir != "" || len(file) == 0 {
+		// File not in the directory of snapshot is not allowed
+		return nil, errors.New("invalid file name in manifest")
+	}
+
+	by, err := os.ReadFile(filepath.Join(dir, file))
+	if err != nil {
+		return nil, err
+	}
+	if hashSnapshotFile(by) != hash {
+		return nil, fmt.Errorf("file %s has been changed since the snapshot was collected", file)
+	}
+
+	return by, nil
+}
+
+// writeSnapshotJson: Write json file in the directory of snapshot
+// @return: Hash of the file
+// @return: Error
+func writeSnapshotJson(pathname string, v any, indent bool) (string, error) {
+	var by []byte
+	var err error
+	if indent {
+		by, err = json.MarshalIndent(v, "", "  ")
+	} else {
+		// Raw data is kept as it is from the cloud
+		by, err = json.Marshal(v)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal as json: %w", err)
+	}
+
+	if err := os.WriteFile(pathname, by, 0600); err != nil {
+		return "", fmt.Errorf("failed to write file of snapshot: %w", err)
+	}
+
+	return hashSnapshotFile(by), nil
+}
+
+// SnapshotDataProvider: Implementation of IDataProvider using snapshot on disk
+//
+// All data is loaded in LoadSnapshot and is read only afterwards, so it is goroutine safe.
+type SnapshotDataProvider struct {
+	// Manifest of snapshot
+	Manifest SnapshotManifest
+	// Recorder replaying calls of extract_cmd from the cassette of snapshot, nil if not recorded
+	Recorder *connector.Recorder
+	// Raw data by id of Listor
+	dataMap map[int][]*json.RawMessage
+	// Error of listing by id of Listor
+	errMap map[int]error
+	// Cloud type by id of Listor
+	ctMap map[int]string
+}
+
+// LoadSnapshot: Load snapshot from a directory for the current definition of Listors
+//
+// The snapshot is refused if any Listor recorded has a different hash from the current one,
+// which means it was collected with a different definition of Listor,
+// or if any file has a different hash from the one in manifest, which means it has been changed.
+// The snapshot is also refused if any Listor is not recorded, which means it was added after the snapshot was collected.
+// Listors failed to get raw data when the snapshot was collected are treated as having no data in the cloud,
+// and the error is returned by GetRawDataByListorId, the same as SyncMapDataProvider.
+// The Recorder in the result should be set by connector.SetRecorder to replay calls of extract_cmd.
+// @param: dir: Directory of snapshot
+// @param: listors: Current Listors to use the snapshot
+// @return: Pointer of SnapshotDataProvider
+// @return: Error
+func LoadSnapshot(dir string, listors []*Listor) (*SnapshotDataProvider, error) {
+	byManifest, err := os.ReadFile(filepath.Join(dir, SNAPSHOT_MANIFEST_FILE))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest of snapshot: %w", err)
+	}
+
+	p := SnapshotDataProvider{
+		dataMap: make(map[int][]*json.RawMessage),
+		errMap:  make(map[int]error),
+		ctMap:   make(map[int]string),
+	}
+	if err := json.Unmarshal(byManifest, &p.Manifest); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal manifest of snapshot: %w", err)
+	}
+	if p.Manifest.Version != SNAPSHOT_VERSION {
+		return nil, fmt.Errorf("unsupported version of snapshot: %d", p.Manifest.Version)
+	}
+
+	mapEntry := make(map[int]*SnapshotListorEntry, len(p.Manifest.Listor))
+	for i := range p.Manifest.Listor {
+		mapEntry[p.Manifest.Listor[i].Id] = &p.Manifest.Listor[i]
+	}
+
+	for _, l := range listors {
+		if l == nil {
+			continue
+		}
+
+		entry, ok := mapEntry[l.conf.Id]
+		if !ok {
+			return nil, fmt.Errorf("listor %d is not recorded in the snapshot", l.conf.Id)
+		}
+
+		hash, err := l.GetHash(crypto.SHA256)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get hash of listor %d: %w", l.conf.Id, err)
+		}
+		if fmt.Sprintf("%x", hash) != entry.Hash {
+			return nil, fmt.Errorf("snapshot of listor %d was collected with a different definition of listor", l.conf.Id)
+		}
+
+		if len(entry.Error) > 0 {
+			p.errMap[l.conf.Id] = fmt.Errorf("failed to list data when the snapshot was collected: %s", entry.Error)
+			continue
+		}
+		if len(entry.File) == 0 {
+			// No data of Listor in the cloud
+			continue
+		}
+
+		byData, err := readSnapshotFile(dir, entry.File, entry.DataHash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read data of listor %d: %w", l.conf.Id, err)
+		}
+
+		var rawData []*json.RawMessage
+		if err := internal.JsonUnmarshal(byData, &rawData); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal data of listor %d: %w", l.conf.Id, err)
+		}
+
+		p.dataMap[l.conf.Id] = rawData
+		p.ctMap[l.conf.Id] = entry.CloudType
+	}
+
+	if p.Manifest.Cassette != nil {
+		if _, err := readSnapshotFile(dir, p.Manifest.Cassette.File, p.Manifest.Cassette.Hash); err != nil {
+			return nil, fmt.Errorf("failed to read cassette of snapshot: %w", err)
+		}
+
+		if p.Recorder, err = connector.NewRecorder(connector.RECORDER_REPLAY,
+			filepath.Join(dir, p.Manifest.Cassette.File)); err != nil {
+			return nil, err
+		}
+	}
+
+	return &p, nil
+}
+
+// GetRawDataByListorId: Implementation of IDataProvider.GetRawDataByListorId
+// @param: listorId: Id of listor
+// @return: Raw data of listor
+// @return: Error
+func (p *SnapshotDataProvider) GetRawDataByListorId(listorId int) ([]*json.RawMessage, error) {
+	if err, ok := p.errMap[listorId]; ok {
+		return nil, err
+	}
+
+	// nil is returned if there is no data of Listor in the snapshot
+	return p.dataMap[listorId], nil
+}
+
+// GetProfileName: Implementation of auth.IProfileNameProvider.GetProfileName,
+// which provides the name of profile that the snapshot was collected with instead of the current one
+// @param: cloudType: Type of the cloud
+// @return: Name of profile
+// @return: Error
+func (p *SnapshotDataProvider) GetProfileName(cloudType def.CloudType) (string, error) {
+	profileName, ok := p.Manifest.Profile[string(cloudType)]
+	if !ok {
+		// Listors were bypassed without profile when the snapshot was collected
+		return "", auth.NewProfileNotDefinedError(cloudType)
+	}
+
+	return profileName, nil
+}
+
+// GetCloudTypeByListorId: Implementation of IDataProvider.GetCloudTypeByListorId
+// @param: listorId: Id of listor
+// @return: Cloud type of listor
+// @return: Error
+func (p *SnapshotDataProvider) GetCloudTypeByListorId(listorId int) (string, error) {
+	// Empty string is returned if there is no data of Listor in the snapshot
+	return p.ctMap[listorId], nil
+}
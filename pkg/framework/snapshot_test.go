@@ -0,0 +1,407 @@
+// Snapshot of raw data of Listors saved on disk to be replayed offline
+
+package framework
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/s3studio/cloud-bench-checker/internal"
+	"github.com/s3studio/cloud-bench-checker/pkg/auth"
+	"github.com/s3studio/cloud-bench-checker/pkg/connector"
+	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
+	"github.com/s3studio/cloud-bench-checker/test"
+)
+
+type mockErrDataProvider struct{}
+
+func (p *mockErrDataProvider) GetRawDataByListorId(listorId int) ([]*json.RawMessage, error) {
+	return nil, errors.New("mock error of GetRawDataByListorId")
+}
+
+func (p *mockErrDataProvider) GetCloudTypeByListorId(listorId int) (string, error) {
+	return "", errors.New("mock error of GetCloudTypeByListorId")
+}
+
+func setupSnapshot(t *testing.T) (string, []*Listor, []*json.RawMessage) {
+	rm, _ := internal.JsonMarshal(map[string]any{"id": "mock"})
+	rmList := []*json.RawMessage{rm}
+	listors := []*Listor{
+		NewListor(&def.ConfListor{Id: 1, CloudType: "mock", RsType: "mock1"}, nil),
+		NewListor(&def.ConfListor{Id: 2, CloudType: "mock", RsType: "mock2"}, nil),
+	}
+
+	p := &SyncMapDataProvider{}
+	p.DataMap.Store(1, rmList)
+	p.CtMap.Store(1, "mock")
+
+	dir := t.TempDir()
+	if err := SaveSnapshot(dir, listors, p, nil); err != nil {
+		t.Fatalf("SaveSnapshot() error = %v", err)
+	}
+
+	return dir, listors, rmList
+}
+
+func TestSaveSnapshot(t *testing.T) {
+	rm, _ := internal.JsonMarshal("mock")
+	p := &SyncMapDataProvider{}
+	p.DataMap.Store(1, []*json.RawMessage{rm})
+	p.CtMap.Store(1, "mock")
+	listors := []*Listor{NewListor(&def.ConfListor{Id: 1, CloudType: "mock"}, nil), nil}
+
+	fileAsDir := filepath.Join(t.TempDir(), "file")
+	os.WriteFile(fileAsDir, nil, 0600)
+
+	dirCassette := t.TempDir()
+	recorder, _ := NewSnapshotRecorder(dirCassette)
+
+	pProfile := &SyncMapDataProvider{}
+	pProfile.CtMap.Store(1, string(def.TENCENT_CLOUD))
+	listorsProfile := []*Listor{
+		NewListor(&def.ConfListor{Id: 1, CloudType: def.TENCENT_CLOUD}, auth.NewAuthFileProvider(test.Test_conf_file)),
+		NewListor(&def.ConfListor{Id: 2, CloudType: def.ALIYUN_CLOUD}, auth.NewAuthFileProvider(test.Test_conf_file)),
+	}
+
+	type args struct {
+		dir          string
+		listors      []*Listor
+		dataProvider IDataProvider
+		recorder     *connector.Recorder
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantFiles   []string
+		wantListor  []SnapshotListorEntry // Entries in manifest without hashes
+		wantProfile map[string]string
+		wantErr     bool
+	}{
+		{
+			"Valid result",
+			args{t.TempDir(), listors, p, nil},
+			[]string{"listor_1.json", SNAPSHOT_MANIFEST_FILE},
+			[]SnapshotListorEntry{{Id: 1, CloudType: "mock", File: "listor_1.json", Count: 1}},
+			nil,
+			false,
+		},
+		{
+			"Valid result with cassette",
+			args{dirCassette, listors, p, recorder},
+			[]string{"listor_1.json", SNAPSHOT_CASSETTE_FILE, SNAPSHOT_MANIFEST_FILE},
+			[]SnapshotListorEntry{{Id: 1, CloudType: "mock", File: "listor_1.json", Count: 1}},
+			nil,
+			false,
+		},
+		{
+			"Listor without data",
+			args{t.TempDir(), []*Listor{NewListor(&def.ConfListor{Id: 2}, nil)}, p, nil},
+			[]string{SNAPSHOT_MANIFEST_FILE},
+			[]SnapshotListorEntry{{Id: 2}},
+			nil,
+			false,
+		},
+		{
+			"Failed to get raw data",
+			args{t.TempDir(), listors, &mockErrDataProvider{}, nil},
+			[]string{SNAPSHOT_MANIFEST_FILE},
+			[]SnapshotListorEntry{{Id: 1, CloudType: "mock", Error: "mock error of GetRawDataByListorId"}},
+			nil,
+			false,
+		},
+		{
+			"Valid result with name of profile",
+			args{t.TempDir(), listorsProfile, pProfile, nil},
+			[]string{SNAPSHOT_MANIFEST_FILE},
+			[]SnapshotListorEntry{{Id: 1, CloudType: "tencent_cloud"}, {Id: 2, CloudType: "aliyun"}},
+			map[string]string{"tencent_cloud": "file"},
+			false,
+		},
+		{
+			"Failed to create directory",
+			args{fileAsDir, listors, p, nil},
+			nil,
+			nil,
+			nil,
+			true,
+		},
+		{
+			"nil pointor of IDataProvider",
+			args{t.TempDir(), listors, nil, nil},
+			nil,
+			nil,
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := SaveSnapshot(tt.args.dir, tt.args.listors, tt.args.dataProvider, tt.args.recorder); (err != nil) != tt.wantErr {
+				t.Errorf("SaveSnapshot() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			for _, file := range tt.wantFiles {
+				if _, err := os.Stat(filepath.Join(tt.args.dir, file)); err != nil {
+					t.Errorf("SaveSnapshot() file %s not saved: %v", file, err)
+				}
+			}
+
+			byManifest, _ := os.ReadFile(filepath.Join(tt.args.dir, SNAPSHOT_MANIFEST_FILE))
+			var manifest SnapshotManifest
+			json.Unmarshal(byManifest, &manifest)
+			for i := range manifest.Listor {
+				manifest.Listor[i].Hash = ""
+				manifest.Listor[i].DataHash = ""
+			}
+			if !reflect.DeepEqual(manifest.Listor, tt.wantListor) {
+				t.Errorf("SaveSnapshot() listor in manifest = %v, want %v", manifest.Listor, tt.wantListor)
+			}
+			if !reflect.DeepEqual(manifest.Profile, tt.wantProfile) {
+				t.Errorf("SaveSnapshot() profile in manifest = %v, want %v", manifest.Profile, tt.wantProfile)
+			}
+		})
+	}
+}
+
+func TestLoadSnapshot(t *testing.T) {
+	dir, listors, rmList := setupSnapshot(t)
+
+	dirInvalidFile, _, _ := setupSnapshot(t)
+	byManifest, _ := os.ReadFile(filepath.Join(dirInvalidFile, SNAPSHOT_MANIFEST_FILE))
+	var manifest SnapshotManifest
+	json.Unmarshal(byManifest, &manifest)
+	manifest.Listor[0].File = "../listor_1.json"
+	writeSnapshotJson(filepath.Join(dirInvalidFile, SNAPSHOT_MANIFEST_FILE), manifest, true)
+
+	dirInvalidVersion := t.TempDir()
+	os.WriteFile(filepath.Join(dirInvalidVersion, SNAPSHOT_MANIFEST_FILE), []byte(`{"version":0}`), 0600)
+
+	dirInvalidData, _, _ := setupSnapshot(t)
+	os.WriteFile(filepath.Join(dirInvalidData, "listor_1.json"), []byte(`{}`), 0600)
+
+	dirChangedData, _, _ := setupSnapshot(t)
+	os.WriteFile(filepath.Join(dirChangedData, "listor_1.json"), []byte(`[{"id":"changed"}]`), 0600)
+
+	dirCassette, _, _ := setupSnapshot(t)
+	recorder, _ := NewSnapshotRecorder(dirCassette)
+	SaveSnapshot(dirCassette, listors, &SyncMapDataProvider{}, recorder)
+
+	dirChangedCassette, _, _ := setupSnapshot(t)
+	recorder, _ = NewSnapshotRecorder(dirChangedCassette)
+	SaveSnapshot(dirChangedCassette, listors, &SyncMapDataProvider{}, recorder)
+	os.WriteFile(filepath.Join(dirChangedCassette, SNAPSHOT_CASSETTE_FILE), []byte(`{"interactions":[]}`), 0600)
+
+	dirFailed := t.TempDir()
+	SaveSnapshot(dirFailed, listors, &mockErrDataProvider{}, nil)
+
+	type args struct {
+		dir     string
+		listors []*Listor
+	}
+	tests := []struct {
+		name         string
+		args         args
+		want         map[int][]*json.RawMessage
+		wantRecorder bool
+		wantErr      bool
+	}{
+		{
+			"Valid result",
+			args{dir, listors},
+			map[int][]*json.RawMessage{1: rmList},
+			false,
+			false,
+		},
+		{
+			"Valid result with cassette",
+			args{dirCassette, listors},
+			map[int][]*json.RawMessage{},
+			true,
+			false,
+		},
+		{
+			"Valid result of listor failed to list",
+			args{dirFailed, listors},
+			map[int][]*json.RawMessage{},
+			false,
+			false,
+		},
+		{
+			"Listor not in snapshot",
+			args{dir, []*Listor{NewListor(&def.ConfListor{Id: 3}, nil), nil}},
+			nil,
+			false,
+			true,
+		},
+		{
+			"Hash mismatch of listor",
+			args{dir, []*Listor{NewListor(&def.ConfListor{Id: 1, CloudType: "mock", RsType: "changed"}, nil)}},
+			nil,
+			false,
+			true,
+		},
+		{
+			"Invalid file name in manifest",
+			args{dirInvalidFile, listors},
+			nil,
+			false,
+			true,
+		},
+		{
+			"Unsupported version",
+			args{dirInvalidVersion, listors},
+			nil,
+			false,
+			true,
+		},
+		{
+			"Invalid data of listor",
+			args{dirInvalidData, listors},
+			nil,
+			false,
+			true,
+		},
+		{
+			"Data of listor changed",
+			args{dirChangedData, listors},
+			nil,
+			false,
+			true,
+		},
+		{
+			"Cassette changed",
+			args{dirChangedCassette, listors},
+			nil,
+			false,
+			true,
+		},
+		{
+			"Manifest not exists",
+			args{t.TempDir(), listors},
+			nil,
+			false,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadSnapshot(tt.args.dir, tt.args.listors)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadSnapshot() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got == nil {
+				return
+			}
+			if !reflect.DeepEqual(got.dataMap, tt.want) {
+				t.Errorf("LoadSnapshot() = %v, want %v", got.dataMap, tt.want)
+			}
+			if (got.Recorder != nil) != tt.wantRecorder {
+				t.Errorf("LoadSnapshot() Recorder = %v, wantRecorder %v", got.Recorder, tt.wantRecorder)
+			} else if got.Recorder != nil && got.Recorder.Mode() != connector.RECORDER_REPLAY {
+				t.Errorf("LoadSnapshot() Recorder.Mode() = %v, want %v", got.Recorder.Mode(), connector.RECORDER_REPLAY)
+			}
+		})
+	}
+}
+
+func TestSnapshotDataProvider_GetRawDataByListorId(t *testing.T) {
+	dir, listors, rmList := setupSnapshot(t)
+	p, _ := LoadSnapshot(dir, listors)
+
+	dirFailed := t.TempDir()
+	SaveSnapshot(dirFailed, listors, &mockErrDataProvider{}, nil)
+	pFailed, _ := LoadSnapshot(dirFailed, listors)
+
+	type args struct {
+		listorId int
+	}
+	tests := []struct {
+		name    string
+		p       *SnapshotDataProvider
+		args    args
+		want    []*json.RawMessage
+		wantErr bool
+	}{
+		{
+			"Valid result",
+			p,
+			args{1},
+			rmList,
+			false,
+		},
+		{
+			"No data in the snapshot",
+			p,
+			args{2},
+			nil,
+			false,
+		},
+		{
+			"Failed to list when the snapshot was collected",
+			pFailed,
+			args{1},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.p.GetRawDataByListorId(tt.args.listorId)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SnapshotDataProvider.GetRawDataByListorId() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SnapshotDataProvider.GetRawDataByListorId() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSnapshotDataProvider_GetCloudTypeByListorId(t *testing.T) {
+	dir, listors, _ := setupSnapshot(t)
+	p, _ := LoadSnapshot(dir, listors)
+
+	type args struct {
+		listorId int
+	}
+	tests := []struct {
+		name    string
+		p       *SnapshotDataProvider
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			"Valid result",
+			p,
+			args{1},
+			"mock",
+			false,
+		},
+		{
+			"No data in the snapshot",
+			p,
+			args{2},
+			"",
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.p.GetCloudTypeByListorId(tt.args.listorId)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SnapshotDataProvider.GetCloudTypeByListorId() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("SnapshotDataProvider.GetCloudTypeByListorId() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// Listing of raw data in multiple subscriptions of Azure
+
+package framework
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/s3studio/cloud-bench-checker/pkg/auth"
+	"github.com/s3studio/cloud-bench-checker/pkg/connector"
+	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
+)
+
+// RAW_DATA_SUBSCRIPTION_KEY: Key added to each item of raw data listed in multiple subscriptions
+const RAW_DATA_SUBSCRIPTION_KEY = "_subscription"
+
+// getListorSubscriptions: Get subscriptions of Azure to list resources in according to definition of Listor
+//
+// All subscriptions visible to the service principal are discovered if the only subscription is SUBSCRIPTION_ALL,
+// and all subscriptions under the management group are discovered if ManagementGroup is defined.
+// @param: authProvider: IAuthProvider to provide profile of auth
+// @param: conf: Definition of Listor
+// @return: List of subscriptions
+// @return: Error
+func getListorSubscriptions(authProvider auth.IAuthProvider, conf *def.ConfListor) ([]string, error) {
+	if len(conf.ManagementGroup) > 0 {
+		if len(conf.Subscriptions) > 0 {
+			return nil, errors.New("subscriptions and management_group can not be used together")
+		}
+		return connector.GetAzureManagementGroupSubscriptions(authProvider, conf.ManagementGroup)
+	}
+
+	if len(conf.Subscriptions) != 1 || conf.Subscriptions[0] != def.SUBSCRIPTION_ALL {
+		if slices.Contains(conf.Subscriptions, def.SUBSCRIPTION_ALL) {
+			return nil, fmt.Errorf("\"%s\" can not be used together with other subscriptions", def.SUBSCRIPTION_ALL)
+		}
+		return conf.Subscriptions, nil
+	}
+
+	return connector.GetAzureSubscriptions(authProvider)
+}
+
+// listDataInSubscriptions: Get list of all raw data in each subscription of Azure concurrently
+//
+// Each item of raw data is tagged with its subscription with key of RAW_DATA_SUBSCRIPTION_KEY,
+// and items are merged in the order of subscriptions.
+// @param: authProvider: IAuthProvider to provide profile of auth
+// @param: opts: Options to pass to GetEntireList
+// @return: List of raw data
+// @return: Error
+func (l *Listor) listDataInSubscriptions(authProvider auth.IAuthProvider, opts ...GetPageOption) ([]*json.RawMessage, error) {
+	if l.conf.CloudType != def.AZURE {
+		return nil, fmt.Errorf("subscriptions not supported for cloud type of %s", l.conf.CloudType)
+	}
+	if l.conf.ListCmd.Azure.Api == def.AZURE_API_GRAPH {
+		return nil, errors.New("subscriptions not supported for Microsoft Graph of Azure")
+	}
+	if authProvider == nil {
+		return nil, errors.New("nil pointor of IAuthProvider of Listor")
+	}
+
+	subscriptions, err := getListorSubscriptions(authProvider, l.conf)
+	if err != nil {
+		pndError := auth.ProfileNotDefinedError{}
+		if errors.As(err, &pndError) {
+			// It's ok to bypass here, the same as listing in single subscription
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get subscriptions: %w", err)
+	}
+
+	return l.listDataInScopes(authProvider, listScope{"subscription", RAW_DATA_SUBSCRIPTION_KEY, SetListorSubscription},
+		subscriptions, opts...)
+}
+
+// getSubscriptionOfRawData: Get subscription tagged in the item of raw data
+// @param: rawData: Item of raw data
+// @return: Subscription of the item, or empty string if not tagged
+func getSubscriptionOfRawData(rawData *json.RawMessage) string {
+	return getTagOfRawData(rawData, RAW_DATA_SUBSCRIPTION_KEY)
+}
@@ -0,0 +1,243 @@
+// Listing of raw data in multiple subscriptions of Azure
+
+package framework
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/s3studio/cloud-bench-checker/internal"
+	"github.com/s3studio/cloud-bench-checker/pkg/auth"
+	"github.com/s3studio/cloud-bench-checker/pkg/connector"
+	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
+	"github.com/s3studio/cloud-bench-checker/test"
+
+	"github.com/agiledragon/gomonkey/v2"
+)
+
+func setupSubscriptions() *gomonkey.Patches {
+	patches := gomonkey.ApplyFunc(connector.GetAzureSubscriptions,
+		func(authProvider auth.IAuthProvider) ([]string, error) {
+			return []string{"sub-1", "sub-2"}, nil
+		})
+	patches.ApplyFunc(connector.GetAzureManagementGroupSubscriptions,
+		func(authProvider auth.IAuthProvider, managementGroup string) ([]string, error) {
+			if managementGroup == "undefined" {
+				return nil, auth.ProfileNotDefinedError{}
+			}
+			return []string{"sub-2"}, nil
+		})
+	patches.ApplyFunc(connector.CallAzureListInScope,
+		func(authProvider auth.IAuthProvider, subscription string, resourceGroup string,
+			provider string, version string, rsType string, extraParam map[string]any, nextLink string) (*json.RawMessage, error) {
+			if subscription == "invalid" {
+				return nil, errors.New("mock error")
+			}
+			return internal.JsonMarshal(map[string]any{"value": []any{map[string]any{"id": subscription}}})
+		})
+
+	return patches
+}
+
+func Test_getListorSubscriptions(t *testing.T) {
+	patches := setupSubscriptions()
+	defer patches.Reset()
+
+	type args struct {
+		authProvider auth.IAuthProvider
+		conf         *def.ConfListor
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []string
+		wantErr bool
+	}{
+		{
+			"Valid result of given subscriptions",
+			args{nil, &def.ConfListor{CloudType: def.AZURE, Subscriptions: []string{"sub-1"}}},
+			[]string{"sub-1"},
+			false,
+		},
+		{
+			"Valid result of all subscriptions",
+			args{nil, &def.ConfListor{CloudType: def.AZURE, Subscriptions: []string{def.SUBSCRIPTION_ALL}}},
+			[]string{"sub-1", "sub-2"},
+			false,
+		},
+		{
+			"Valid result of management group",
+			args{nil, &def.ConfListor{CloudType: def.AZURE, ManagementGroup: "mg-1"}},
+			[]string{"sub-2"},
+			false,
+		},
+		{
+			"all can not be used together with other subscriptions",
+			args{nil, &def.ConfListor{CloudType: def.AZURE, Subscriptions: []string{def.SUBSCRIPTION_ALL, "sub-1"}}},
+			nil,
+			true,
+		},
+		{
+			"subscriptions and management_group can not be used together",
+			args{nil, &def.ConfListor{CloudType: def.AZURE, Subscriptions: []string{"sub-1"}, ManagementGroup: "mg-1"}},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getListorSubscriptions(tt.args.authProvider, tt.args.conf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getListorSubscriptions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getListorSubscriptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListor_listDataInSubscriptions(t *testing.T) {
+	patches := setupSubscriptions()
+	defer patches.Reset()
+	authProvider := auth.NewAuthFileProvider(test.Test_conf_azure)
+	fnNewListor := func(cloudType def.CloudType, subscriptions []string, managementGroup string) *Listor {
+		return NewListor(&def.ConfListor{
+			CloudType:       cloudType,
+			Subscriptions:   subscriptions,
+			ManagementGroup: managementGroup,
+		}, nil)
+	}
+	rm1, _ := internal.JsonMarshal(map[string]any{"id": "sub-1", RAW_DATA_SUBSCRIPTION_KEY: "sub-1"})
+	rm2, _ := internal.JsonMarshal(map[string]any{"id": "sub-2", RAW_DATA_SUBSCRIPTION_KEY: "sub-2"})
+	rmInvalidError, _ := internal.JsonMarshal(map[string]any{
+		RAW_DATA_ERROR_KEY:        "failed to list data in subscription invalid: failed to get data of page (offset 0/limit 5): mock error",
+		RAW_DATA_SUBSCRIPTION_KEY: "invalid",
+	})
+
+	type args struct {
+		authProvider auth.IAuthProvider
+	}
+	tests := []struct {
+		name    string
+		l       *Listor
+		args    args
+		want    []*json.RawMessage
+		wantErr bool
+	}{
+		{
+			"Valid result of given subscriptions",
+			fnNewListor(def.AZURE, []string{"sub-2"}, ""),
+			args{authProvider},
+			[]*json.RawMessage{rm2},
+			false,
+		},
+		{
+			"Valid result of all subscriptions",
+			fnNewListor(def.AZURE, []string{def.SUBSCRIPTION_ALL}, ""),
+			args{authProvider},
+			[]*json.RawMessage{rm1, rm2},
+			false,
+		},
+		{
+			"Valid result of management group",
+			fnNewListor(def.AZURE, nil, "mg-1"),
+			args{authProvider},
+			[]*json.RawMessage{rm2},
+			false,
+		},
+		{
+			"Valid result of profile not defined",
+			fnNewListor(def.AZURE, nil, "undefined"),
+			args{authProvider},
+			nil,
+			false,
+		},
+		{
+			"Valid result with error of one of subscriptions",
+			fnNewListor(def.AZURE, []string{"sub-1", "invalid"}, ""),
+			args{authProvider},
+			[]*json.RawMessage{rm1, rmInvalidError},
+			false,
+		},
+		{
+			"failed to get subscriptions",
+			fnNewListor(def.AZURE, []string{def.SUBSCRIPTION_ALL, "sub-1"}, ""),
+			args{authProvider},
+			nil,
+			true,
+		},
+		{
+			"subscriptions not supported for cloud type",
+			fnNewListor(def.TENCENT_CLOUD, []string{"sub-1"}, ""),
+			args{authProvider},
+			nil,
+			true,
+		},
+		{
+			"subscriptions not supported for Microsoft Graph",
+			NewListor(&def.ConfListor{
+				CloudType:     def.AZURE,
+				ListCmd:       def.ConfListCmd{Azure: def.ConfAzureCmd{Api: def.AZURE_API_GRAPH}},
+				Subscriptions: []string{"sub-1"},
+			}, nil),
+			args{authProvider},
+			nil,
+			true,
+		},
+		{
+			"nil pointor of IAuthProvider",
+			fnNewListor(def.AZURE, []string{"sub-1"}, ""),
+			args{nil},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.l.listDataInSubscriptions(tt.args.authProvider)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Listor.listDataInSubscriptions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Listor.listDataInSubscriptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getSubscriptionOfRawData(t *testing.T) {
+	rm, _ := internal.JsonMarshal(map[string]any{"id": "mock"})
+	rmTagged, _ := internal.JsonMarshal(map[string]any{"id": "mock", RAW_DATA_SUBSCRIPTION_KEY: "sub-1"})
+
+	type args struct {
+		rawData *json.RawMessage
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			"Valid result",
+			args{rmTagged},
+			"sub-1",
+		},
+		{
+			"Raw data not tagged",
+			args{rm},
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getSubscriptionOfRawData(tt.args.rawData); got != tt.want {
+				t.Errorf("getSubscriptionOfRawData() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Validators of properties used by Checker
+
+package framework
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// iValidator: Interface to validate a property against the rule of benchmark
+type iValidator interface {
+	// validate: Validate the property
+	// @param: prop: Property to validate
+	// @return: Whether the property matches the rule, that is, the resource is in risk
+	// @return: Messages of the rule explaining the risk, if provided by the validator
+	// @return: Error
+	validate(prop *json.RawMessage) (bool, []string, error)
+}
+
+// newValidator: Create validator according to the definition
+//
+// The string with key of DynValidateValue surrounded by "%" is replaced in the rule before creation,
+// and so are the time-relative variables such as "%NOW-90d%".
+// @param: conf: Definition of validator
+// @return: Instance of validator
+// @return: Error
+func newValidator(conf *def.ConfValidator) (iValidator, error) {
+	count := 0
+	for _, rule := range []string{conf.ValidateSchema, conf.ValidateCel, conf.ValidateRego} {
+		if len(rule) > 0 {
+			count++
+		}
+	}
+	if count > 1 {
+		return nil, errors.New("only one of validate_schema, validate_cel and validate_rego can be used")
+	}
+
+	fnReplace := func(rule string) string {
+		for k, v := range conf.DynValidateValue {
+			rule = strings.ReplaceAll(rule, fmt.Sprintf("%%%s%%", k), v) // replace %k% to v
+		}
+		// Time-relative variables are expanded after, so that they can be used in DynValidateValue
+		return expandTimeVariables(rule)
+	}
+
+	if len(conf.ValidateCel) > 0 {
+		return newCelValidator(fnReplace(conf.ValidateCel))
+	}
+	if len(conf.ValidateRego) > 0 {
+		return newRegoValidator(fnReplace(conf.ValidateRego))
+	}
+
+	return newJsonSchemaValidator(fnReplace(conf.ValidateSchema))
+}
+
+// CheckValidatorConf: Check the definition of validator on loading conf file
+//
+// Expression of CEL and policy of Rego are compiled, so that errors are reported before any resource is listed.
+// JsonSchema is still created on the first validation.
+// @param: conf: Definition of validator
+// @return: Error
+func CheckValidatorConf(conf *def.ConfValidator) error {
+	if len(conf.ValidateCel) == 0 && len(conf.ValidateRego) == 0 {
+		return nil
+	}
+
+	_, err := newValidator(conf)
+	return err
+}
+
+// jsonSchemaValidator: Validator matching the property with JsonSchema
+type jsonSchemaValidator struct {
+	// JsonSchema after dynamic values are replaced
+	schema string
+	// Instance of JsonSchema
+	js *gojsonschema.Schema
+}
+
+// newJsonSchemaValidator: Constructor of jsonSchemaValidator
+// @param: schema: JsonSchema
+func newJsonSchemaValidator(schema string) (*jsonSchemaValidator, error) {
+	registerTimeFormats(schema)
+
+	js, err := gojsonschema.NewSchema(gojsonschema.NewStringLoader(schema))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create jsonschema: %w", err)
+	}
+
+	return &jsonSchemaValidator{schema: schema, js: js}, nil
+}
+
+// validate: Implementation of iValidator.validate
+func (v *jsonSchemaValidator) validate(prop *json.RawMessage) (bool, []string, error) {
+	jsResult, err := v.js.Validate(gojsonschema.NewBytesLoader(*prop))
+	if err != nil {
+		return false, nil, err
+	}
+
+	return jsResult.Valid(), nil, nil
+}
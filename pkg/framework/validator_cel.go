@@ -0,0 +1,84 @@
+// Validator of properties using expression of CEL
+
+package framework
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+)
+
+const (
+	CEL_VAR_PROP = "prop" // Name of variable of the property in expression of CEL
+	CEL_VAR_NOW  = "now"  // Name of variable of the current time in expression of CEL
+)
+
+// celValidator: Validator evaluating expression of CEL against the property
+type celValidator struct {
+	program cel.Program
+}
+
+// newCelValidator: Constructor of celValidator
+//
+// The expression must return a bool, and the resource is in risk if it returns true.
+// Variables available in the expression:
+// 1. prop: Property extracted, of type of dyn
+// 2. now: Current time, of type of timestamp
+// @param: expr: Expression of CEL
+// @return: Instance of celValidator
+// @return: Error
+func newCelValidator(expr string) (*celValidator, error) {
+	env, err := cel.NewEnv(
+		cel.Variable(CEL_VAR_PROP, cel.DynType),
+		cel.Variable(CEL_VAR_NOW, cel.TimestampType),
+		ext.Strings(),
+		ext.Encoders(),
+		ext.Math(),
+		ext.Lists(),
+		ext.Sets(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create environment of cel: %w", err)
+	}
+
+	ast, issues := env.Compile(expr)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("failed to compile cel: %w", issues.Err())
+	}
+	if outputType := ast.OutputType(); outputType != cel.BoolType && outputType != cel.DynType {
+		return nil, fmt.Errorf("cel must return bool, got %s", outputType)
+	}
+
+	program, err := env.Program(ast)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create program of cel: %w", err)
+	}
+
+	return &celValidator{program: program}, nil
+}
+
+// validate: Implementation of iValidator.validate
+func (v *celValidator) validate(prop *json.RawMessage) (bool, []string, error) {
+	var value any
+	if err := json.Unmarshal(*prop, &value); err != nil {
+		return false, nil, err
+	}
+
+	out, _, err := v.program.Eval(map[string]any{
+		CEL_VAR_PROP: value,
+		CEL_VAR_NOW:  _validatorClock(),
+	})
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to evaluate cel: %w", err)
+	}
+
+	inRisk, ok := out.Value().(bool)
+	if !ok {
+		return false, nil, errors.New("result of cel is not bool")
+	}
+
+	return inRisk, nil, nil
+}
@@ -0,0 +1,121 @@
+// Validator of properties using expression of CEL
+
+package framework
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func Test_newCelValidator(t *testing.T) {
+	type args struct {
+		expr string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{"Valid result", args{`prop.port == 22`}, false},
+		{"Valid result with ext library", args{`prop.name.lowerAscii().startsWith("test")`}, false},
+		{"failed to compile cel", args{`prop.port ==`}, true},
+		{"undeclared variable", args{`mock.port == 22`}, true},
+		{"cel not returning bool", args{`"mock"`}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newCelValidator(tt.args.expr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newCelValidator() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got == nil {
+				t.Errorf("newCelValidator() = nil")
+			}
+		})
+	}
+}
+
+func Test_celValidator_validate(t *testing.T) {
+	created := time.Now().Add(-24 * 100 * time.Hour).UTC().Format(time.RFC3339)
+	rm := json.RawMessage(`{"port": 22, "cidr": ["10.0.0.0/8", "0.0.0.0/0"], "flags": "a,b,c", "created": "` +
+		created + `"}`)
+	rmInvalid := json.RawMessage(`invalid`)
+
+	type args struct {
+		expr string
+		prop *json.RawMessage
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			"Valid result of numeric comparison",
+			args{`prop.port < 1024`, &rm},
+			true,
+			false,
+		},
+		{
+			"Valid result of list",
+			args{`"0.0.0.0/0" in prop.cidr`, &rm},
+			true,
+			false,
+		},
+		{
+			"Valid result of parsing flags",
+			args{`!("d" in prop.flags.split(","))`, &rm},
+			true,
+			false,
+		},
+		{
+			"Valid result of date math",
+			args{`timestamp(prop.created) < now - duration("2160h")`, &rm},
+			true,
+			false,
+		},
+		{
+			"Valid result not in risk",
+			args{`timestamp(prop.created) < now - duration("4320h")`, &rm},
+			false,
+			false,
+		},
+		{
+			"failed to evaluate cel",
+			args{`prop.missing == 1`, &rm},
+			false,
+			true,
+		},
+		{
+			"result of cel is not bool",
+			args{`prop.port`, &rm},
+			false,
+			true,
+		},
+		{
+			"failed to unmarshal prop",
+			args{`true`, &rmInvalid},
+			false,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := newCelValidator(tt.args.expr)
+			if err != nil {
+				t.Fatalf("newCelValidator() error = %v", err)
+			}
+			got, _, err := v.validate(tt.args.prop)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("celValidator.validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("celValidator.validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+// Validator of properties using policy of Rego
+
+package framework
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/s3studio/cloud-bench-checker/internal"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+)
+
+const REGO_MODULE_FILENAME = "validate_rego.rego" // Name of file of the module of Rego, used in errors of compilation
+
+// _regoRules: Rules of Rego deciding the resource is in risk, in the style of conftest and gatekeeper
+var _regoRules = []string{"deny", "violation"}
+
+// _regoBuiltinsDisabled: Builtins of Rego not allowed in validator, to avoid access to network or runtime
+var _regoBuiltinsDisabled = []string{"http.send", "net.lookup_ip_addr", "opa.runtime"}
+
+// regoValidator: Validator evaluating policy of Rego against the property
+type regoValidator struct {
+	query rego.PreparedEvalQuery
+}
+
+// newRegoValidator: Constructor of regoValidator
+//
+// The module must define rule of "deny" or "violation" in its package, and the resource is in risk
+// if any of them is not empty. The property is used as "input".
+// @param: module: Module of Rego
+// @return: Instance of regoValidator
+// @return: Error
+func newRegoValidator(module string) (*regoValidator, error) {
+	parsed, err := ast.ParseModule(REGO_MODULE_FILENAME, module)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse rego: %w", err)
+	}
+	if parsed == nil {
+		return nil, errors.New("failed to parse rego: empty module")
+	}
+
+	if !slices.ContainsFunc(parsed.Rules, func(rule *ast.Rule) bool {
+		return slices.Contains(_regoRules, rule.Head.Ref().String())
+	}) {
+		return nil, fmt.Errorf("rego must define rule of %v", _regoRules)
+	}
+
+	capabilities := ast.CapabilitiesForThisVersion()
+	capabilities.Builtins = slices.DeleteFunc(capabilities.Builtins, func(builtin *ast.Builtin) bool {
+		return slices.Contains(_regoBuiltinsDisabled, builtin.Name)
+	})
+
+	query, err := rego.New(
+		rego.Query(parsed.Package.Path.String()),
+		rego.Module(REGO_MODULE_FILENAME, module),
+		rego.Capabilities(capabilities),
+	).PrepareForEval(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile rego: %w", err)
+	}
+
+	return &regoValidator{query: query}, nil
+}
+
+// validate: Implementation of iValidator.validate
+func (v *regoValidator) validate(prop *json.RawMessage) (bool, []string, error) {
+	var value any
+	if err := json.Unmarshal(*prop, &value); err != nil {
+		return false, nil, err
+	}
+
+	rs, err := v.query.Eval(context.Background(), rego.EvalInput(value), rego.EvalTime(_validatorClock()))
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to evaluate rego: %w", err)
+	}
+	if len(rs) == 0 || len(rs[0].Expressions) == 0 {
+		return false, nil, errors.New("result of rego is undefined")
+	}
+	document, ok := rs[0].Expressions[0].Value.(map[string]any)
+	if !ok {
+		return false, nil, errors.New("result of rego is not object")
+	}
+
+	var inRisk bool
+	var messages []string
+	for _, rule := range _regoRules {
+		value, ok := document[rule]
+		if !ok {
+			// Rule undefined, e.g. no rule of deny in the module
+			continue
+		}
+		items, ok := value.([]any)
+		if !ok {
+			// A boolean rule such as "deny { input.public }" would be ignored silently otherwise
+			return false, nil, fmt.Errorf("result of rule \"%s\" of rego is not a set", rule)
+		}
+		for _, item := range items {
+			inRisk = true
+			if message := getRegoMessage(item); message != "" {
+				messages = append(messages, message)
+			}
+		}
+	}
+
+	return inRisk, messages, nil
+}
+
+// getRegoMessage: Get message of an item of deny or violation
+//
+// The item is either a string, an object with key of "msg" as gatekeeper does,
+// or any other value which is marshaled to json.
+func getRegoMessage(item any) string {
+	switch v := item.(type) {
+	case string:
+		return v
+	case map[string]any:
+		if msg, ok := v["msg"].(string); ok {
+			return msg
+		}
+	}
+
+	rm, err := internal.JsonMarshal(item)
+	if err != nil {
+		return ""
+	}
+	return string(*rm)
+}
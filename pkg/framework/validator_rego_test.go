@@ -0,0 +1,195 @@
+// Validator of properties using policy of Rego
+
+package framework
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func Test_newRegoValidator(t *testing.T) {
+	type args struct {
+		module string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			"Valid result of deny",
+			args{"package mock\ndeny[msg] { input.public; msg := \"public\" }"},
+			false,
+		},
+		{
+			"Valid result of violation with rego.v1",
+			args{"package mock\nimport rego.v1\nviolation contains {\"msg\": \"public\"} if input.public"},
+			false,
+		},
+		{
+			"failed to parse rego",
+			args{"package mock\ndeny[msg] {"},
+			true,
+		},
+		{
+			"empty module",
+			args{""},
+			true,
+		},
+		{
+			"rule of deny or violation not defined",
+			args{"package mock\nallow { input.public }"},
+			true,
+		},
+		{
+			"failed to compile rego",
+			args{"package mock\ndeny[msg] { msg := undefined_func(input) }"},
+			true,
+		},
+		{
+			"builtin disabled",
+			args{"package mock\ndeny[msg] { msg := http.send({\"method\": \"get\", \"url\": \"http://mock\"}) }"},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newRegoValidator(tt.args.module)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newRegoValidator() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got == nil {
+				t.Errorf("newRegoValidator() = nil")
+			}
+		})
+	}
+}
+
+func Test_regoValidator_validate(t *testing.T) {
+	module := `package mock
+deny[msg] {
+	input.public
+	msg := "public access is allowed"
+}
+violation[{"msg": msg}] {
+	input.tls < 2
+	msg := sprintf("tls version %v is too low", [input.tls])
+}
+violation[{"port": port}] {
+	port := input.ports[_]
+	port < 1024
+}`
+	rmBoth := json.RawMessage(`{"public": true, "tls": 1, "ports": []}`)
+	rmPort := json.RawMessage(`{"public": false, "tls": 2, "ports": [22]}`)
+	rmNone := json.RawMessage(`{"public": false, "tls": 2, "ports": [8080]}`)
+	rmInvalid := json.RawMessage(`invalid`)
+	v, err := newRegoValidator(module)
+	if err != nil {
+		t.Fatalf("newRegoValidator() error = %v", err)
+	}
+	vBoolean, err := newRegoValidator("package mock\ndeny { input.public }")
+	if err != nil {
+		t.Fatalf("newRegoValidator() error = %v", err)
+	}
+
+	type args struct {
+		prop *json.RawMessage
+	}
+	tests := []struct {
+		name         string
+		v            *regoValidator
+		args         args
+		want         bool
+		wantMessages []string
+		wantErr      bool
+	}{
+		{
+			"Valid result of deny and violation",
+			v,
+			args{&rmBoth},
+			true,
+			[]string{"public access is allowed", "tls version 1 is too low"},
+			false,
+		},
+		{
+			"Valid result of violation without msg",
+			v,
+			args{&rmPort},
+			true,
+			[]string{`{"port":22}`},
+			false,
+		},
+		{
+			"Valid result not in risk",
+			v,
+			args{&rmNone},
+			false,
+			nil,
+			false,
+		},
+		{
+			"failed to unmarshal prop",
+			v,
+			args{&rmInvalid},
+			false,
+			nil,
+			true,
+		},
+		{
+			"Valid result of boolean rule undefined",
+			vBoolean,
+			args{&rmNone},
+			false,
+			nil,
+			false,
+		},
+		{
+			"result of boolean rule is not a set",
+			vBoolean,
+			args{&rmBoth},
+			false,
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotMessages, err := tt.v.validate(tt.args.prop)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("regoValidator.validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("regoValidator.validate() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(gotMessages, tt.wantMessages) {
+				t.Errorf("regoValidator.validate() gotMessages = %v, want %v", gotMessages, tt.wantMessages)
+			}
+		})
+	}
+}
+
+func Test_getRegoMessage(t *testing.T) {
+	type args struct {
+		item any
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{"Valid result of string", args{"mock"}, "mock"},
+		{"Valid result of msg", args{map[string]any{"msg": "mock", "details": 1}}, "mock"},
+		{"Valid result of other object", args{map[string]any{"details": 1}}, `{"details":1}`},
+		{"Valid result of number", args{json.Number("1")}, "1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getRegoMessage(tt.args.item); got != tt.want {
+				t.Errorf("getRegoMessage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
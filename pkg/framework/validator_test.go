@@ -0,0 +1,165 @@
+// Validators of properties used by Checker
+
+package framework
+
+import (
+	"encoding/json"
+	"testing"
+
+	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
+)
+
+func Test_newValidator(t *testing.T) {
+	rm := json.RawMessage(`{"status": "Enabled"}`)
+
+	type args struct {
+		conf *def.ConfValidator
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantInRisk bool
+		wantErr    bool
+	}{
+		{
+			"Valid result of jsonschema",
+			args{&def.ConfValidator{
+				ValidateSchema: `{"properties": {"status": {"const": "Enabled"}}}`,
+			}},
+			true,
+			false,
+		},
+		{
+			"Valid result of jsonschema with DynValidateValue",
+			args{&def.ConfValidator{
+				ValidateSchema:   `{"properties": {"status": {"const": "%status%"}}}`,
+				DynValidateValue: map[string]string{"status": "Disabled"},
+			}},
+			false,
+			false,
+		},
+		{
+			"Valid result of cel",
+			args{&def.ConfValidator{
+				ValidateCel: `prop.status == "Enabled"`,
+			}},
+			true,
+			false,
+		},
+		{
+			"Valid result of cel with DynValidateValue",
+			args{&def.ConfValidator{
+				ValidateCel:      `prop.status == "%status%"`,
+				DynValidateValue: map[string]string{"status": "Disabled"},
+			}},
+			false,
+			false,
+		},
+		{
+			"Valid result of rego",
+			args{&def.ConfValidator{
+				ValidateRego: "package mock\ndeny[msg] { input.status == \"Enabled\"; msg := \"enabled\" }",
+			}},
+			true,
+			false,
+		},
+		{
+			"Valid result of rego with DynValidateValue",
+			args{&def.ConfValidator{
+				ValidateRego:     "package mock\ndeny[msg] { input.status == \"%status%\"; msg := \"disabled\" }",
+				DynValidateValue: map[string]string{"status": "Disabled"},
+			}},
+			false,
+			false,
+		},
+		{
+			"more than one of rules used together",
+			args{&def.ConfValidator{
+				ValidateSchema: `{"type": "object"}`,
+				ValidateCel:    `true`,
+			}},
+			false,
+			true,
+		},
+		{
+			"failed to create jsonschema",
+			args{&def.ConfValidator{
+				ValidateSchema: `invalid`,
+			}},
+			false,
+			true,
+		},
+		{
+			"failed to compile cel",
+			args{&def.ConfValidator{
+				ValidateCel: `prop.status ==`,
+			}},
+			false,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newValidator(tt.args.conf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("newValidator() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			inRisk, _, err := got.validate(&rm)
+			if err != nil {
+				t.Errorf("validate() error = %v", err)
+				return
+			}
+			if inRisk != tt.wantInRisk {
+				t.Errorf("validate() = %v, want %v", inRisk, tt.wantInRisk)
+			}
+		})
+	}
+}
+
+func TestCheckValidatorConf(t *testing.T) {
+	type args struct {
+		conf *def.ConfValidator
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			"Valid result of cel",
+			args{&def.ConfValidator{ValidateCel: `prop.enabled == false`}},
+			false,
+		},
+		{
+			"Valid result of jsonschema not created on loading",
+			args{&def.ConfValidator{ValidateSchema: `invalid`}},
+			false,
+		},
+		{
+			"failed to compile cel",
+			args{&def.ConfValidator{ValidateCel: `prop.enabled ==`}},
+			true,
+		},
+		{
+			"failed to compile rego",
+			args{&def.ConfValidator{ValidateRego: "package mock\nallow { true }"}},
+			true,
+		},
+		{
+			"validate_schema and validate_cel used together",
+			args{&def.ConfValidator{ValidateSchema: `{}`, ValidateCel: `true`}},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckValidatorConf(tt.args.conf); (err != nil) != tt.wantErr {
+				t.Errorf("CheckValidatorConf() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
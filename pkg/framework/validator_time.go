@@ -0,0 +1,179 @@
+// Time-relative variables and format checkers of JsonSchema used by validators
+
+package framework
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"time"
+
+	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+const (
+	TIME_FORMAT_OLDER_THAN     = "older-than"     // Format of time earlier than the duration before now
+	TIME_FORMAT_EXPIRES_WITHIN = "expires-within" // Format of time earlier than the duration after now
+)
+
+// _validatorClock: Clock used by validators as the current time
+var _validatorClock = time.Now
+
+// _regTimeVariable: Time-relative variable such as "%NOW%" or "%NOW-90d%"
+var _regTimeVariable = regexp.MustCompile(`%NOW(?:([+-]\d+[smhdw]))?%`)
+
+// _regTimeFormat: Time-relative format of JsonSchema such as "older-than-90d"
+var _regTimeFormat = regexp.MustCompile(fmt.Sprintf(`"format"\s*:\s*"((%s|%s)-(\d+[smhdw]))"`,
+	TIME_FORMAT_OLDER_THAN, TIME_FORMAT_EXPIRES_WITHIN))
+
+// _timeLayouts: Layouts of time accepted by format checkers, in addition to unix timestamp in seconds
+var _timeLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", time.DateOnly}
+
+// SetValidatorClock: Set clock used by validators as the current time
+//
+// The clock is used by time-relative variables, format checkers of JsonSchema, "now" of CEL and time of Rego.
+// @param: clock: Function returning the current time, nil to reset to time.Now
+func SetValidatorClock(clock func() time.Time) {
+	if clock == nil {
+		clock = time.Now
+	}
+	_validatorClock = clock
+}
+
+// parseRelativeDuration: Parse duration with unit of s, m, h, d (day) or w (week), such as "90d" or "-12h"
+func parseRelativeDuration(s string) (time.Duration, error) {
+	if len(s) < 2 {
+		return 0, fmt.Errorf("invalid relative duration: %s", s)
+	}
+
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil {
+		return 0, fmt.Errorf("invalid relative duration: %s", s)
+	}
+
+	var unit time.Duration
+	switch s[len(s)-1] {
+	case 's':
+		unit = time.Second
+	case 'm':
+		unit = time.Minute
+	case 'h':
+		unit = time.Hour
+	case 'd':
+		unit = 24 * time.Hour
+	case 'w':
+		unit = 7 * 24 * time.Hour
+	default:
+		return 0, fmt.Errorf("invalid unit of relative duration: %s", s)
+	}
+
+	return time.Duration(n) * unit, nil
+}
+
+// expandTimeVariables: Replace time-relative variables in the rule with time in RFC3339 of UTC
+//
+// "%NOW%" is replaced with the current time, and "%NOW-90d%" with 90 days before it.
+// @param: rule: Rule of validator
+// @return: Rule with variables replaced
+func expandTimeVariables(rule string) string {
+	now := _validatorClock()
+	return _regTimeVariable.ReplaceAllStringFunc(rule, func(variable string) string {
+		t := now
+		if offset := _regTimeVariable.FindStringSubmatch(variable)[1]; offset != "" {
+			// Validated by the regexp
+			d, _ := parseRelativeDuration(offset)
+			t = t.Add(d)
+		}
+		return t.UTC().Format(time.RFC3339)
+	})
+}
+
+// hasTimeVariables: Check whether time-relative variables are used in the rule or DynValidateValue of validator
+// @param: conf: Definition of validator
+// @return: Whether time-relative variables are used
+func hasTimeVariables(conf *def.ConfValidator) bool {
+	for _, rule := range []string{conf.ValidateSchema, conf.ValidateCel, conf.ValidateRego} {
+		if _regTimeVariable.MatchString(rule) {
+			return true
+		}
+	}
+	for _, v := range conf.DynValidateValue {
+		if _regTimeVariable.MatchString(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// registerTimeFormats: Register format checkers of time-relative formats used in JsonSchema
+//
+// As format checkers of gojsonschema are registered by name, each format with its duration,
+// such as "older-than-90d", is registered on its first use.
+// @param: schema: JsonSchema
+func registerTimeFormats(schema string) {
+	for _, match := range _regTimeFormat.FindAllStringSubmatch(schema, -1) {
+		name, kind := match[1], match[2]
+		if gojsonschema.FormatCheckers.Has(name) {
+			continue
+		}
+
+		// Validated by the regexp
+		d, _ := parseRelativeDuration(match[3])
+		gojsonschema.FormatCheckers.Add(name, timeFormatChecker{kind: kind, duration: d})
+	}
+}
+
+// timeFormatChecker: Format checker of gojsonschema matching time relative to now
+type timeFormatChecker struct {
+	// TIME_FORMAT_OLDER_THAN or TIME_FORMAT_EXPIRES_WITHIN
+	kind string
+	// Duration relative to now
+	duration time.Duration
+}
+
+// IsFormat: Implementation of gojsonschema.FormatChecker
+//
+// Values not parsed as time do not match the format.
+func (c timeFormatChecker) IsFormat(input any) bool {
+	t, ok := parseTime(input)
+	if !ok {
+		return false
+	}
+
+	now := _validatorClock()
+	switch c.kind {
+	case TIME_FORMAT_OLDER_THAN:
+		return t.Before(now.Add(-c.duration))
+	case TIME_FORMAT_EXPIRES_WITHIN:
+		return t.Before(now.Add(c.duration))
+	default:
+		return false
+	}
+}
+
+// parseTime: Parse value of property to time
+// @param: input: String in one of _timeLayouts, or number of unix timestamp in seconds
+// @return: Time parsed
+// @return: Whether the value is parsed
+func parseTime(input any) (time.Time, bool) {
+	switch v := input.(type) {
+	case string:
+		for _, layout := range _timeLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, true
+			}
+		}
+	case *big.Rat:
+		// Number is passed by gojsonschema as *big.Rat
+		f, _ := v.Float64()
+		return time.Unix(int64(f), 0), true
+	case float64:
+		return time.Unix(int64(v), 0), true
+	}
+
+	return time.Time{}, false
+}
@@ -0,0 +1,258 @@
+// Time-relative variables and format checkers of JsonSchema used by validators
+
+package framework
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
+)
+
+// setupValidatorClock: Set clock of validators to a fixed time, reset on cleanup
+func setupValidatorClock(t *testing.T, now time.Time) {
+	SetValidatorClock(func() time.Time { return now })
+	t.Cleanup(func() { SetValidatorClock(nil) })
+}
+
+func Test_parseRelativeDuration(t *testing.T) {
+	type args struct {
+		s string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    time.Duration
+		wantErr bool
+	}{
+		{"Valid result of days", args{"90d"}, 90 * 24 * time.Hour, false},
+		{"Valid result of negative weeks", args{"-2w"}, -14 * 24 * time.Hour, false},
+		{"Valid result of positive hours", args{"+12h"}, 12 * time.Hour, false},
+		{"Valid result of minutes", args{"30m"}, 30 * time.Minute, false},
+		{"Valid result of seconds", args{"10s"}, 10 * time.Second, false},
+		{"invalid unit", args{"90y"}, 0, true},
+		{"invalid number", args{"xd"}, 0, true},
+		{"too short", args{"d"}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseRelativeDuration(tt.args.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseRelativeDuration() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("parseRelativeDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_expandTimeVariables(t *testing.T) {
+	setupValidatorClock(t, time.Date(2024, 3, 31, 8, 0, 0, 0, time.FixedZone("mock", 8*3600)))
+
+	type args struct {
+		rule string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{"Valid result of now", args{`"%NOW%"`}, `"2024-03-31T00:00:00Z"`},
+		{"Valid result of before", args{`"%NOW-90d%"`}, `"2024-01-01T00:00:00Z"`},
+		{"Valid result of after", args{`"%NOW+1w%"`}, `"2024-04-07T00:00:00Z"`},
+		{"Valid result of multiple", args{`%NOW-1h% %NOW%`}, `2024-03-30T23:00:00Z 2024-03-31T00:00:00Z`},
+		{"Valid result of not variable", args{`%NOW-90y% %NOWADAYS%`}, `%NOW-90y% %NOWADAYS%`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := expandTimeVariables(tt.args.rule); got != tt.want {
+				t.Errorf("expandTimeVariables() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_timeFormatChecker_IsFormat(t *testing.T) {
+	setupValidatorClock(t, time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC))
+
+	type args struct {
+		input any
+	}
+	tests := []struct {
+		name string
+		c    timeFormatChecker
+		args args
+		want bool
+	}{
+		{
+			"Valid result of older-than",
+			timeFormatChecker{TIME_FORMAT_OLDER_THAN, 90 * 24 * time.Hour},
+			args{"2023-12-31T23:59:59Z"},
+			true,
+		},
+		{
+			"Valid result of not older-than",
+			timeFormatChecker{TIME_FORMAT_OLDER_THAN, 90 * 24 * time.Hour},
+			args{"2024-01-01T00:00:01+00:00"},
+			false,
+		},
+		{
+			"Valid result of expires-within",
+			timeFormatChecker{TIME_FORMAT_EXPIRES_WITHIN, 30 * 24 * time.Hour},
+			args{"2024-04-15"},
+			true,
+		},
+		{
+			"Valid result of expired",
+			timeFormatChecker{TIME_FORMAT_EXPIRES_WITHIN, 30 * 24 * time.Hour},
+			args{"2024-01-01 00:00:00"},
+			true,
+		},
+		{
+			"Valid result of not expires-within",
+			timeFormatChecker{TIME_FORMAT_EXPIRES_WITHIN, 30 * 24 * time.Hour},
+			args{"2024-05-01T00:00:00.123456Z"},
+			false,
+		},
+		{
+			"Valid result of unix timestamp",
+			timeFormatChecker{TIME_FORMAT_EXPIRES_WITHIN, 30 * 24 * time.Hour},
+			args{big.NewRat(1711929600, 1)}, // 2024-04-01
+			true,
+		},
+		{
+			"Value not parsed as time",
+			timeFormatChecker{TIME_FORMAT_OLDER_THAN, 90 * 24 * time.Hour},
+			args{"N/A"},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.IsFormat(tt.args.input); got != tt.want {
+				t.Errorf("timeFormatChecker.IsFormat() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_hasTimeVariables(t *testing.T) {
+	type args struct {
+		conf *def.ConfValidator
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			"Variable in rule",
+			args{&def.ConfValidator{ValidateRego: `package mock
+deny[msg] { time.parse_rfc3339_ns(input.created) < time.parse_rfc3339_ns("%NOW-90d%"); msg := "old" }`}},
+			true,
+		},
+		{
+			"Variable in DynValidateValue",
+			args{&def.ConfValidator{ValidateCel: `prop.created < "%deadline%"`, DynValidateValue: map[string]string{"deadline": "%NOW%"}}},
+			true,
+		},
+		{
+			"No variable",
+			args{&def.ConfValidator{ValidateSchema: `{"properties": {"created": {"format": "older-than-90d"}}}`}},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasTimeVariables(tt.args.conf); got != tt.want {
+				t.Errorf("hasTimeVariables() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newValidator_time(t *testing.T) {
+	setupValidatorClock(t, time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC))
+	rm := json.RawMessage(`{"created": "2023-12-01T00:00:00Z", "expires": 1711929600}`)
+
+	type args struct {
+		conf *def.ConfValidator
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			"Valid result of format older-than",
+			args{&def.ConfValidator{
+				ValidateSchema: `{"properties": {"created": {"type": "string", "format": "older-than-90d"}}}`,
+			}},
+			true,
+		},
+		{
+			"Valid result of format older-than in DynValidateValue",
+			args{&def.ConfValidator{
+				ValidateSchema:   `{"properties": {"created": {"type": "string", "format": "older-than-%days%"}}}`,
+				DynValidateValue: map[string]string{"days": "180d"},
+			}},
+			false,
+		},
+		{
+			"Valid result of format expires-within",
+			args{&def.ConfValidator{
+				ValidateSchema: `{"properties": {"expires": {"type": "number", "format": "expires-within-7d"}}}`,
+			}},
+			true,
+		},
+		{
+			"Valid result of variable in cel",
+			args{&def.ConfValidator{
+				ValidateCel: `timestamp(prop.created) < timestamp("%NOW-90d%")`,
+			}},
+			true,
+		},
+		{
+			"Valid result of variable in DynValidateValue",
+			args{&def.ConfValidator{
+				ValidateCel:      `timestamp(prop.created) < timestamp("%deadline%")`,
+				DynValidateValue: map[string]string{"deadline": "%NOW-180d%"},
+			}},
+			false,
+		},
+		{
+			"Valid result of now of cel",
+			args{&def.ConfValidator{
+				ValidateCel: `now == timestamp("2024-03-31T00:00:00Z")`,
+			}},
+			true,
+		},
+		{
+			"Valid result of time of rego",
+			args{&def.ConfValidator{
+				ValidateRego: "package mock\ndeny[msg] { time.parse_rfc3339_ns(input.created) < time.now_ns() - 90 * 86400 * 1e9; msg := \"old\" }",
+			}},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := newValidator(tt.args.conf)
+			if err != nil {
+				t.Fatalf("newValidator() error = %v", err)
+			}
+			got, _, err := v.validate(&rm)
+			if err != nil {
+				t.Errorf("validate() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+// Waivers to suppress accepted risks in results of validation
+
+package framework
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"time"
+
+	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
+
+	"gopkg.in/yaml.v3"
+)
+
+// waiver: Waiver prepared from the definition
+type waiver struct {
+	// Definition of waiver
+	conf *def.ConfWaiver
+	// Regexp of name of resource, nil if not set
+	regName *regexp.Regexp
+	// Time when the waiver expires
+	expiry time.Time
+}
+
+// WaiverList: Used to apply waivers to results of validation
+//
+// A waiver accepts the risk of results of OUTCOME_FAIL matching all of its conditions until it expires.
+// Such results are reported as OUTCOME_WAIVED instead of being dropped.
+type WaiverList struct {
+	waivers []*waiver
+}
+
+// NewWaiverList: Constructor of WaiverList
+// @param: conf: Definition of waivers
+// @return: Instance of WaiverList
+// @return: Error
+func NewWaiverList(conf *def.ConfWaiverFile) (*WaiverList, error) {
+	if conf == nil {
+		return nil, errors.New("nil pointor of definition of waivers")
+	}
+
+	list := WaiverList{waivers: make([]*waiver, len(conf.Waiver))}
+	for i := range conf.Waiver {
+		w, err := newWaiver(&conf.Waiver[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid waiver #%d: %w", i, err)
+		}
+		list.waivers[i] = w
+	}
+
+	return &list, nil
+}
+
+// LoadWaiverFile: Load waivers from a file in yaml format
+//
+// A relative pathname is resolved against the directory of the conf file defining it as waiver_file,
+// so that the command tool and apiserver load the same file for the same conf file.
+// @param: pathname: Pathname of the file of waivers
+// @param: confFilePath: Pathname of the conf file defining the file of waivers,
+// or empty to resolve a relative pathname against the working directory, e.g. for the argument of command tool
+// @return: Instance of WaiverList
+// @return: Error
+func LoadWaiverFile(pathname string, confFilePath string) (*WaiverList, error) {
+	if len(confFilePath) > 0 && !filepath.IsAbs(pathname) {
+		pathname = filepath.Join(filepath.Dir(confFilePath), pathname)
+	}
+
+	by, err := os.ReadFile(pathname)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file of waivers: %w", err)
+	}
+
+	var conf def.ConfWaiverFile
+	if err := yaml.Unmarshal(by, &conf); err != nil {
+		return nil, fmt.Errorf("failed to load file of waivers as yaml: %w", err)
+	}
+
+	return NewWaiverList(&conf)
+}
+
+// newWaiver: Prepare the waiver
+//
+// A waiver must have justification, owner, expiry and at least one condition,
+// so that no risk is accepted without being reviewed later.
+func newWaiver(conf *def.ConfWaiver) (*waiver, error) {
+	if conf.Justification == "" || conf.Owner == "" {
+		return nil, errors.New("justification and owner are required")
+	}
+	if len(conf.Tag) == 0 && len(conf.Metadata) == 0 && conf.CloudType == "" &&
+		conf.ResourceId == "" && conf.ResourceName == "" {
+		return nil, errors.New("at least one of tag, metadata, cloud_type, resource_id and resource_name is required")
+	}
+
+	w := waiver{conf: conf}
+	if t, err := time.Parse(time.DateOnly, conf.Expiry); err == nil {
+		// The waiver is valid until the end of the date
+		w.expiry = t.AddDate(0, 0, 1)
+	} else if t, err := time.Parse(time.RFC3339, conf.Expiry); err == nil {
+		w.expiry = t
+	} else {
+		return nil, fmt.Errorf("failed to parse expiry \"%s\", should be in the format of 2006-01-02 or RFC3339",
+			conf.Expiry)
+	}
+
+	if conf.ResourceName != "" {
+		var err error
+		if w.regName, err = regexp.Compile(conf.ResourceName); err != nil {
+			return nil, fmt.Errorf("failed to compile resource_name: %w", err)
+		}
+	}
+
+	return &w, nil
+}
+
+// match: Check whether the result of the Baseline matches all conditions of the waiver
+func (w *waiver) match(b *Baseline, result *ValidateResult) bool {
+	if len(w.conf.Tag) > 0 && !slices.ContainsFunc(b.GetTag(), func(tag string) bool {
+		return slices.Contains(w.conf.Tag, tag)
+	}) {
+		return false
+	}
+	for k, v := range w.conf.Metadata {
+		if value, ok := (*b.GetMetadata())[k]; !ok || value != v {
+			return false
+		}
+	}
+	if w.conf.CloudType != "" && w.conf.CloudType != result.CloudType {
+		return false
+	}
+	if w.conf.ResourceId != "" && w.conf.ResourceId != result.Id {
+		return false
+	}
+	if w.regName != nil && !w.regName.MatchString(result.Name) {
+		return false
+	}
+
+	return true
+}
+
+// describe: Get description of the waiver to be outputted with the result
+func (w *waiver) describe() string {
+	return fmt.Sprintf("%s (owner: %s, expiry: %s)", w.conf.Justification, w.conf.Owner, w.conf.Expiry)
+}
+
+// Apply: Apply waivers to results of validation of the Baseline
+//
+// Results of OUTCOME_FAIL matching a waiver not expired are changed to OUTCOME_WAIVED with InRisk of false.
+// Results matching an expired waiver are kept, with description of the waiver expired in Waiver.
+// It is safe to call on a nil pointor, with no waiver applied.
+// @param: b: Baseline of results
+// @param: results: Results of Baseline.Validate, modified in place
+// @return: Warnings of expired waivers matching results
+func (l *WaiverList) Apply(b *Baseline, results []*ValidateResult) []string {
+	if l == nil || b == nil {
+		return nil
+	}
+
+	now := _validatorClock()
+	expiredCount := make([]int, len(l.waivers))
+	for _, result := range results {
+		if result.Outcome != OUTCOME_FAIL {
+			continue
+		}
+
+		for i, w := range l.waivers {
+			if !w.match(b, result) {
+				continue
+			}
+
+			if !now.Before(w.expiry) {
+				expiredCount[i]++
+				if result.Waiver == "" {
+					result.Waiver = "expired: " + w.describe()
+				}
+				continue
+			}
+
+			result.Outcome = OUTCOME_WAIVED
+			result.InRisk = false
+			result.Waiver = w.describe()
+			break
+		}
+	}
+
+	var warnings []string
+	for i, count := range expiredCount {
+		if count > 0 {
+			warnings = append(warnings, fmt.Sprintf("waiver #%d of %s expired on %s, matching %d results in risk",
+				i, l.waivers[i].conf.Owner, l.waivers[i].conf.Expiry, count))
+		}
+	}
+
+	return warnings
+}
@@ -0,0 +1,213 @@
+// Waivers to suppress accepted risks in results of validation
+
+package framework
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	def "github.com/s3studio/cloud-bench-checker/pkg/definition"
+)
+
+func TestNewWaiverList(t *testing.T) {
+	validWaiver := def.ConfWaiver{
+		ResourceName:  "^public-",
+		Justification: "mock justification",
+		Owner:         "mock owner",
+		Expiry:        "2024-06-30",
+	}
+	fnWaiver := func(fnModify func(w *def.ConfWaiver)) *def.ConfWaiverFile {
+		w := validWaiver
+		fnModify(&w)
+		return &def.ConfWaiverFile{Waiver: []def.ConfWaiver{w}}
+	}
+
+	type args struct {
+		conf *def.ConfWaiverFile
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{"Valid result", args{fnWaiver(func(w *def.ConfWaiver) {})}, false},
+		{"Valid result of expiry in RFC3339", args{fnWaiver(func(w *def.ConfWaiver) { w.Expiry = "2024-06-30T12:00:00Z" })}, false},
+		{"Valid result of empty list", args{&def.ConfWaiverFile{}}, false},
+		{"nil pointor of definition", args{nil}, true},
+		{"justification required", args{fnWaiver(func(w *def.ConfWaiver) { w.Justification = "" })}, true},
+		{"owner required", args{fnWaiver(func(w *def.ConfWaiver) { w.Owner = "" })}, true},
+		{"condition required", args{fnWaiver(func(w *def.ConfWaiver) { w.ResourceName = "" })}, true},
+		{"expiry required", args{fnWaiver(func(w *def.ConfWaiver) { w.Expiry = "" })}, true},
+		{"invalid expiry", args{fnWaiver(func(w *def.ConfWaiver) { w.Expiry = "30/06/2024" })}, true},
+		{"invalid resource_name", args{fnWaiver(func(w *def.ConfWaiver) { w.ResourceName = "(" })}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewWaiverList(tt.args.conf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewWaiverList() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got == nil {
+				t.Errorf("NewWaiverList() = nil")
+			}
+		})
+	}
+}
+
+func TestLoadWaiverFile(t *testing.T) {
+	dir := t.TempDir()
+	validFile := filepath.Join(dir, "valid.yaml")
+	os.WriteFile(validFile, []byte(`waiver:
+  - resource_name: ^public-
+    justification: mock justification
+    owner: mock owner
+    expiry: 2024-06-30
+`), 0600)
+	invalidYaml := filepath.Join(dir, "invalid_yaml.yaml")
+	os.WriteFile(invalidYaml, []byte(`waiver: [`), 0600)
+	invalidWaiver := filepath.Join(dir, "invalid_waiver.yaml")
+	os.WriteFile(invalidWaiver, []byte(`waiver:
+  - resource_name: ^public-
+`), 0600)
+
+	type args struct {
+		pathname     string
+		confFilePath string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    int
+		wantErr bool
+	}{
+		{"Valid result", args{validFile, ""}, 1, false},
+		{"Valid result relative to conf file", args{"valid.yaml", filepath.Join(dir, "config.conf")}, 1, false},
+		{"Valid result of absolute path with conf file", args{validFile, "/mock/config.conf"}, 1, false},
+		{"failed to read file", args{filepath.Join(dir, "not_exists.yaml"), ""}, 0, true},
+		{"failed to load as yaml", args{invalidYaml, ""}, 0, true},
+		{"invalid waiver", args{invalidWaiver, ""}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadWaiverFile(tt.args.pathname, tt.args.confFilePath)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadWaiverFile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got.waivers) != tt.want {
+				t.Errorf("LoadWaiverFile() = %d waivers, want %d", len(got.waivers), tt.want)
+			}
+		})
+	}
+}
+
+func TestWaiverList_Apply(t *testing.T) {
+	setupValidatorClock(t, time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC))
+
+	b := NewBaseline(&def.ConfBaseline{
+		Tag:      []string{"prod"},
+		Metadata: map[string]string{"id": "1.1"},
+	}, nil, nil)
+	conf := def.ConfWaiverFile{Waiver: []def.ConfWaiver{
+		{
+			Tag:           []string{"test", "prod"},
+			CloudType:     def.ALIYUN_OSS,
+			ResourceName:  "^public-",
+			Justification: "public website",
+			Owner:         "web team",
+			Expiry:        "2024-06-30",
+		},
+		{
+			Metadata:      map[string]string{"id": "1.1"},
+			ResourceId:    "mock_expired",
+			Justification: "legacy",
+			Owner:         "ops team",
+			Expiry:        "2024-06-29",
+		},
+		{
+			Metadata:      map[string]string{"id": "1.2"},
+			ResourceId:    "mock_other_baseline",
+			Justification: "other baseline",
+			Owner:         "ops team",
+			Expiry:        "2025-01-01",
+		},
+	}}
+	l, err := NewWaiverList(&conf)
+	if err != nil {
+		t.Fatalf("NewWaiverList() error = %v", err)
+	}
+
+	type args struct {
+		b       *Baseline
+		results []*ValidateResult
+	}
+	tests := []struct {
+		name         string
+		l            *WaiverList
+		args         args
+		want         []*ValidateResult
+		wantWarnings []string
+	}{
+		{
+			"Valid result",
+			l,
+			args{b, []*ValidateResult{
+				{CloudType: def.ALIYUN_OSS, Id: "mock_id", Name: "public-site", InRisk: true, Outcome: OUTCOME_FAIL},
+				{CloudType: def.ALIYUN_OSS, Id: "mock_id", Name: "private-site", InRisk: true, Outcome: OUTCOME_FAIL},
+				{CloudType: def.ALIYUN_CLOUD, Id: "mock_id", Name: "public-site", InRisk: true, Outcome: OUTCOME_FAIL},
+				{CloudType: def.ALIYUN_OSS, Id: "mock_id", Name: "public-site", Outcome: OUTCOME_PASS},
+				{CloudType: def.ALIYUN_CLOUD, Id: "mock_other_baseline", InRisk: true, Outcome: OUTCOME_FAIL},
+			}},
+			[]*ValidateResult{
+				{CloudType: def.ALIYUN_OSS, Id: "mock_id", Name: "public-site", Outcome: OUTCOME_WAIVED,
+					Waiver: "public website (owner: web team, expiry: 2024-06-30)"},
+				{CloudType: def.ALIYUN_OSS, Id: "mock_id", Name: "private-site", InRisk: true, Outcome: OUTCOME_FAIL},
+				{CloudType: def.ALIYUN_CLOUD, Id: "mock_id", Name: "public-site", InRisk: true, Outcome: OUTCOME_FAIL},
+				{CloudType: def.ALIYUN_OSS, Id: "mock_id", Name: "public-site", Outcome: OUTCOME_PASS},
+				{CloudType: def.ALIYUN_CLOUD, Id: "mock_other_baseline", InRisk: true, Outcome: OUTCOME_FAIL},
+			},
+			nil,
+		},
+		{
+			"Valid result of expired waiver",
+			l,
+			args{b, []*ValidateResult{
+				{CloudType: def.ALIYUN_CLOUD, Id: "mock_expired", InRisk: true, Outcome: OUTCOME_FAIL},
+				{CloudType: def.AWS, Id: "mock_expired", InRisk: true, Outcome: OUTCOME_FAIL},
+			}},
+			[]*ValidateResult{
+				{CloudType: def.ALIYUN_CLOUD, Id: "mock_expired", InRisk: true, Outcome: OUTCOME_FAIL,
+					Waiver: "expired: legacy (owner: ops team, expiry: 2024-06-29)"},
+				{CloudType: def.AWS, Id: "mock_expired", InRisk: true, Outcome: OUTCOME_FAIL,
+					Waiver: "expired: legacy (owner: ops team, expiry: 2024-06-29)"},
+			},
+			[]string{"waiver #1 of ops team expired on 2024-06-29, matching 2 results in risk"},
+		},
+		{
+			"nil pointor of WaiverList",
+			nil,
+			args{b, []*ValidateResult{
+				{CloudType: def.ALIYUN_OSS, Id: "mock_id", Name: "public-site", InRisk: true, Outcome: OUTCOME_FAIL},
+			}},
+			[]*ValidateResult{
+				{CloudType: def.ALIYUN_OSS, Id: "mock_id", Name: "public-site", InRisk: true, Outcome: OUTCOME_FAIL},
+			},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotWarnings := tt.l.Apply(tt.args.b, tt.args.results)
+			if !reflect.DeepEqual(tt.args.results, tt.want) {
+				t.Errorf("WaiverList.Apply() results = %v, want %v", tt.args.results, tt.want)
+			}
+			if !reflect.DeepEqual(gotWarnings, tt.wantWarnings) {
+				t.Errorf("WaiverList.Apply() = %v, want %v", gotWarnings, tt.wantWarnings)
+			}
+		})
+	}
+}
@@ -47,6 +47,18 @@ const (
 
 	// Cloudtype4apiAzure captures enum value "azure"
 	Cloudtype4apiAzure Cloudtype4api = "azure"
+
+	// Cloudtype4apiAws captures enum value "aws"
+	Cloudtype4apiAws Cloudtype4api = "aws"
+
+	// Cloudtype4apiGcp captures enum value "gcp"
+	Cloudtype4apiGcp Cloudtype4api = "gcp"
+
+	// Cloudtype4apiOpenstack captures enum value "openstack"
+	Cloudtype4apiOpenstack Cloudtype4api = "openstack"
+
+	// Cloudtype4apiHTTPAPI captures enum value "http_api"
+	Cloudtype4apiHTTPAPI Cloudtype4api = "http_api"
 )
 
 // for schema
@@ -54,7 +66,7 @@ var cloudtype4apiEnum []interface{}
 
 func init() {
 	var res []Cloudtype4api
-	if err := json.Unmarshal([]byte(`["tencent_cloud","tencent_cos","aliyun","aliyun_oss","k8s","azure"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["tencent_cloud","tencent_cos","aliyun","aliyun_oss","k8s","azure","aws","gcp","openstack","http_api"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
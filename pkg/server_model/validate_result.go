@@ -23,17 +23,38 @@ type ValidateResult struct {
 	// cloud type
 	CloudType string `json:"cloud_type"`
 
+	// error message
+	ErrorMessage string `json:"error_message,omitempty"`
+
 	// metadata
 	Metadata map[string]string `json:"metadata,omitempty"`
 
+	// One of pass, fail, not_applicable, error and waived
+	Outcome string `json:"outcome"`
+
+	// reason
+	Reason string `json:"reason,omitempty"`
+
 	// resource id
 	ResourceID string `json:"resource_id"`
 
 	// resource in risk
 	ResourceInRisk bool `json:"resource_in_risk"`
 
+	// resource k8s context
+	ResourceK8sContext string `json:"resource_k8s_context,omitempty"`
+
 	// resource name
 	ResourceName string `json:"resource_name"`
+
+	// resource region
+	ResourceRegion string `json:"resource_region,omitempty"`
+
+	// resource subscription
+	ResourceSubscription string `json:"resource_subscription,omitempty"`
+
+	// waiver
+	Waiver string `json:"waiver,omitempty"`
 }
 
 // Validate validates this validate result
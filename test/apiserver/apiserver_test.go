@@ -15,12 +15,10 @@ import (
 	"runtime"
 	"testing"
 
-	"github.com/s3studio/cloud-bench-checker/internal"
 	swaggerserversrv "github.com/s3studio/cloud-bench-checker/internal/server"
 	"github.com/s3studio/cloud-bench-checker/internal/server/operations"
 	"github.com/s3studio/cloud-bench-checker/internal/server/operations/baseline"
 	"github.com/s3studio/cloud-bench-checker/internal/server/operations/listor"
-	"github.com/s3studio/cloud-bench-checker/pkg/auth"
 	"github.com/s3studio/cloud-bench-checker/pkg/connector"
 	"github.com/s3studio/cloud-bench-checker/pkg/server_model"
 
@@ -29,10 +27,10 @@ import (
 )
 
 const (
-	TESTSUITE_DIR_NAME     = "testsuite"
-	CONF_FILENAME          = "config.conf"
-	CLOUD_LIST_RESULT_FILE = "TencentCloudListResult.json"
-	TEST_RESULT_FILE       = "TestResult.json"
+	TESTSUITE_DIR_NAME = "testsuite"
+	CONF_FILENAME      = "config.conf"
+	CASSETTE_FILE      = "Cassette.json"
+	TEST_RESULT_FILE   = "TestResult.json"
 )
 
 var (
@@ -63,21 +61,17 @@ func setupEnvironment() {
 		})
 	_deferList = append([]func(){patchOsOpen.Reset}, _deferList...)
 
-	cloudListResultFile, _ := os.Open(filepath.Join(_testSuiteDir, CLOUD_LIST_RESULT_FILE))
-	defer cloudListResultFile.Close()
-	byFile, _ := io.ReadAll(cloudListResultFile)
-	var v any
-	json.Unmarshal(byFile, &v)
-	rm, _ := internal.JsonMarshal(v)
-	patches := gomonkey.ApplyFunc(connector.CallTencentCloud,
-		func(authProvider auth.IAuthProvider, service string, version string, action string, extraParam map[string]any) (*json.RawMessage, error) {
-			return rm, nil
-		})
-	_deferList = append([]func(){patches.Reset}, _deferList...)
+	// Calls of connectors are replayed from the cassette instead of the cloud
+	recorder, err := connector.NewRecorder(connector.RECORDER_REPLAY, filepath.Join(_testSuiteDir, CASSETTE_FILE))
+	if err != nil {
+		log.Fatalln(err)
+	}
+	connector.SetRecorder(recorder)
+	_deferList = append([]func(){func() { connector.SetRecorder(nil) }}, _deferList...)
 
 	testResultFile, _ := os.Open(filepath.Join(_testSuiteDir, TEST_RESULT_FILE))
 	defer testResultFile.Close()
-	byFile, _ = io.ReadAll(testResultFile)
+	byFile, _ := io.ReadAll(testResultFile)
 	json.Unmarshal(byFile, &_testResult)
 }
 
@@ -351,6 +345,27 @@ func TestApiserverIntegration(t *testing.T) {
 		}
 	})
 
+	t.Run("POST /baseline/validate with invalid param", func(t *testing.T) {
+		if resGetProp == nil {
+			t.Fatal("Preconditions not met")
+		}
+
+		jsonListData, _ := json.Marshal(resGetProp)
+		req := httptest.NewRequest(
+			"POST",
+			fmt.Sprintf("/api/baseline/validate?id=%d&param=invalid", baselineId),
+			bytes.NewReader(jsonListData),
+		)
+		req.Header.Set("Content-Type", "application/json")
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+
+		if resp.Code != http.StatusBadRequest {
+			t.Errorf("%s = %d, want %d", t.Name(), resp.Code, http.StatusBadRequest)
+			return
+		}
+	})
+
 	// The following API is not necessary for client-side
 	t.Run("GET /listor/getIds", func(t *testing.T) {
 		req := httptest.NewRequest("GET", "/api/listor/getIds", nil)
@@ -9,12 +9,16 @@ import (
 )
 
 var (
-	Test_conf_env     = def.ConfProfile{"tencent": "$ENV"}
-	Test_conf_file    = def.ConfProfile{"tencent": "file"}
-	Test_conf_aliyun  = def.ConfProfile{"aliyun": "$ENV"}
-	Test_conf_k8s     = def.ConfProfile{"k8s": "$ENV"}
-	Test_conf_azure   = def.ConfProfile{"azure": "$ENV"}
-	Test_conf_invalid = def.ConfProfile{}
+	Test_conf_env       = def.ConfProfile{"tencent": "$ENV"}
+	Test_conf_file      = def.ConfProfile{"tencent": "file"}
+	Test_conf_aliyun    = def.ConfProfile{"aliyun": "$ENV"}
+	Test_conf_k8s       = def.ConfProfile{"k8s": "$ENV"}
+	Test_conf_azure     = def.ConfProfile{"azure": "$ENV"}
+	Test_conf_aws       = def.ConfProfile{"aws": "$ENV"}
+	Test_conf_gcp       = def.ConfProfile{"gcp": "$ENV"}
+	Test_conf_openstack = def.ConfProfile{"openstack": "$ENV"}
+	Test_conf_http_api  = def.ConfProfile{"http_api": "$ENV"}
+	Test_conf_invalid   = def.ConfProfile{}
 )
 
 type MockKeyNotSetAuthProvider struct{}